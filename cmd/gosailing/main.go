@@ -1,19 +1,121 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mpihlak/gosailing2/pkg/game"
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/game/world"
 )
 
 func main() {
+	supersample := flag.Int("supersample", 1, "Internal render resolution multiplier (2 = crisper lines on high-DPI displays)")
+	opponents := flag.Int("opponents", 0, "Number of AI opponents (0-6)")
+	aiSkill := flag.String("ai-skill", "club", "AI opponent skill tier: novice, club or expert")
+	current := flag.Float64("current", 0, "Tidal current speed in knots (0 = slack water)")
+	currentDir := flag.Float64("current-dir", 90, "Direction the current flows toward in degrees (0 = North)")
+	boat := flag.String("boat", "keelboat", "Boat class: keelboat or dinghy")
+	gusts := flag.Float64("gusts", world.DefaultGustConfig().Frequency, "Average new gusts and lulls per minute (0 = steady breeze)")
+	gustStrength := flag.Float64("gust-strength", world.DefaultGustConfig().Strength, "Largest wind speed change in a gust or lull, knots")
+	shadowLength := flag.Float64("shadow-length", world.DefaultShadowConfig().Length, "Meters the committee boat's wind shadow reaches downwind (0 = no wind shadows)")
+	shadowStrength := flag.Float64("shadow-strength", world.DefaultShadowConfig().Strength, "Fraction of wind speed lost just behind the committee boat (0-1)")
+	windLog := flag.String("wind-log", "", "Sail in the wind recorded in this JSON wind log instead of live wind")
+	recordWind := flag.String("record-wind", "", "Save the wind sailed in to this JSON wind log on exit")
+	gate := flag.Bool("gate", false, "Sail a windward-leeward course through a leeward gate before finishing")
+	laps := flag.Int("laps", 0, "Times round the course before the finish (0 = the course's setting, else 1)")
+	boundary := flag.String("boundary", "", "Edge of the sailing area: wall (bounce off) or shallows (slowed, then aground); empty = the course's setting")
+	courseFile := flag.String("course", "", "Sail the course laid out in this JSON file (see README)")
+	leaderboardURL := flag.String("leaderboard", "", "Share scores on the leaderboard server at this URL (see README)")
+	autosave := flag.Bool("autosave", true, "Save each finished result at once, so a crash before the scoreboard can't lose it")
+	nameBlocklist := flag.String("name-blocklist", "", "Reject leaderboard names containing a word listed in this file (one per line)")
+	flag.Parse()
+
+	skill, err := game.ParseAISkill(*aiSkill)
+	if err != nil {
+		log.Fatal(err)
+	}
+	class, err := objects.ParseBoatClass(*boat)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle("Go Sailing!")
 
-	g := game.NewGame()
+	course := game.DefaultCourseConfig()
+	if *gate {
+		course = game.WindwardLeewardCourseConfig()
+	}
+	if *courseFile != "" {
+		f, err := os.Open(*courseFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		course, err = game.LoadCourse(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", *courseFile, err)
+		}
+	}
+	if *laps > 0 {
+		course.Laps = *laps
+	}
+	if *boundary != "" {
+		if err := course.Boundary.UnmarshalText([]byte(*boundary)); err != nil {
+			log.Fatalf("-boundary: %v", err)
+		}
+	}
+	g, err := game.NewGameWithConfig(course, class)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g.SetStore(game.NewLocalStore())
+	g.SetSupersampling(*supersample)
+	g.SetAutosave(*autosave)
+	g.SetOpponents(*opponents, skill)
+	g.SetGusts(world.GustConfig{Frequency: *gusts, Strength: *gustStrength})
+	g.SetWindShadow(world.ShadowConfig{Length: *shadowLength, Strength: *shadowStrength})
+	if *leaderboardURL != "" {
+		g.SetLeaderboard(game.NewRESTBackend(*leaderboardURL))
+	}
+	if *nameBlocklist != "" {
+		f, err := os.Open(*nameBlocklist)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filter, err := game.LoadNameFilter(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", *nameBlocklist, err)
+		}
+		g.SetNameFilter(filter)
+	}
+	if *current > 0 {
+		g.SetCurrent(&world.ConstantCurrent{Direction: *currentDir, Speed: *current})
+	}
 
-	if err := ebiten.RunGame(g); err != nil {
+	if *windLog != "" {
+		samples, err := world.LoadWindLog(*windLog)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.SetWindReplay(samples)
+	}
+
+	// Offer a finished race that was lost before it reached the scoreboard
+	g.RecoverPendingResult()
+
+	err = ebiten.RunGame(g)
+	if *recordWind != "" {
+		if saveErr := world.SaveWindLog(*recordWind, g.WindLog()); saveErr != nil {
+			log.Print(saveErr)
+		}
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,131 @@
+package dashboard
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Compass rose placement (below the dashboard text, right side of the screen)
+const (
+	compassRoseRadius  = 50.0
+	compassRoseOffsetX = 80.0  // Distance of the center from the right screen edge
+	compassRoseY       = 360.0 // Center Y on screen
+)
+
+// Velocities below this (pixels/frame) are too small to give a reliable direction of motion
+const minMotionVelocity = 1e-4
+
+var (
+	trueWindColor     = color.RGBA{192, 192, 192, 255} // Same light gray as the wind barbs
+	apparentWindColor = color.RGBA{255, 220, 0, 255}   // Yellow
+)
+
+// apparentWind combines the true wind with the headwind from the boat's own motion.
+// Directions are where the wind blows FROM (degrees), speeds in knots.
+func apparentWind(twd, tws, heading, boatSpeed float64) (awd, aws float64) {
+	twdRad := twd * math.Pi / 180
+	headingRad := heading * math.Pi / 180
+
+	// Sum the "from" vectors (east, north components)
+	east := tws*math.Sin(twdRad) + boatSpeed*math.Sin(headingRad)
+	north := tws*math.Cos(twdRad) + boatSpeed*math.Cos(headingRad)
+
+	aws = math.Sqrt(east*east + north*north)
+	if aws < 0.001 {
+		return twd, 0
+	}
+	awd = math.Mod(math.Atan2(east, north)*180/math.Pi+360, 360)
+	return awd, aws
+}
+
+// CalculateApparentWind returns the apparent wind direction (degrees, where it blows FROM)
+// and speed (knots) felt on the moving boat. The boat's own wind comes from the way it
+// is actually moving through the water, which differs from the heading by the leeway.
+func (d *Dashboard) CalculateApparentWind() (awd, aws float64) {
+	twd, tws := d.Wind.GetWind(d.Boat.Pos)
+	return apparentWind(twd, tws, d.motionDirection(), d.Boat.Speed)
+}
+
+// CalculateApparentWindAngle returns the apparent wind angle relative to the bow
+// (degrees, positive on port like TWA) and the apparent wind speed (knots)
+func (d *Dashboard) CalculateApparentWindAngle() (awa, aws float64) {
+	awd, aws := d.CalculateApparentWind()
+	awa = d.Boat.Heading - awd
+	if awa < -180 {
+		awa += 360
+	} else if awa > 180 {
+		awa -= 360
+	}
+	return awa, aws
+}
+
+// motionDirection returns the direction the boat is moving through the water (degrees),
+// falling back to the heading when it is barely moving
+func (d *Dashboard) motionDirection() float64 {
+	if math.Hypot(d.Boat.VelX, d.Boat.VelY) < minMotionVelocity {
+		return d.Boat.Heading
+	}
+	return math.Mod(math.Atan2(d.Boat.VelX, -d.Boat.VelY)*180/math.Pi+360, 360)
+}
+
+// windArrow returns the tail (on the rim, where the wind comes from) and head (near the
+// center) of a wind arrow on a compass rose
+func windArrow(center geometry.Point, radius, direction float64) (tail, head geometry.Point) {
+	dirRad := direction * math.Pi / 180
+	dx, dy := math.Sin(dirRad), -math.Cos(dirRad) // Y is inverted
+	tail = geometry.Point{X: center.X + radius*dx, Y: center.Y + radius*dy}
+	head = geometry.Point{X: center.X + 0.2*radius*dx, Y: center.Y + 0.2*radius*dy}
+	return tail, head
+}
+
+// drawArrow draws a wind arrow with an arrowhead at its head
+func drawArrow(screen *ebiten.Image, tail, head geometry.Point, arrowColor color.Color) {
+	vector.StrokeLine(screen, float32(tail.X), float32(tail.Y), float32(head.X), float32(head.Y), 2, arrowColor, false)
+
+	// Arrowhead: two short lines angled back from the head
+	angle := math.Atan2(head.Y-tail.Y, head.X-tail.X)
+	for _, side := range []float64{-1, 1} {
+		a := angle + math.Pi - side*math.Pi/6
+		x := head.X + 8*math.Cos(a)
+		y := head.Y + 8*math.Sin(a)
+		vector.StrokeLine(screen, float32(head.X), float32(head.Y), float32(x), float32(y), 2, arrowColor, false)
+	}
+}
+
+// drawCompassRose draws a compass with the boat heading, the true wind arrow and,
+// when showApparent is set, the apparent wind arrow
+func (d *Dashboard) drawCompassRose(screen *ebiten.Image, showApparent bool) {
+	center := geometry.Point{X: float64(screen.Bounds().Dx()) - compassRoseOffsetX, Y: compassRoseY}
+	r := compassRoseRadius
+
+	vector.DrawFilledCircle(screen, float32(center.X), float32(center.Y), float32(r+12), color.RGBA{0, 0, 0, 100}, false)
+	vector.StrokeCircle(screen, float32(center.X), float32(center.Y), float32(r), 1, color.White, false)
+
+	// Cardinal labels just outside the rim
+	ebitenutil.DebugPrintAt(screen, "N", int(center.X)-3, int(center.Y-r)-16)
+	ebitenutil.DebugPrintAt(screen, "S", int(center.X)-3, int(center.Y+r)+1)
+	ebitenutil.DebugPrintAt(screen, "E", int(center.X+r)+3, int(center.Y)-8)
+	ebitenutil.DebugPrintAt(screen, "W", int(center.X-r)-10, int(center.Y)-8)
+
+	// Boat heading as a line from the center to the rim
+	headingRad := d.Boat.Heading * math.Pi / 180
+	vector.StrokeLine(screen, float32(center.X), float32(center.Y),
+		float32(center.X+r*math.Sin(headingRad)), float32(center.Y-r*math.Cos(headingRad)),
+		1, color.RGBA{173, 216, 230, 255}, false)
+
+	twd, _ := d.Wind.GetWind(d.Boat.Pos)
+	tail, head := windArrow(center, r, twd)
+	drawArrow(screen, tail, head, trueWindColor)
+
+	if showApparent {
+		awd, _ := d.CalculateApparentWind()
+		tail, head := windArrow(center, r, awd)
+		drawArrow(screen, tail, head, apparentWindColor)
+		ebitenutil.DebugPrintAt(screen, "TW gray  AW yellow", int(center.X)-55, int(center.Y+r)+16)
+	}
+}
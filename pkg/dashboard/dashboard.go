@@ -2,11 +2,15 @@ package dashboard
 
 import (
 	"fmt"
+	"image/color"
 	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/mpihlak/gosailing2/pkg/game/objects"
 	"github.com/mpihlak/gosailing2/pkg/game/world"
 	"github.com/mpihlak/gosailing2/pkg/geometry"
@@ -16,21 +20,61 @@ type Dashboard struct {
 	Boat       *objects.Boat
 	Wind       world.Wind
 	StartTime  time.Time
-	LineStart  geometry.Point // Pin end of starting line
-	LineEnd    geometry.Point // Committee end of starting line
-	UpwindMark geometry.Point // Upwind mark position
+	Line       *world.StartLine // Start/finish line (shared with the arena)
+	UpwindMark geometry.Point   // Upwind mark position
+	// Display options
+	ShowApparentWind bool // Draw the apparent wind arrow alongside true wind on the compass rose
+	ShowVMC          bool // Show VMG to the next mark (VMC) alongside VMG to the wind
+	ShowTargetSpeed  bool // Show the polar target speed next to the actual speed
+	// Penalty turns owed after touching a mark (none while PenaltyRequired is 0)
+	PenaltyTurned   float64 // Degrees turned so far
+	PenaltyRequired float64 // Degrees that must be turned to exonerate
+	// Leeward gate sailed through after the upwind mark (nil = no gate)
+	Gate       *world.Gate
+	GatePassed bool // Whether the boat has passed between the gate marks
+	// Lap being sailed out of the race's total (no lap counter for a single lap)
+	Lap  int
+	Laps int
+	// Race phase name ("Beat", "Run", ...) and the leg being sailed out of the race's legs
+	// (no leg shown while Leg is 0)
+	Phase string
+	Leg   int
+	Legs  int
+	// Where the boat is in the race ("Running to gate", ...), shown under the phase
+	Status string
+	// Show whether the boat is lifted or headed (hidden while the wind is still biasing the start)
+	ShowWindShift bool
 }
 
+// StartPanel holds the pre-start readouts shown together in the start panel
+type StartPanel struct {
+	DistanceToLine   float64 // Perpendicular distance from bow to line (meters, negative on course side)
+	TimeToLine       float64 // Seconds to reach the line at current closing speed (+Inf if not closing)
+	TimeToLineAtVMG  float64 // Seconds to reach the line beating at the best upwind VMG
+	TimeToBurn       float64 // Seconds to spare before the gun (negative = late, -Inf if not closing)
+	FavoredEnd       string  // "Pin", "Committee" or "Square"
+	FavoredAdvantage float64 // How much further upwind the favored end is (meters)
+	FavoredDegrees   float64 // How far the line is turned from square to the wind (degrees)
+	BoatLength       float64 // Hull length for showing the advantage in boat lengths (meters)
+	LineLength       float64 // Length of the start line (meters)
+	DistanceToCross  float64 // Distance along heading to the line crossing point (-1 if not crossing)
+	TimeToCross      float64 // Time to reach the crossing point (+Inf if not crossing)
+}
+
+// Polar targets below this (knots) mean the boat is head to wind with no meaningful target
+const inIronsTargetSpeed = 0.1
+
 // CalculateDistanceToLine calculates the perpendicular distance from boat's bow to the starting line
 // Returns negative distance when boat is on the course side (above) of the line
 func (d *Dashboard) CalculateDistanceToLine() float64 {
 	bowPos := d.Boat.GetBowPosition()
+	pin, committee := d.Line.Ends()
 
 	// Calculate line equation (Ax + By + C = 0)
-	// For line from LineStart to LineEnd
-	A := d.LineEnd.Y - d.LineStart.Y
-	B := d.LineStart.X - d.LineEnd.X
-	C := d.LineEnd.X*d.LineStart.Y - d.LineStart.X*d.LineEnd.Y
+	// For line from pin to committee
+	A := committee.Y - pin.Y
+	B := pin.X - committee.X
+	C := committee.X*pin.Y - pin.X*committee.Y
 
 	// Calculate signed distance (without absolute value)
 	denominator := math.Sqrt(A*A + B*B)
@@ -46,6 +90,114 @@ func (d *Dashboard) CalculateDistanceToLine() float64 {
 	return -signedDistance
 }
 
+// CalculateTimeToLine calculates the seconds needed for the bow to reach the starting line
+// at the boat's current velocity component towards the course side.
+// Returns +Inf when the boat is not closing on the line.
+func (d *Dashboard) CalculateTimeToLine() float64 {
+	distance := d.CalculateDistanceToLine()
+	if distance <= 0 {
+		return 0 // Already on or over the line
+	}
+
+	// Unit normal of the line pointing towards the course side
+	pin, committee := d.Line.Ends()
+	dx := committee.X - pin.X
+	dy := committee.Y - pin.Y
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length == 0 {
+		return math.Inf(1)
+	}
+	normalX := dy / length
+	normalY := -dx / length
+
+	// Closing speed in meters per second (velocity is in pixels/frame at 60 FPS, 1 pixel = 1 meter)
+	closingSpeed := (d.Boat.VelX*normalX + d.Boat.VelY*normalY) * 60.0
+	if closingSpeed < 0.01 {
+		return math.Inf(1)
+	}
+
+	return distance / closingSpeed
+}
+
+// CalculateTimeToLineAtVMG estimates the seconds for the bow to reach the starting line
+// beating at the best upwind VMG for the local wind, the pace a well-sailed approach makes
+// whatever the boat is doing now. Returns +Inf when the polars give no upwind VMG.
+func (d *Dashboard) CalculateTimeToLineAtVMG() float64 {
+	distance := d.CalculateDistanceToLine()
+	if distance <= 0 {
+		return 0 // Already on or over the line
+	}
+	_, windSpeed := d.Wind.GetWind(d.Boat.Pos)
+	vmg := objects.MetersPerSecond(d.bestBeatVMG(windSpeed))
+	if vmg < 0.01 {
+		return math.Inf(1)
+	}
+	return distance / vmg
+}
+
+// CalculateLineBias determines which end of the starting line is favored (further upwind)
+// and by how many meters. Returns "Square" when neither end has a meaningful advantage.
+func (d *Dashboard) CalculateLineBias() (string, float64) {
+	favored, _, meters := d.lineBias()
+	return favored, meters
+}
+
+// lineBias returns the favored end of the line in the wind at its middle, the degrees the
+// line is off square and the meters the favored end is further upwind
+func (d *Dashboard) lineBias() (string, float64, float64) {
+	windDir, _ := d.Wind.GetWind(d.Line.Midpoint())
+	return d.Line.Bias(windDir)
+}
+
+// favoredReadout formats the line bias ("Pin favored by 8° (2 lengths)")
+func favoredReadout(favored string, degrees, meters, boatLength float64) string {
+	if favored == "Square" {
+		return "Square line"
+	}
+	return fmt.Sprintf("%s favored by %.0f° (%.1f lengths)", favored, degrees, meters/boatLength)
+}
+
+// secondsReadout formats a time to the line, "--" when the boat isn't getting there
+func secondsReadout(seconds float64) string {
+	if math.IsInf(seconds, 0) {
+		return "--"
+	}
+	return fmt.Sprintf("%.1fs", seconds)
+}
+
+// burnReadout says whether the boat is early or late for the gun at its present pace
+// ("Early by 4.2s"), or "--" when it is sailing away from the line
+func burnReadout(burn float64) string {
+	switch {
+	case math.IsInf(burn, 0):
+		return "Early/late: --"
+	case burn >= 0:
+		return fmt.Sprintf("Early by %.1fs", burn)
+	default:
+		return fmt.Sprintf("Late by %.1fs", -burn)
+	}
+}
+
+// CalculateStartPanel computes the start panel readouts for the given time remaining to the gun
+func (d *Dashboard) CalculateStartPanel(remaining time.Duration) StartPanel {
+	timeToLine := d.CalculateTimeToLine()
+	favoredEnd, degrees, advantage := d.lineBias()
+
+	return StartPanel{
+		DistanceToLine:   d.CalculateDistanceToLine(),
+		TimeToLine:       timeToLine,
+		TimeToLineAtVMG:  d.CalculateTimeToLineAtVMG(),
+		TimeToBurn:       remaining.Seconds() - timeToLine,
+		FavoredEnd:       favoredEnd,
+		FavoredAdvantage: advantage,
+		FavoredDegrees:   degrees,
+		BoatLength:       d.Boat.HullLength(),
+		LineLength:       d.Line.Length(),
+		DistanceToCross:  -1,
+		TimeToCross:      math.Inf(1),
+	}
+}
+
 // CalculateVMG calculates the current VMG (Velocity Made Good) towards wind
 func (d *Dashboard) CalculateVMG() float64 {
 	windDir, _ := d.Wind.GetWind(d.Boat.Pos)
@@ -66,6 +218,164 @@ func (d *Dashboard) CalculateVMG() float64 {
 	return vmg
 }
 
+// CalculateTargetSpeed returns the polar speed for the boat's actual TWA in the local wind
+func (d *Dashboard) CalculateTargetSpeed() float64 {
+	windDir, windSpeed := d.Wind.GetWind(d.Boat.Pos)
+	twa := d.Boat.Heading - windDir
+	if twa < -180 {
+		twa += 360
+	} else if twa > 180 {
+		twa -= 360
+	}
+
+	target := d.Boat.PolarSpeed(twa, windSpeed)
+	if math.IsNaN(target) || math.IsInf(target, 0) {
+		return 0.0
+	}
+	return target
+}
+
+// speedReadout formats the actual speed next to the polar target ("Speed: 6.2 / 6.8 kts").
+// In irons there is no target to sail to, so it is shown as "--".
+func speedReadout(speed, target float64) string {
+	if target < inIronsTargetSpeed {
+		return fmt.Sprintf("Speed: %.1f / -- kts", speed)
+	}
+	return fmt.Sprintf("Speed: %.1f / %.1f kts", speed, target)
+}
+
+// groundSpeedReadout compares speed over ground with speed through the water
+// ("SOG/STW: 5.2 / 6.2 kts"), which differ by the current
+func groundSpeedReadout(sog, stw float64) string {
+	return fmt.Sprintf("SOG/STW: %.1f / %.1f kts", sog, stw)
+}
+
+// Width of the penalty turn progress bar in characters
+const penaltyBarWidth = 10
+
+// penaltyReadout formats the penalty turns owed with a progress bar
+// ("PENALTY: do 720" over "[###-------] 270°")
+func penaltyReadout(turned, required float64) string {
+	filled := int(math.Min(1, math.Max(0, turned/required)) * penaltyBarWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", penaltyBarWidth-filled)
+	return fmt.Sprintf("PENALTY: do %.0f\n[%s] %.0f°", required, bar, turned)
+}
+
+// CalculateVMC calculates the velocity made good towards a target point (VMC)
+func (d *Dashboard) CalculateVMC(target geometry.Point) float64 {
+	return vmc(d.Boat.Speed, d.Boat.Heading, geometry.Bearing(d.Boat.Pos, target))
+}
+
+// FindBestVMC searches all headings for the fastest way toward target at the current wind
+// speed, returning the best VMC in knots and the heading that gives it
+func (d *Dashboard) FindBestVMC(target geometry.Point) (float64, float64) {
+	windDir, windSpeed := d.Wind.GetWind(d.Boat.Pos)
+	bearing := geometry.Bearing(d.Boat.Pos, target)
+
+	bestVMC, bestHeading := 0.0, bearing
+	for heading := 0.0; heading < 360; heading += 1.0 {
+		twa := math.Mod(heading-windDir+540, 360) - 180
+		v := vmc(d.Boat.Polars.GetBoatSpeed(twa, windSpeed), heading, bearing)
+		if v > bestVMC {
+			bestVMC, bestHeading = v, heading
+		}
+	}
+	return bestVMC, bestHeading
+}
+
+// vmcReadout formats VMC toward the next mark next to the best achievable VMC and the
+// heading to steer for it ("VMC (mark): 5.2 kts\nBest VMC: 6.0 kts @ 352°")
+func vmcReadout(current, best, bestHeading float64) string {
+	return fmt.Sprintf("VMC (mark): %.1f kts\nBest VMC: %.1f kts @ %03.0f°", current, best, bestHeading)
+}
+
+// vmc returns the component of speed along the bearing: VMC = speed * cos(heading - bearing)
+func vmc(speed, heading, bearing float64) float64 {
+	v := speed * math.Cos((heading-bearing)*math.Pi/180)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0.0
+	}
+	return v
+}
+
+// runningToGate reports whether the boat has rounded the mark and still has to pass the leeward gate
+func (d *Dashboard) runningToGate(markRounded bool) bool {
+	return markRounded && d.Gate != nil && !d.GatePassed
+}
+
+// nextMark returns the point the boat is racing to: the upwind mark, the middle of any
+// leeward gate, then the middle of the finish line
+func (d *Dashboard) nextMark(markRounded bool) geometry.Point {
+	if d.runningToGate(markRounded) {
+		return d.Gate.Midpoint()
+	}
+	if markRounded {
+		return d.Line.Midpoint()
+	}
+	return d.UpwindMark
+}
+
+// CalculateDistanceToMark returns the distance in meters from the bow to a target point
+func (d *Dashboard) CalculateDistanceToMark(target geometry.Point) float64 {
+	bow := d.Boat.GetBowPosition()
+	return math.Hypot(target.X-bow.X, target.Y-bow.Y)
+}
+
+// markReadout formats the distance and bearing from the bow to the point the boat is racing
+// to ("To Mark: 420m @ 352°"), switching to the gate and then the finish once the mark is rounded
+func (d *Dashboard) markReadout(markRounded bool) string {
+	label := "To Mark"
+	if d.runningToGate(markRounded) {
+		label = "To Gate"
+	} else if markRounded {
+		label = "To Finish"
+	}
+	target := d.nextMark(markRounded)
+	bearing := geometry.Bearing(d.Boat.GetBowPosition(), target)
+	return fmt.Sprintf("%s: %.0fm @ %03.0f°", label, d.CalculateDistanceToMark(target), bearing)
+}
+
+// CalculateCrossTrackError returns how far the boat is off the rhumb line from the middle
+// of the start line to the upwind mark, in meters: positive to the right of the line looking
+// up the course, negative to the left
+func (d *Dashboard) CalculateCrossTrackError() float64 {
+	start := d.Line.Midpoint()
+	dx, dy := d.UpwindMark.X-start.X, d.UpwindMark.Y-start.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return 0
+	}
+
+	// Project onto the unit vector pointing to the right of the rhumb line
+	return ((d.Boat.Pos.X-start.X)*-dy + (d.Boat.Pos.Y-start.Y)*dx) / length
+}
+
+// lapReadout formats the lap counter ("Lap 2 of 3"), empty for a single-lap race
+func lapReadout(lap, laps int) string {
+	if laps <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("Lap %d of %d", lap, laps)
+}
+
+// phaseReadout formats the race phase with the leg being sailed ("Leg 1 of 2: Beat"),
+// or just the phase outside the legs
+func phaseReadout(phase string, leg, legs int) string {
+	if leg <= 0 || legs <= 1 {
+		return "Phase: " + phase
+	}
+	return fmt.Sprintf("Leg %d of %d: %s", leg, legs, phase)
+}
+
+// crossTrackReadout formats the cross-track error with the side of the rhumb line ("XTE: 45m R")
+func crossTrackReadout(xte float64) string {
+	side := "R"
+	if xte < 0 {
+		side = "L"
+	}
+	return fmt.Sprintf("XTE: %.0fm %s", math.Abs(xte), side)
+}
+
 // FindBestVMG finds the best VMG achievable for current sailing mode (beat or run)
 func (d *Dashboard) FindBestVMG() float64 {
 	windDir, windSpeed := d.Wind.GetWind(d.Boat.Pos)
@@ -81,15 +391,7 @@ func (d *Dashboard) FindBestVMG() float64 {
 
 	if absTWA < 90 {
 		// Upwind sailing - find best beat VMG (positive VMG towards wind)
-		for angle := 30.0; angle <= 90.0; angle += 1.0 {
-			speed := d.Boat.Polars.GetBoatSpeed(angle, windSpeed)
-			angleRad := angle * math.Pi / 180
-			vmg := speed * math.Cos(angleRad)
-
-			if vmg > bestVMG {
-				bestVMG = vmg
-			}
-		}
+		bestVMG = d.bestBeatVMG(windSpeed)
 	} else {
 		// Downwind sailing - find best run VMG (negative VMG away from wind)
 		for angle := 90.0; angle <= 180.0; angle += 1.0 {
@@ -106,6 +408,21 @@ func (d *Dashboard) FindBestVMG() float64 {
 	return bestVMG
 }
 
+// bestBeatVMG returns the best upwind VMG (knots) the polars give in windSpeed
+func (d *Dashboard) bestBeatVMG(windSpeed float64) float64 {
+	bestVMG := 0.0
+	for angle := 30.0; angle <= 90.0; angle += 1.0 {
+		speed := d.Boat.Polars.GetBoatSpeed(angle, windSpeed)
+		angleRad := angle * math.Pi / 180
+		vmg := speed * math.Cos(angleRad)
+
+		if vmg > bestVMG {
+			bestVMG = vmg
+		}
+	}
+	return bestVMG
+}
+
 func (d *Dashboard) Draw(screen *ebiten.Image, raceStarted bool, isOCS bool, timerDuration time.Duration, elapsedTime time.Duration, hasCrossedLine bool, secondsLate float64, speedPercentage float64, markRounded bool, raceFinished bool, distanceToLineCrossing float64, timeToCross float64, penaltyCount int, distanceSailed float64) {
 	windDir, windSpeed := d.Wind.GetWind(d.Boat.Pos)
 	twa := d.Boat.Heading - windDir
@@ -115,10 +432,27 @@ func (d *Dashboard) Draw(screen *ebiten.Image, raceStarted bool, isOCS bool, tim
 		twa -= 360
 	}
 
+	awa, aws := d.CalculateApparentWindAngle()
 	distanceToLine := d.CalculateDistanceToLine()
 	currentVMG := d.CalculateVMG()
 	targetVMG := d.FindBestVMG()
 
+	speedLine := fmt.Sprintf("Speed: %.1f kts", d.Boat.Speed)
+	if d.ShowTargetSpeed {
+		speedLine = speedReadout(d.Boat.Speed, d.CalculateTargetSpeed())
+	}
+	if d.Boat.Current != nil {
+		speedLine += "\n" + groundSpeedReadout(d.Boat.SOG, d.Boat.Speed)
+	}
+
+	// VMG to the wind, and optionally VMG to the next mark to decide between footing and pinching
+	vmgLine := fmt.Sprintf("VMG: %.1f kts", currentVMG)
+	if d.ShowVMC {
+		target := d.nextMark(markRounded)
+		bestVMC, bestHeading := d.FindBestVMC(target)
+		vmgLine += "\n" + vmcReadout(d.CalculateVMC(target), bestVMC, bestHeading)
+	}
+
 	// Base dashboard message - show distance sailed after line crossing, otherwise distance to line
 	var distanceLabel string
 	var distanceValue float64
@@ -131,43 +465,94 @@ func (d *Dashboard) Draw(screen *ebiten.Image, raceStarted bool, isOCS bool, tim
 	}
 
 	msg := fmt.Sprintf(
-		"Speed: %.1f kts\nHeading: %.0f°\nTWA: %.0f°\nTWD: %.0f°\nTWS: %.1f kts\n%s: %.0fm\nVMG: %.1f kts\nTarget VMG: %.1f kts",
-		d.Boat.Speed, d.Boat.Heading, twa, windDir, windSpeed, distanceLabel, distanceValue, currentVMG, targetVMG,
+		"%s\nHeading: %.0f°\nCOG: %.0f°\nTWA: %.0f°\nTWD: %.0f°\nTWS: %.1f kts\nAWA: %.0f°\nAWS: %.1f kts\n%s: %.0fm\n%s\nTarget VMG: %.1f kts",
+		speedLine, d.Boat.Heading, d.Boat.COG, twa, windDir, windSpeed, awa, aws, distanceLabel, distanceValue, vmgLine, targetVMG,
 	)
 
-	// Add distance to line crossing point during pre-start
-	if !raceStarted && distanceToLineCrossing >= 0 {
-		msg += fmt.Sprintf("\nDist to Cross: %.0fm", distanceToLineCrossing)
-		// Add time to cross
-		if math.IsInf(timeToCross, 1) {
-			msg += "\nTime to Cross: ∞"
-		} else {
-			msg += fmt.Sprintf("\nTime to Cross: %.1fs", timeToCross)
-		}
-	}
-
 	// Add line crossing information if boat has crossed
 	if hasCrossedLine {
 		msg += fmt.Sprintf("\nLate: %.1f sec\n%% target speed: %.1f%%", secondsLate, speedPercentage)
 	}
 
-	// Add race progress information
-	if raceStarted {
-		if raceFinished {
-			msg += "\nStatus: FINISHED! 🏆"
-		} else if markRounded {
-			msg += "\nStatus: Mark rounded ✓"
-		} else if hasCrossedLine {
-			msg += "\nStatus: Racing to mark ⛵"
-		} else {
-			msg += "\nStatus: Must cross start line"
+	// Distance and bearing to the mark (or finish) being raced to, and on the beat how far
+	// off the direct line to the mark the boat is
+	if raceStarted && !raceFinished {
+		msg += "\n" + d.markReadout(markRounded)
+		if !markRounded {
+			msg += "\n" + crossTrackReadout(d.CalculateCrossTrackError())
 		}
 	}
 
+	// Add race progress information
+	if lap := lapReadout(d.Lap, d.Laps); lap != "" && raceStarted && !raceFinished {
+		msg += "\n" + lap
+	}
+	if raceStarted && d.Phase != "" {
+		msg += "\n" + phaseReadout(d.Phase, d.Leg, d.Legs)
+	}
+	if raceStarted && d.Status != "" {
+		msg += "\nStatus: " + d.Status
+	}
+
 	// Add penalty display
 	if penaltyCount > 0 {
 		msg += fmt.Sprintf("\nPenalties: %d", penaltyCount)
 	}
+	if d.PenaltyRequired > 0 {
+		msg += "\n" + penaltyReadout(d.PenaltyTurned, d.PenaltyRequired)
+	}
 
 	ebitenutil.DebugPrintAt(screen, msg, screen.Bounds().Dx()-150, 10)
+
+	d.drawCompassRose(screen, d.ShowApparentWind)
+	d.drawTack(screen)
+	if d.ShowWindShift {
+		d.drawWindShift(screen)
+	}
+	d.drawSpinnaker(screen)
+
+	// Pre-start panel disappears at the gun
+	if !raceStarted {
+		panel := d.CalculateStartPanel(timerDuration - elapsedTime)
+		panel.DistanceToCross = distanceToLineCrossing
+		panel.TimeToCross = timeToCross
+		d.drawStartPanel(screen, panel)
+	}
+}
+
+// drawStartPanel renders the combined pre-start readout below the countdown timer
+func (d *Dashboard) drawStartPanel(screen *ebiten.Image, panel StartPanel) {
+	favored := favoredReadout(panel.FavoredEnd, panel.FavoredDegrees, panel.FavoredAdvantage, panel.BoatLength)
+
+	msg := fmt.Sprintf("Dist to Line: %.0fm\nTime to Line: %s (VMG %s)\n%s\n%s\nLine Length: %.0fm",
+		panel.DistanceToLine, secondsReadout(panel.TimeToLine), secondsReadout(panel.TimeToLineAtVMG),
+		burnReadout(panel.TimeToBurn), favored, panel.LineLength)
+
+	// Distance and time along the current heading to the crossing point
+	if panel.DistanceToCross >= 0 {
+		msg += fmt.Sprintf("\nDist to Cross: %.0fm", panel.DistanceToCross)
+		if math.IsInf(panel.TimeToCross, 1) {
+			msg += "\nTime to Cross: ∞"
+		} else {
+			msg += fmt.Sprintf("\nTime to Cross: %.1fs", panel.TimeToCross)
+		}
+	}
+
+	// Size the background to the text (debug font is 6px per character, 16px per line)
+	panelWidth := textWidth(msg) + 10
+	panelHeight := 16*(strings.Count(msg, "\n")+1) + 10
+	x := screen.Bounds().Dx()/2 - panelWidth/2
+	y := 90 // Below the timer, OCS warning and timing bar
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(panelWidth), float32(panelHeight), color.RGBA{0, 0, 0, 120}, false)
+	ebitenutil.DebugPrintAt(screen, msg, x+5, y+5)
+}
+
+// textWidth returns the width in pixels of the longest line of msg in the debug font
+func textWidth(msg string) int {
+	longest := 0
+	for _, line := range strings.Split(msg, "\n") {
+		longest = max(longest, utf8.RuneCountInString(line))
+	}
+	return longest * 6
 }
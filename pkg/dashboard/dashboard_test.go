@@ -1,6 +1,7 @@
 package dashboard
 
 import (
+	"fmt"
 	"math"
 	"testing"
 	"time"
@@ -13,7 +14,7 @@ import (
 
 // Helper to create test dashboard
 func createTestDashboard() *Dashboard {
-	wind := world.NewOscillatingWind(10.0, 10.0, 2000.0)
+	wind := world.NewOscillatingWind(10.0, 10.0, 2000.0, 0)
 	boat := &objects.Boat{
 		Pos:     geometry.Point{X: 1000, Y: 2500},
 		Heading: 0, // North
@@ -23,11 +24,13 @@ func createTestDashboard() *Dashboard {
 	}
 
 	return &Dashboard{
-		Boat:       boat,
-		Wind:       wind,
-		StartTime:  time.Now(),
-		LineStart:  geometry.Point{X: 800, Y: 2400},
-		LineEnd:    geometry.Point{X: 1200, Y: 2400},
+		Boat:      boat,
+		Wind:      wind,
+		StartTime: time.Now(),
+		Line: &world.StartLine{
+			Pin:       &world.Mark{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+			Committee: &world.Mark{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+		},
 		UpwindMark: geometry.Point{X: 1000, Y: 1800},
 	}
 }
@@ -247,3 +250,560 @@ func TestFindBestVMG_Consistency(t *testing.T) {
 			bestVMG1, bestVMG2)
 	}
 }
+
+func TestCalculateStartPanel_KnownPreStart(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	dash.Boat.Wind = dash.Wind
+
+	// Boat 100m below the line heading straight at it at 30 m/s (0.5 px/frame)
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2500}
+	dash.Boat.Heading = 0
+	dash.Boat.VelX = 0
+	dash.Boat.VelY = -0.5
+
+	panel := dash.CalculateStartPanel(10 * time.Second)
+
+	// Bow is 7.5m ahead of the boat center
+	if math.Abs(panel.DistanceToLine-92.5) > 0.01 {
+		t.Errorf("Distance to line should be 92.5m, got %.2f", panel.DistanceToLine)
+	}
+
+	expectedTimeToLine := 92.5 / 30.0
+	if math.Abs(panel.TimeToLine-expectedTimeToLine) > 0.01 {
+		t.Errorf("Time to line should be %.2fs, got %.2f", expectedTimeToLine, panel.TimeToLine)
+	}
+
+	if math.Abs(panel.TimeToBurn-(10-expectedTimeToLine)) > 0.01 {
+		t.Errorf("Time to burn should be %.2fs, got %.2f", 10-expectedTimeToLine, panel.TimeToBurn)
+	}
+
+	// Horizontal line with wind from North is square
+	if panel.FavoredEnd != "Square" {
+		t.Errorf("Expected square line, got %s favored", panel.FavoredEnd)
+	}
+}
+
+func TestCalculateStartPanel_FavoredEnd(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+
+	// Committee end 10m further upwind than the pin
+	dash.Line.Committee.Pos = geometry.Point{X: 1200, Y: 2390}
+	panel := dash.CalculateStartPanel(10 * time.Second)
+	if panel.FavoredEnd != "Committee" || math.Abs(panel.FavoredAdvantage-10) > 0.01 {
+		t.Errorf("Expected Committee favored by 10m, got %s by %.2f", panel.FavoredEnd, panel.FavoredAdvantage)
+	}
+	if want := math.Asin(10/dash.Line.Length()) * 180 / math.Pi; math.Abs(panel.FavoredDegrees-want) > 1e-9 {
+		t.Errorf("Expected the line %.2f° off square, got %.2f°", want, panel.FavoredDegrees)
+	}
+
+	// Pin end 10m further upwind
+	dash.Line.Committee.Pos = geometry.Point{X: 1200, Y: 2410}
+	panel = dash.CalculateStartPanel(10 * time.Second)
+	if panel.FavoredEnd != "Pin" || math.Abs(panel.FavoredAdvantage-10) > 0.01 {
+		t.Errorf("Expected Pin favored by 10m, got %s by %.2f", panel.FavoredEnd, panel.FavoredAdvantage)
+	}
+}
+
+func TestCalculateStartPanel_SailingAway(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2500}
+	dash.Boat.Heading = 180
+	dash.Boat.VelX = 0
+	dash.Boat.VelY = 0.5 // Moving away from the line
+
+	panel := dash.CalculateStartPanel(10 * time.Second)
+
+	if !math.IsInf(panel.TimeToLine, 1) {
+		t.Errorf("Time to line should be infinite when sailing away, got %.2f", panel.TimeToLine)
+	}
+	if !math.IsInf(panel.TimeToBurn, -1) {
+		t.Errorf("Time to burn should be -Inf when sailing away, got %.2f", panel.TimeToBurn)
+	}
+	if got := burnReadout(panel.TimeToBurn); got != "Early/late: --" {
+		t.Errorf("Expected no early/late readout when sailing away, got %q", got)
+	}
+	if got := secondsReadout(panel.TimeToLine); got != "--" {
+		t.Errorf("Expected no time to line when sailing away, got %q", got)
+	}
+
+	// The time at best VMG doesn't depend on which way the boat is going
+	if math.IsInf(panel.TimeToLineAtVMG, 0) || panel.TimeToLineAtVMG <= 0 {
+		t.Errorf("Expected a time to line at best VMG, got %.2f", panel.TimeToLineAtVMG)
+	}
+}
+
+func TestCalculateTimeToLineAtVMG(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2500}
+	dash.Boat.Heading = 0
+
+	vmg := dash.bestBeatVMG(10)
+	if vmg <= 0 {
+		t.Fatalf("Expected a positive beat VMG in 10 knots, got %.2f", vmg)
+	}
+	want := dash.CalculateDistanceToLine() / objects.MetersPerSecond(vmg)
+	if got := dash.CalculateTimeToLineAtVMG(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected %.2fs to the line at best VMG, got %.2fs", want, got)
+	}
+
+	// Over the line there is nothing left to sail
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2300}
+	if got := dash.CalculateTimeToLineAtVMG(); got != 0 {
+		t.Errorf("Expected 0 over the line, got %.2f", got)
+	}
+}
+
+func TestBurnReadout(t *testing.T) {
+	tests := []struct {
+		burn float64
+		want string
+	}{
+		{4.24, "Early by 4.2s"},
+		{0, "Early by 0.0s"},
+		{-3.05, "Late by 3.0s"},
+		{math.Inf(-1), "Early/late: --"},
+	}
+	for _, tt := range tests {
+		if got := burnReadout(tt.burn); got != tt.want {
+			t.Errorf("burnReadout(%v) = %q, want %q", tt.burn, got, tt.want)
+		}
+	}
+}
+
+func TestApparentWind_ArrowsOnCompassRose(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	dash.Boat.Heading = 90 // Beam reach heading east
+	dash.Boat.Speed = 6
+
+	awd, aws := dash.CalculateApparentWind()
+
+	// Apparent wind moves forward toward the bow: atan(6/10) = 31.0°
+	expectedAWD := math.Atan2(6, 10) * 180 / math.Pi
+	if math.Abs(awd-expectedAWD) > 0.01 {
+		t.Errorf("Expected apparent wind direction %.2f°, got %.2f°", expectedAWD, awd)
+	}
+	expectedAWS := math.Sqrt(6*6 + 10*10)
+	if math.Abs(aws-expectedAWS) > 0.01 {
+		t.Errorf("Expected apparent wind speed %.2f kts, got %.2f kts", expectedAWS, aws)
+	}
+
+	center := geometry.Point{X: 1200, Y: 330}
+	radius := 50.0
+	trueTail, trueHead := windArrow(center, radius, 0)
+	awTail, awHead := windArrow(center, radius, awd)
+
+	// True wind arrow comes straight down from the top of the rose
+	if math.Abs(trueTail.X-center.X) > 0.001 || math.Abs(trueTail.Y-(center.Y-radius)) > 0.001 {
+		t.Errorf("Expected true wind tail at top of rose, got (%.1f, %.1f)", trueTail.X, trueTail.Y)
+	}
+
+	// Apparent wind arrow is rotated toward the bow (clockwise, to the east of true wind)
+	if awTail.X <= trueTail.X || awTail.Y <= trueTail.Y {
+		t.Errorf("Expected apparent wind tail clockwise of true wind tail, got AW (%.1f, %.1f) vs TW (%.1f, %.1f)",
+			awTail.X, awTail.Y, trueTail.X, trueTail.Y)
+	}
+
+	// Both tails sit on the rim, both heads point toward the center
+	if d := math.Hypot(awTail.X-center.X, awTail.Y-center.Y); math.Abs(d-radius) > 0.001 {
+		t.Errorf("Expected apparent wind tail on the rim, distance %.2f", d)
+	}
+	if math.Hypot(awHead.X-center.X, awHead.Y-center.Y) >= radius || math.Hypot(trueHead.X-center.X, trueHead.Y-center.Y) >= radius {
+		t.Error("Expected arrow heads inside the rose")
+	}
+}
+
+func TestApparentWind_Stationary(t *testing.T) {
+	awd, aws := apparentWind(45, 12, 180, 0)
+	if awd != 45 || aws != 12 {
+		t.Errorf("Expected apparent wind equal to true wind when stationary, got %.1f° at %.1f kts", awd, aws)
+	}
+}
+
+func TestCalculateVMC(t *testing.T) {
+	tests := []struct {
+		name     string
+		heading  float64
+		speed    float64
+		target   geometry.Point
+		expected float64
+	}{
+		{"Heading straight at mark", 0, 6.0, geometry.Point{X: 1000, Y: 1800}, 6.0},
+		{"Mark 45° off the bow", 45, 6.0, geometry.Point{X: 1000, Y: 1800}, 6.0 * math.Cos(math.Pi/4)},
+		{"Mark abeam", 90, 6.0, geometry.Point{X: 1000, Y: 1800}, 0.0},
+		{"Sailing away from mark", 180, 6.0, geometry.Point{X: 1000, Y: 1800}, -6.0},
+		{"Reaching to mark to the east", 60, 5.0, geometry.Point{X: 1500, Y: 2500}, 5.0 * math.Cos(30*math.Pi/180)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dash := createTestDashboard()
+			dash.Boat.Heading = tt.heading
+			dash.Boat.Speed = tt.speed
+
+			got := dash.CalculateVMC(tt.target)
+			if math.Abs(got-tt.expected) > 0.001 {
+				t.Errorf("Expected VMC %.3f, got %.3f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFindBestVMC(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	dash.Boat.Heading = 0
+
+	// Mark dead upwind: the best course to it is close-hauled, the same as the best beat VMG
+	best, heading := dash.FindBestVMC(dash.UpwindMark)
+	beatAngle := polars.OptimalBeatAngle(dash.Boat.Polars, 10)
+	if offWind := math.Abs(math.Mod(heading+180, 360) - 180); math.Abs(offWind-beatAngle) > 1 {
+		t.Errorf("Expected to beat at %.0f° off the wind, got heading %.0f°", beatAngle, heading)
+	}
+	if math.Abs(best-dash.FindBestVMG()) > 0.05 {
+		t.Errorf("Expected best VMC %.2f to match the best beat VMG %.2f", best, dash.FindBestVMG())
+	}
+
+	// Mark on a beam reach to the east: sail roughly straight at it
+	best, heading = dash.FindBestVMC(geometry.Point{X: 2000, Y: 2500})
+	if math.Abs(heading-90) > 15 {
+		t.Errorf("Expected to sail about 90° to a mark abeam, got %.0f°", heading)
+	}
+	if reach := dash.Boat.Polars.GetBoatSpeed(90, 10); best < reach {
+		t.Errorf("Expected best VMC at least the beam reach speed %.2f, got %.2f", reach, best)
+	}
+}
+
+func TestVMCReadout(t *testing.T) {
+	if got, want := vmcReadout(5.2, 6.04, 352), "VMC (mark): 5.2 kts\nBest VMC: 6.0 kts @ 352°"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCrossTrackError_SideOfRhumbLine(t *testing.T) {
+	dash := createTestDashboard()
+
+	// Rhumb line runs due north from the middle of the line (1000, 2400) to the mark (1000, 1800)
+	dash.Boat.Pos = geometry.Point{X: 1045, Y: 2100}
+	if got := dash.CalculateCrossTrackError(); math.Abs(got-45) > 1e-9 {
+		t.Errorf("Expected 45m right of the rhumb line, got %.1f", got)
+	}
+	if got, want := crossTrackReadout(dash.CalculateCrossTrackError()), "XTE: 45m R"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	dash.Boat.Pos = geometry.Point{X: 970, Y: 2600}
+	if got, want := crossTrackReadout(dash.CalculateCrossTrackError()), "XTE: 30m L"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Skewed course: the mark up and to the right, boat on the line itself
+	dash.UpwindMark = geometry.Point{X: 1300, Y: 2000}
+	dash.Boat.Pos = geometry.Point{X: 1150, Y: 2200}
+	if got := dash.CalculateCrossTrackError(); math.Abs(got) > 1e-9 {
+		t.Errorf("Expected no cross-track error on the rhumb line, got %.3f", got)
+	}
+}
+
+func TestNextMark_FinishAfterRounding(t *testing.T) {
+	dash := createTestDashboard()
+
+	if got := dash.nextMark(false); got != dash.UpwindMark {
+		t.Errorf("Expected upwind mark before rounding, got %v", got)
+	}
+	if got := dash.nextMark(true); got != (geometry.Point{X: 1000, Y: 2400}) {
+		t.Errorf("Expected middle of the finish line after rounding, got %v", got)
+	}
+}
+
+func TestNextMark_GateBeforeFinish(t *testing.T) {
+	dash := createTestDashboard()
+	dash.Gate = &world.Gate{
+		Left:  &world.Mark{Pos: geometry.Point{X: 960, Y: 2250}, Name: "Gate Left"},
+		Right: &world.Mark{Pos: geometry.Point{X: 1040, Y: 2250}, Name: "Gate Right"},
+	}
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2000}
+	dash.Boat.Heading = 180
+	bow := dash.Boat.GetBowPosition()
+
+	if got := dash.nextMark(true); got != (geometry.Point{X: 1000, Y: 2250}) {
+		t.Errorf("Expected middle of the gate after rounding, got %v", got)
+	}
+	if got, want := dash.markReadout(true), fmt.Sprintf("To Gate: %.0fm @ 180°", 2250-bow.Y); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	dash.GatePassed = true
+	if got := dash.nextMark(true); got != (geometry.Point{X: 1000, Y: 2400}) {
+		t.Errorf("Expected middle of the finish line after the gate, got %v", got)
+	}
+	if got := dash.nextMark(false); got != dash.UpwindMark {
+		t.Errorf("Expected upwind mark before rounding, got %v", got)
+	}
+}
+
+func TestDistanceToMark_FromBow(t *testing.T) {
+	dash := createTestDashboard()
+	bow := dash.Boat.GetBowPosition()
+
+	// Heading straight at the upwind mark 700m north of the boat's center
+	if got, want := dash.CalculateDistanceToMark(dash.UpwindMark), bow.Y-1800; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected %.1fm from the bow to the mark, got %.1f", want, got)
+	}
+	if got, want := dash.markReadout(false), fmt.Sprintf("To Mark: %.0fm @ 000°", bow.Y-1800); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// After rounding the target is the middle of the finish line, due south of the boat
+	dash.Boat.Pos = geometry.Point{X: 1000, Y: 2000}
+	dash.Boat.Heading = 180
+	bow = dash.Boat.GetBowPosition()
+	if got, want := dash.markReadout(true), fmt.Sprintf("To Finish: %.0fm @ 180°", 2400-bow.Y); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestTargetSpeedReadout(t *testing.T) {
+	p := &polars.RealisticPolar{}
+
+	tests := []struct {
+		name     string
+		heading  float64
+		expected string
+	}{
+		{"Close hauled", 45, fmt.Sprintf("Speed: 6.0 / %.1f kts", p.GetBoatSpeed(45, 10))},
+		{"Beam reach", 90, "Speed: 6.0 / 7.1 kts"},
+		{"Run", 180, "Speed: 6.0 / 4.0 kts"},
+		{"Port tack uses actual angle", 270, "Speed: 6.0 / 7.1 kts"},
+		{"In irons", 0, "Speed: 6.0 / -- kts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dash := createTestDashboard()
+			dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+			dash.Boat.Heading = tt.heading
+
+			got := speedReadout(dash.Boat.Speed, dash.CalculateTargetSpeed())
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGroundSpeedReadout(t *testing.T) {
+	// Sailing into a 1 kt current: over the ground the boat is slower than through the water
+	if got, want := groundSpeedReadout(5.2, 6.2), "SOG/STW: 5.2 / 6.2 kts"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestPenaltyReadout(t *testing.T) {
+	if got, want := penaltyReadout(270, 720), "PENALTY: do 720\n[###-------] 270°"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	// Overshooting doesn't overflow the bar
+	if got, want := penaltyReadout(800, 720), "PENALTY: do 720\n[##########] 800°"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestApparentWindAngle(t *testing.T) {
+	tests := []struct {
+		name       string
+		heading    float64
+		velX, velY float64 // Pixels/frame
+		speed      float64
+		wantAWA    float64
+		wantAWS    float64
+	}{
+		// Beam reach on port: the boat's own wind pulls the apparent wind forward
+		{"Beam reach", 90, 0.5, 0, 6, 90 - math.Atan2(6, 10)*180/math.Pi, math.Hypot(6, 10)},
+		// Close-hauled on starboard tack the apparent wind is forward of the true wind and stronger
+		{"Close hauled", 315, -0.3536, -0.3536, 6, -(45 - math.Atan2(6*math.Sin(math.Pi/4), 10+6*math.Cos(math.Pi/4))*180/math.Pi), math.Hypot(6*math.Sin(math.Pi/4), 10+6*math.Cos(math.Pi/4))},
+		{"Stopped", 45, 0, 0, 0, 45, 10},
+		{"Tiny velocity", 45, 1e-9, -1e-9, 1e-7, 45, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dash := createTestDashboard()
+			dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+			dash.Boat.Heading = tt.heading
+			dash.Boat.VelX, dash.Boat.VelY = tt.velX, tt.velY
+			dash.Boat.Speed = tt.speed
+
+			awa, aws := dash.CalculateApparentWindAngle()
+			if math.IsNaN(awa) || math.IsNaN(aws) {
+				t.Fatalf("Expected no NaN, got AWA %v, AWS %v", awa, aws)
+			}
+			if math.Abs(awa-tt.wantAWA) > 0.1 {
+				t.Errorf("Expected AWA %.1f°, got %.1f°", tt.wantAWA, awa)
+			}
+			if math.Abs(aws-tt.wantAWS) > 0.01 {
+				t.Errorf("Expected AWS %.2f kts, got %.2f kts", tt.wantAWS, aws)
+			}
+		})
+	}
+}
+
+func TestApparentWind_FollowsVelocityWithLeeway(t *testing.T) {
+	// Heading 45° but slipping along 50°: apparent wind is computed from the actual motion
+	dash := createTestDashboard()
+	dash.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	dash.Boat.Heading = 45
+	dash.Boat.Speed = 6
+	courseRad := 50 * math.Pi / 180
+	dash.Boat.VelX, dash.Boat.VelY = 0.5*math.Sin(courseRad), -0.5*math.Cos(courseRad)
+
+	awd, _ := dash.CalculateApparentWind()
+	want, _ := apparentWind(0, 10, 50, 6)
+	if math.Abs(awd-want) > 0.01 {
+		t.Errorf("Expected apparent wind from the course through the water (%.2f°), got %.2f°", want, awd)
+	}
+}
+
+func TestLapReadout(t *testing.T) {
+	if got := lapReadout(2, 3); got != "Lap 2 of 3" {
+		t.Errorf("Expected %q, got %q", "Lap 2 of 3", got)
+	}
+	for _, laps := range []int{0, 1} {
+		if got := lapReadout(1, laps); got != "" {
+			t.Errorf("Expected no lap counter for %d laps, got %q", laps, got)
+		}
+	}
+}
+
+func TestPhaseReadout(t *testing.T) {
+	tests := []struct {
+		phase     string
+		leg, legs int
+		want      string
+	}{
+		{"Beat", 1, 2, "Leg 1 of 2: Beat"},
+		{"Run", 4, 4, "Leg 4 of 4: Run"},
+		{"Pre-start", 0, 2, "Phase: Pre-start"},
+		{"Beat", 1, 1, "Phase: Beat"},
+	}
+	for _, tt := range tests {
+		if got := phaseReadout(tt.phase, tt.leg, tt.legs); got != tt.want {
+			t.Errorf("phaseReadout(%q, %d, %d) = %q, want %q", tt.phase, tt.leg, tt.legs, got, tt.want)
+		}
+	}
+}
+
+func TestTack_AcrossHeadingsAndWinds(t *testing.T) {
+	tests := []struct {
+		windDir, heading float64
+		want             string
+	}{
+		// Northerly
+		{0, 45, TackPort},
+		{0, 315, TackStarboard},
+		{0, 135, TackPort},
+		{0, 225, TackStarboard},
+		{0, 2, TackHeadToWind},
+		{0, 182, TackDeadRunning},
+		// Easterly
+		{90, 0, TackStarboard},
+		{90, 180, TackPort},
+		{90, 88, TackHeadToWind},
+		{90, 270, TackDeadRunning},
+		// South-westerly, across north
+		{225, 180, TackStarboard},
+		{225, 270, TackPort},
+		{225, 45, TackDeadRunning},
+		// Northwesterly, across north
+		{350, 10, TackPort},
+		{350, 300, TackStarboard},
+	}
+
+	for _, tt := range tests {
+		d := createTestDashboard()
+		d.Wind = &world.ConstantWind{Direction: tt.windDir, Speed: 12}
+		d.Boat.Heading = tt.heading
+		if got := d.Tack(); got != tt.want {
+			t.Errorf("Wind from %.0f°, heading %.0f°: expected %q, got %q", tt.windDir, tt.heading, tt.want, got)
+		}
+	}
+}
+
+func TestTackColor(t *testing.T) {
+	if tackColor(TackStarboard) != starboardTackColor || tackColor(TackPort) != portTackColor {
+		t.Error("Expected green for starboard and red for port")
+	}
+	if tackColor(TackHeadToWind) != neutralTackColor || tackColor(TackDeadRunning) != neutralTackColor {
+		t.Error("Expected the neutral color head to wind and dead downwind")
+	}
+}
+
+// medianTestWind blows from dir while oscillating around median
+type medianTestWind struct{ dir, median float64 }
+
+func (w *medianTestWind) GetWind(geometry.Point) (float64, float64) { return w.dir, 12 }
+func (w *medianTestWind) MedianDirection() float64                  { return w.median }
+
+func TestCalculateWindShift_LiftedOrHeadedByTack(t *testing.T) {
+	tests := []struct {
+		name        string
+		dir, median float64
+		heading     float64
+		wantShift   float64
+		wantLifted  bool
+		wantReadout string
+	}{
+		{"Veer lifts starboard", 10, 0, 325, 10, true, "Lifted 10°"},
+		{"Veer heads port", 10, 0, 55, 10, false, "Headed 10°"},
+		{"Back lifts port", 352, 0, 37, -8, true, "Lifted 8°"},
+		{"Back heads starboard", 352, 0, 307, -8, false, "Headed 8°"},
+		{"Across north from a 355° median", 5, 355, 320, 10, true, "Lifted 10°"},
+		{"Easterly backed, port tack", 84, 90, 130, -6, true, "Lifted 6°"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := createTestDashboard()
+			d.Wind = &medianTestWind{dir: tt.dir, median: tt.median}
+			d.Boat.Heading = tt.heading
+
+			shift, lifted := d.CalculateWindShift()
+			if math.Abs(shift-tt.wantShift) > 1e-9 || lifted != tt.wantLifted {
+				t.Errorf("Expected shift %.0f° lifted=%v, got %.1f° lifted=%v", tt.wantShift, tt.wantLifted, shift, lifted)
+			}
+			if got := windShiftReadout(shift, lifted); got != tt.wantReadout {
+				t.Errorf("Expected %q, got %q", tt.wantReadout, got)
+			}
+		})
+	}
+}
+
+func TestCalculateWindShift_SteadyWithoutMedian(t *testing.T) {
+	d := createTestDashboard()
+	d.Wind = &world.ConstantWind{Direction: 20, Speed: 12}
+	if shift, _ := d.CalculateWindShift(); shift != 0 {
+		t.Errorf("Expected no shift for a wind without a median, got %.1f°", shift)
+	}
+	if got := windShiftReadout(0.5, false); got != "Wind steady" {
+		t.Errorf("Expected a steady readout under a degree, got %q", got)
+	}
+}
+
+func TestFavoredReadout(t *testing.T) {
+	if got := favoredReadout("Pin", 8.2, 30, 15); got != "Pin favored by 8° (2.0 lengths)" {
+		t.Errorf("Expected the pin bias in degrees and boat lengths, got %q", got)
+	}
+	if got := favoredReadout("Square", 0, 0, 15); got != "Square line" {
+		t.Errorf("Expected a square line, got %q", got)
+	}
+}
+
+func TestTextWidth_LongestLine(t *testing.T) {
+	msg := "Dist to Line: 40m\nCommittee favored by 12° (10.5 lengths)\nLine Length: 200m"
+	if got := textWidth(msg); got != 39*6 {
+		t.Errorf("Expected the longest line to set the width at 6px per character, got %d", got)
+	}
+}
@@ -0,0 +1,171 @@
+package dashboard
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// Tack names, and the neutral labels when the wind is too near the bow or stern to say
+const (
+	TackStarboard   = "Starboard"
+	TackPort        = "Port"
+	TackHeadToWind  = "Head to wind"
+	TackDeadRunning = "Dead downwind"
+)
+
+// Within this many degrees of head to wind or dead downwind the tack isn't shown
+const tackNeutralAngle = 5.0
+
+var (
+	starboardTackColor = color.RGBA{0, 160, 0, 220}     // Green, like the starboard light
+	portTackColor      = color.RGBA{200, 0, 0, 220}     // Red, like the port light
+	neutralTackColor   = color.RGBA{100, 100, 100, 220} // Gray
+)
+
+// tackName returns the tack for a signed TWA (positive = wind over the port side)
+func tackName(twa float64) string {
+	switch {
+	case math.Abs(twa) < tackNeutralAngle:
+		return TackHeadToWind
+	case math.Abs(twa) > 180-tackNeutralAngle:
+		return TackDeadRunning
+	case twa > 0:
+		return TackPort
+	default:
+		return TackStarboard
+	}
+}
+
+// Tack returns the tack the boat is on in the wind at its position: TackStarboard, TackPort,
+// or a neutral label when head to wind or dead downwind
+func (d *Dashboard) Tack() string {
+	windDir, _ := d.Wind.GetWind(d.Boat.Pos)
+	twa := math.Mod(d.Boat.Heading-windDir+540, 360) - 180
+	return tackName(twa)
+}
+
+// Shifts smaller than this (degrees) from the median read as steady wind
+const windShiftThreshold = 1.0
+
+var (
+	liftColor   = color.RGBA{0, 200, 0, 255} // Green arrow pointing up
+	headerColor = color.RGBA{220, 0, 0, 255} // Red arrow pointing down
+)
+
+// CalculateWindShift returns how far the wind at the boat has shifted from the median it
+// oscillates around (degrees, positive = veered) and whether that lifts the boat on its
+// current tack. On port tack a back is a lift; on starboard a veer is. Winds without a
+// median never shift.
+func (d *Dashboard) CalculateWindShift() (float64, bool) {
+	mw, ok := d.Wind.(interface{ MedianDirection() float64 })
+	if !ok {
+		return 0, false
+	}
+	windDir, _ := d.Wind.GetWind(d.Boat.Pos)
+	shift := math.Mod(windDir-mw.MedianDirection()+540, 360) - 180
+	twa := math.Mod(d.Boat.Heading-windDir+540, 360) - 180
+	return shift, shift*twa < 0
+}
+
+// windShiftReadout formats the shift for the boat's tack ("Lifted 6°", "Headed 4°")
+func windShiftReadout(shift float64, lifted bool) string {
+	switch {
+	case math.Abs(shift) < windShiftThreshold:
+		return "Wind steady"
+	case lifted:
+		return fmt.Sprintf("Lifted %.0f°", math.Abs(shift))
+	default:
+		return fmt.Sprintf("Headed %.0f°", math.Abs(shift))
+	}
+}
+
+// drawWindShift shows whether the boat is lifted or headed below the tack label, with an
+// arrow up for a lift and down for a header. Not shown head to wind or dead downwind.
+func (d *Dashboard) drawWindShift(screen *ebiten.Image) {
+	if tack := d.Tack(); tack != TackStarboard && tack != TackPort {
+		return
+	}
+	shift, lifted := d.CalculateWindShift()
+	label := windShiftReadout(shift, lifted)
+
+	x := screen.Bounds().Dx() - int(compassRoseOffsetX) - (len(label)*6+16)/2
+	y := int(compassRoseY+compassRoseRadius) + 60 // Below the tack label
+	ebitenutil.DebugPrintAt(screen, label, x+16, y)
+	if math.Abs(shift) < windShiftThreshold {
+		return
+	}
+
+	// Small arrow left of the text, pointing up (lift) or down (header)
+	cx, top, bottom := float32(x+6), float32(y+2), float32(y+14)
+	tip, headY, arrowColor := top, top+5, headerColor
+	if lifted {
+		arrowColor = liftColor
+	} else {
+		tip, headY = bottom, bottom-5
+	}
+	vector.StrokeLine(screen, cx, top, cx, bottom, 2, arrowColor, false)
+	vector.StrokeLine(screen, cx, tip, cx-4, headY, 2, arrowColor, false)
+	vector.StrokeLine(screen, cx, tip, cx+4, headY, 2, arrowColor, false)
+}
+
+// tackColor returns the label background for a tack: green for starboard, red for port
+func tackColor(tack string) color.Color {
+	switch tack {
+	case TackStarboard:
+		return starboardTackColor
+	case TackPort:
+		return portTackColor
+	default:
+		return neutralTackColor
+	}
+}
+
+// drawTack shows the tack in a colored box below the compass rose
+func (d *Dashboard) drawTack(screen *ebiten.Image) {
+	tack := d.Tack()
+	label := tack
+	if tack == TackStarboard || tack == TackPort {
+		label += " tack"
+	}
+
+	width := len(label)*6 + 16 // Debug font is 6px per character
+	x := screen.Bounds().Dx() - int(compassRoseOffsetX) - width/2
+	y := int(compassRoseY+compassRoseRadius) + 36 // Below the compass legend
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), 20, tackColor(tack), false)
+	ebitenutil.DebugPrintAt(screen, label, x+8, y+2)
+}
+
+var (
+	spinnakerColor        = color.RGBA{140, 60, 200, 220} // Purple kite drawing well
+	spinnakerPenaltyColor = color.RGBA{200, 80, 0, 220}   // Orange: flown too high or too low
+)
+
+// drawSpinnaker shows a SPIN box below the tack while the spinnaker is up, orange when
+// it is costing speed at the current angle
+func (d *Dashboard) drawSpinnaker(screen *ebiten.Image) {
+	if !d.Boat.SpinnakerUp {
+		return
+	}
+	windDir, _ := d.Wind.GetWind(d.Boat.Pos)
+	twa := math.Mod(d.Boat.Heading-windDir+540, 360) - 180
+
+	label, boxColor := "SPIN", spinnakerColor
+	if polars.SpinnakerFactor(twa) < 1 {
+		label, boxColor = "SPIN - too high", spinnakerPenaltyColor
+		if math.Abs(twa) > 150 {
+			label = "SPIN - too deep"
+		}
+	}
+
+	width := len(label)*6 + 16
+	x := screen.Bounds().Dx() - int(compassRoseOffsetX) - width/2
+	y := int(compassRoseY+compassRoseRadius) + 84 // Below the wind shift readout
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), 20, boxColor, false)
+	ebitenutil.DebugPrintAt(screen, label, x+8, y+2)
+}
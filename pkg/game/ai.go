@@ -0,0 +1,529 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// AILeg is the part of the course an opponent is sailing
+type AILeg int
+
+const (
+	AILegPreStart AILeg = iota // Maneuvering below the line before the gun
+	AILegBeat                  // Sailing upwind to the mark
+	AILegRun                   // Sailing downwind to the finish
+	AILegFinished              // Crossed the finish line
+)
+
+// AISkill sets how close an opponent sails to the optimal angles
+type AISkill int
+
+const (
+	AISkillNovice AISkill = iota
+	AISkillClub
+	AISkillExpert
+)
+
+// String returns the skill tier name
+func (s AISkill) String() string {
+	switch s {
+	case AISkillNovice:
+		return "novice"
+	case AISkillClub:
+		return "club"
+	default:
+		return "expert"
+	}
+}
+
+// angleError returns how many degrees wide of the optimal beat and run angles this tier sails
+func (s AISkill) angleError() float64 {
+	switch s {
+	case AISkillNovice:
+		return 8
+	case AISkillClub:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// ParseAISkill converts a skill tier name ("novice", "club" or "expert") to an AISkill
+func ParseAISkill(name string) (AISkill, error) {
+	for _, s := range []AISkill{AISkillNovice, AISkillClub, AISkillExpert} {
+		if strings.EqualFold(name, s.String()) {
+			return s, nil
+		}
+	}
+	return AISkillClub, fmt.Errorf("unknown AI skill %q (want novice, club or expert)", name)
+}
+
+const (
+	maxOpponents     = 6
+	aiRoundingRadius = 25.0  // Meters from the upwind mark at which an opponent bears away
+	aiRunAngle       = 150.0 // Downwind TWA (close to best run VMG)
+	aiStartDepth     = 150.0 // Meters below the line where opponents begin the sequence
+)
+
+// AIContext is what a controller sees when choosing a heading
+type AIContext struct {
+	Wind        world.Wind
+	Arena       *world.Arena   // Start line, marks and laylines
+	Leg         AILeg          // Set by the opponent from its race progress
+	TimeToStart time.Duration  // Time until the gun (negative after the start)
+	StartSpot   geometry.Point // Where on the line this opponent aims to start
+	Skill       AISkill
+	GatePassed  bool // Whether the opponent has passed the leeward gate (if the course has one)
+	Laps        int  // Times round the course before the finish (0 or 1 = a single lap)
+}
+
+// AIController steers an opponent boat. Each personality is a different controller.
+type AIController interface {
+	Name() string
+	Heading(boat *objects.Boat, ctx AIContext) float64
+}
+
+// Opponent is a computer-sailed boat in the fleet
+type Opponent struct {
+	Name       string
+	Boat       *objects.Boat
+	Controller AIController
+	Skill      AISkill
+	Leg        AILeg
+	StartSpot  geometry.Point
+	FinishTime time.Duration // Race time at the finish (valid once Leg is AILegFinished)
+	GatePassed bool          // Passed the leeward gate on the run
+	// Laps finished so far on a multi-lap race
+	LapsCompleted int
+}
+
+// Step steers and moves the opponent by one physics step and advances its race progress
+func (o *Opponent) Step(ctx AIContext, raceTime time.Duration) {
+	ctx.Leg = o.Leg
+	ctx.StartSpot = o.StartSpot
+	ctx.Skill = o.Skill
+	ctx.GatePassed = o.GatePassed
+
+	desired := o.Controller.Heading(o.Boat, ctx)
+	o.Boat.Heading = steerToward(o.Boat.Heading, desired, o.Boat.TurnRate()) // Full helm, like the player
+	o.trimSpinnaker(ctx)
+
+	prevBow := o.Boat.GetBowPosition()
+	o.Boat.Update()
+	bow := o.Boat.GetBowPosition()
+
+	switch o.Leg {
+	case AILegPreStart:
+		if ctx.TimeToStart <= 0 {
+			o.Leg = AILegBeat
+		}
+	case AILegBeat:
+		if mark, ok := upwindMark(ctx); ok && distance(o.Boat.Pos, mark) <= aiRoundingRadius {
+			o.Leg = AILegRun
+		}
+	case AILegRun:
+		if gate := ctx.Arena.Gate; gate != nil && !o.GatePassed {
+			if mark, ok := upwindMark(ctx); ok && gate.Passed(prevBow, bow, mark) {
+				o.GatePassed = true
+			}
+			break
+		}
+		lineY := ctx.Arena.Line.Pin.Pos.Y
+		if prevBow.Y < lineY && bow.Y >= lineY && ctx.Arena.Line.WithinBounds(bow.X) {
+			if o.LapsCompleted+1 < ctx.Laps {
+				// Another lap: back up the beat
+				o.LapsCompleted++
+				o.Leg = AILegBeat
+				o.GatePassed = false
+				break
+			}
+			o.Leg = AILegFinished
+			o.FinishTime = raceTime
+		}
+	}
+}
+
+// trimSpinnaker flies the spinnaker on the run once the opponent is sailing at least
+// SpinnakerMinTWA off the wind, and drops it when it comes higher or leaves the run, the
+// same kite the player hoists with Z
+func (o *Opponent) trimSpinnaker(ctx AIContext) {
+	windDir, _ := ctx.Wind.GetWind(o.Boat.Pos)
+	deep := math.Abs(geometry.AngleDiff(o.Boat.Heading, windDir)) >= polars.SpinnakerMinTWA
+	if want := o.Leg == AILegRun && deep; want != o.Boat.SpinnakerUp {
+		o.Boat.ToggleSpinnaker()
+	}
+}
+
+// AggressiveStarter times its run to hit the favored end of the line right at the gun,
+// then tacks only on big headers
+type AggressiveStarter struct {
+	tacks tackState
+}
+
+func (c *AggressiveStarter) Name() string { return "Aggressive Starter" }
+
+func (c *AggressiveStarter) Heading(boat *objects.Boat, ctx AIContext) float64 {
+	if ctx.Leg == AILegPreStart {
+		ctx.StartSpot = favoredEndSpot(ctx)
+	}
+	return sailCourse(boat, ctx, &c.tacks, 0, func(side float64) bool {
+		return c.tacks.since(10*time.Second) && headed(boat, ctx, side, 6)
+	})
+}
+
+// LaylineSailer starts conservatively a few seconds late in clear air and sails a
+// two-tack beat, tacking only at the layline
+type LaylineSailer struct {
+	tacks tackState
+}
+
+func (c *LaylineSailer) Name() string { return "Layline Sailer" }
+
+func (c *LaylineSailer) Heading(boat *objects.Boat, ctx AIContext) float64 {
+	return sailCourse(boat, ctx, &c.tacks, 6*time.Second, func(side float64) bool {
+		return false // Only the layline turns it
+	})
+}
+
+// ShiftChaser tacks on every meaningful header to stay on the lifted tack
+type ShiftChaser struct {
+	tacks tackState
+}
+
+func (c *ShiftChaser) Name() string { return "Shift Chaser" }
+
+func (c *ShiftChaser) Heading(boat *objects.Boat, ctx AIContext) float64 {
+	return sailCourse(boat, ctx, &c.tacks, 3*time.Second, func(side float64) bool {
+		return c.tacks.since(5*time.Second) && headed(boat, ctx, side, 3)
+	})
+}
+
+// newPersonality returns the controller for the i-th opponent, cycling through the personalities
+func newPersonality(i int) (AIController, color.RGBA) {
+	switch i % 3 {
+	case 0:
+		return &AggressiveStarter{}, color.RGBA{255, 120, 80, 255} // Orange-red
+	case 1:
+		return &LaylineSailer{}, color.RGBA{120, 220, 120, 255} // Green
+	default:
+		return &ShiftChaser{}, color.RGBA{230, 130, 230, 255} // Magenta
+	}
+}
+
+// newFleet creates count opponents of the given skill sailing the given class, spread below
+// the start line, each aiming for its own spot on the line
+func newFleet(count int, skill AISkill, class *objects.BoatClass, line *world.StartLine, wind world.Wind) []*Opponent {
+	count = max(0, min(count, maxOpponents))
+	pin, committee := line.Ends()
+	if class == nil {
+		class = &objects.Keelboat
+	}
+
+	fleet := make([]*Opponent, 0, count)
+	for i := 0; i < count; i++ {
+		f := float64(i+1) / float64(count+1)
+		spot := geometry.Point{X: pin.X + (committee.X-pin.X)*f, Y: pin.Y + (committee.Y-pin.Y)*f}
+		pos := geometry.Point{X: spot.X, Y: spot.Y + aiStartDepth}
+		windDir, _ := wind.GetWind(pos)
+
+		controller, hull := newPersonality(i)
+		fleet = append(fleet, &Opponent{
+			Name: controller.Name(),
+			Boat: &objects.Boat{
+				Pos:     pos,
+				Heading: windDir, // Luffing head to wind until it is time to go
+				Class:   class,
+				Polars:  class.Polars,
+				Wind:    wind,
+				Color:   hull,
+				// Same class as the player, so the same handling
+				BroachEnabled: class.Broaches,
+			},
+			Controller: controller,
+			Skill:      skill,
+			StartSpot:  spot,
+		})
+	}
+	return fleet
+}
+
+// tackState remembers which tack a controller wants to be on and when it last tacked
+type tackState struct {
+	side  float64 // -1 starboard, +1 port (0 = not yet on the beat)
+	steps int     // Physics steps since the last tack
+}
+
+// since reports whether at least d has passed since the last tack
+func (t *tackState) since(d time.Duration) bool {
+	return float64(t.steps) >= d.Seconds()*60
+}
+
+// sailCourse steers the legs all personalities share: a timed approach that reaches the start
+// spot startMargin after the gun, a beat where shouldTack decides the tacks (the layline always
+// does), and a run to the finish
+func sailCourse(boat *objects.Boat, ctx AIContext, tacks *tackState, startMargin time.Duration, shouldTack func(side float64) bool) float64 {
+	windDir, beatAngle := closeHauled(boat, ctx)
+
+	switch ctx.Leg {
+	case AILegPreStart:
+		return approachHeading(boat, ctx, windDir, beatAngle, startMargin)
+	case AILegBeat:
+		if tacks.side == 0 {
+			tacks.side = tackSide(boat.Heading, windDir)
+			tacks.steps = math.MaxInt32 / 2 // Free to tack straight away
+		}
+		tacks.steps++
+		if atLayline(boat, ctx, windDir, beatAngle, tacks.side) || shouldTack(tacks.side) {
+			tacks.side = -tacks.side
+			tacks.steps = 0
+		}
+		return beatHeading(boat, ctx, windDir, beatAngle, tacks.side)
+	case AILegRun:
+		return runHeading(boat, ctx, windDir)
+	default:
+		return windDir // Finished: luff up and stop
+	}
+}
+
+// approachHeading holds head to wind until it is time to sail to the start spot, arriving
+// startMargin after the gun at full speed
+func approachHeading(boat *objects.Boat, ctx AIContext, windDir, beatAngle float64, startMargin time.Duration) float64 {
+	// Over the line early: bear away and go back
+	if boat.GetBowPosition().Y <= ctx.Arena.Line.Pin.Pos.Y {
+		return math.Mod(windDir+180, 360)
+	}
+
+	toSpot := geometry.Bearing(boat.Pos, ctx.StartSpot)
+	heading := layableHeading(toSpot, windDir, beatAngle)
+
+	_, windSpeed := ctx.Wind.GetWind(boat.Pos)
+	speed := objects.MetersPerSecond(boat.Polars.GetBoatSpeed(geometry.AngleDiff(heading, windDir), windSpeed))
+	if speed <= 0 {
+		return heading
+	}
+	timeNeeded := distance(boat.Pos, ctx.StartSpot) / speed
+
+	if ctx.TimeToStart.Seconds()-timeNeeded+startMargin.Seconds() > 0 {
+		return windDir // Too early - hold position
+	}
+	return heading
+}
+
+// beatHeading sails close-hauled on the given side, pointing straight at the mark once it can be laid
+func beatHeading(boat *objects.Boat, ctx AIContext, windDir, beatAngle, side float64) float64 {
+	closeHauledHeading := math.Mod(windDir+side*beatAngle+360, 360)
+	mark, ok := upwindMark(ctx)
+	if !ok {
+		return closeHauledHeading
+	}
+	offset := geometry.AngleDiff(geometry.Bearing(boat.Pos, mark), windDir)
+	if offset*side > 0 && math.Abs(offset) >= beatAngle {
+		return geometry.Bearing(boat.Pos, mark)
+	}
+	return closeHauledHeading
+}
+
+// runHeading sails to the middle of the leeward gate (if any) and then the finish line,
+// gybing at the run angle when it is too deep to sail directly
+func runHeading(boat *objects.Boat, ctx AIContext, windDir float64) float64 {
+	target := ctx.Arena.Line.Midpoint()
+	if ctx.Arena.Gate != nil && !ctx.GatePassed {
+		target = ctx.Arena.Gate.Midpoint()
+	}
+	toTarget := geometry.Bearing(boat.Pos, target)
+	runAngle := aiRunAngle - ctx.Skill.angleError()
+
+	if math.Abs(geometry.AngleDiff(toTarget, windDir)) <= runAngle {
+		return toTarget
+	}
+	side := tackSide(boat.Heading, windDir)
+	return math.Mod(windDir+side*runAngle+360, 360)
+}
+
+// closeHauled returns the local wind direction and the beat angle this opponent sails
+func closeHauled(boat *objects.Boat, ctx AIContext) (windDir, beatAngle float64) {
+	windDir, windSpeed := ctx.Wind.GetWind(boat.Pos)
+	return windDir, polars.OptimalBeatAngle(boat.Polars, windSpeed) + ctx.Skill.angleError()
+}
+
+// atLayline reports whether the mark can be laid on the other tack but not on the current one
+func atLayline(boat *objects.Boat, ctx AIContext, windDir, beatAngle, side float64) bool {
+	current := math.Mod(windDir+side*beatAngle+360, 360)
+	other := math.Mod(windDir-side*beatAngle+360, 360)
+	return ctx.Arena.CanFetchMark(boat.Pos, other, ctx.Wind) && !ctx.Arena.CanFetchMark(boat.Pos, current, ctx.Wind)
+}
+
+// headed reports whether the wind has shifted against the given tack by more than threshold degrees
+// from its median direction
+func headed(boat *objects.Boat, ctx AIContext, side, threshold float64) bool {
+	windDir, _ := ctx.Wind.GetWind(boat.Pos)
+	shift := geometry.AngleDiff(windDir, medianWindDirection(ctx.Wind, boat.Pos))
+	return shift*side > threshold
+}
+
+// favoredEndSpot returns a start spot just inside the end of the line that is further upwind
+func favoredEndSpot(ctx AIContext) geometry.Point {
+	line := ctx.Arena.Line
+	pin, committee := line.Ends()
+	windDir, _ := ctx.Wind.GetWind(line.Midpoint())
+	windRad := windDir * math.Pi / 180
+	advantage := (committee.X-pin.X)*math.Sin(windRad) - (committee.Y-pin.Y)*math.Cos(windRad)
+
+	// 10% in from the favored end so the boat starts between the marks
+	f := 0.1
+	if advantage > 0 {
+		f = 0.9
+	}
+	return geometry.Point{X: pin.X + (committee.X-pin.X)*f, Y: pin.Y + (committee.Y-pin.Y)*f}
+}
+
+// layableHeading returns the bearing if it can be sailed, or the close-hauled heading on the
+// tack closest to it
+func layableHeading(bearing, windDir, beatAngle float64) float64 {
+	offset := geometry.AngleDiff(bearing, windDir)
+	if math.Abs(offset) >= beatAngle {
+		return bearing
+	}
+	side := -1.0 // Starboard when dead upwind
+	if offset > 0 {
+		side = 1
+	}
+	return math.Mod(windDir+side*beatAngle+360, 360)
+}
+
+// upwindMark returns the first rounding mark, if the course has one
+func upwindMark(ctx AIContext) (geometry.Point, bool) {
+	if len(ctx.Arena.Marks) < 3 {
+		return geometry.Point{}, false
+	}
+	return ctx.Arena.Marks[2].Pos, true
+}
+
+// steerToward turns heading toward desired by at most rate degrees, the short way round
+func steerToward(heading, desired, rate float64) float64 {
+	turn := math.Max(-rate, math.Min(rate, geometry.AngleDiff(desired, heading)))
+	return math.Mod(heading+turn+360, 360)
+}
+
+// tackSide returns +1 on port tack (TWA > 0) and -1 on starboard (including head to wind)
+func tackSide(heading, windDir float64) float64 {
+	if geometry.AngleDiff(heading, windDir) > 0 {
+		return 1
+	}
+	return -1
+}
+
+// distance returns the straight-line distance between two points in meters
+func distance(a, b geometry.Point) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// updateOpponents moves the fleet by one physics step
+func (g *GameState) updateOpponents() {
+	ctx := AIContext{
+		Wind:        g.Wind,
+		Arena:       g.Arena,
+		TimeToStart: g.timerDuration - g.elapsedTime,
+		Laps:        g.totalLaps(),
+	}
+	for _, o := range g.opponents {
+		o.Step(ctx, g.raceTimer)
+	}
+}
+
+// setupFleet places the configured number of opponents below the start line
+func (g *GameState) setupFleet() {
+	g.opponents = newFleet(g.settings.Opponents, g.settings.AISkill, g.Boat.Class, g.Arena.Line, g.Wind)
+	for _, o := range g.opponents {
+		o.Boat.Current = g.Current
+		o.Boat.Boundary = g.Boat.Boundary
+	}
+}
+
+// SetOpponents sets how many AI opponents race (0 to 6) and their skill tier
+func (g *GameState) SetOpponents(count int, skill AISkill) {
+	g.settings.Opponents = max(0, min(count, maxOpponents))
+	g.settings.AISkill = skill
+	g.setupFleet()
+}
+
+// drawOpponents draws the fleet with each boat's personality name
+func (g *GameState) drawOpponents(screen *ebiten.Image, view world.View) {
+	for _, o := range g.opponents {
+		o.Boat.Draw(screen, view)
+		x, y := view.ToScreen(o.Boat.Pos.X, o.Boat.Pos.Y)
+		ebitenutil.DebugPrintAt(screen, o.Name, int(x+view.Length(10)), int(y-view.Length(10)))
+	}
+}
+
+// FleetResult is one boat's finish in a race against the AI
+type FleetResult struct {
+	Name       string
+	FinishTime time.Duration // Race time at the finish (0 while still racing)
+	Player     bool
+}
+
+// fleetResults returns the player and the opponents in finishing order, with boats still
+// racing listed last
+func (g *GameState) fleetResults() []FleetResult {
+	var finished, racing []FleetResult
+	if g.raceFinished {
+		finished = append(finished, FleetResult{Name: "You", FinishTime: g.finishTime, Player: true})
+	} else {
+		racing = append(racing, FleetResult{Name: "You", Player: true})
+	}
+	for _, o := range g.opponents {
+		if o.Leg == AILegFinished {
+			finished = append(finished, FleetResult{Name: o.Name, FinishTime: o.FinishTime})
+		} else {
+			racing = append(racing, FleetResult{Name: o.Name})
+		}
+	}
+	sort.SliceStable(finished, func(i, j int) bool { return finished[i].FinishTime < finished[j].FinishTime })
+	return append(finished, racing...)
+}
+
+// fleetPlace returns the player's finishing place in the fleet (1 = won)
+func (g *GameState) fleetPlace() int {
+	for i, r := range g.fleetResults() {
+		if r.Player {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// fleetResultsText formats the finishing order, one boat per line ("2. Shift Chaser  03:15.20")
+func fleetResultsText(results []FleetResult) string {
+	text := "FLEET RESULTS"
+	for i, r := range results {
+		finish := "racing"
+		if r.FinishTime > 0 {
+			finish = fmt.Sprintf("%02d:%02d.%02d", int(r.FinishTime.Minutes()), int(r.FinishTime.Seconds())%60, int(r.FinishTime.Milliseconds()%1000/10))
+		}
+		text += fmt.Sprintf("\n%d. %-18s %s", i+1, r.Name, finish)
+	}
+	return text
+}
+
+// drawFleetResults lists the fleet's finishing order once the player has finished, filling
+// in the opponents as they cross the line
+func (g *GameState) drawFleetResults(screen *ebiten.Image) {
+	if !g.raceFinished || len(g.opponents) == 0 {
+		return
+	}
+	results := g.fleetResults()
+	bounds := screen.Bounds()
+	ebitenutil.DebugPrintAt(screen, fleetResultsText(results), bounds.Dx()-220, bounds.Dy()-16*(len(results)+1)-10)
+}
@@ -0,0 +1,296 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// shiftedWind is a steady wind blowing from dir while oscillating around median
+type shiftedWind struct {
+	dir, median, speed float64
+}
+
+func (w *shiftedWind) GetWind(_ geometry.Point) (float64, float64) { return w.dir, w.speed }
+func (w *shiftedWind) MedianDirection() float64                    { return w.median }
+
+// createTestAIContext returns a context on the default course layout (400m line at Y=2400,
+// upwind mark at (1000, 1800))
+func createTestAIContext(wind world.Wind, leg AILeg) AIContext {
+	g := createTestGame()
+	return AIContext{
+		Wind:  wind,
+		Arena: g.Arena,
+		Leg:   leg,
+	}
+}
+
+func createTestAIBoat(pos geometry.Point, heading float64, wind world.Wind) *objects.Boat {
+	return &objects.Boat{
+		Pos:     pos,
+		Heading: heading,
+		Polars:  &polars.RealisticPolar{},
+		Wind:    wind,
+	}
+}
+
+func TestShiftChaser_TacksOnHeader(t *testing.T) {
+	// Port tack (heading NE) with the wind veered 5° right of the median: a header
+	wind := &shiftedWind{dir: 5, median: 0, speed: 10}
+	ctx := createTestAIContext(wind, AILegBeat)
+	pos := geometry.Point{X: 1000, Y: 2200}
+
+	chaser := &ShiftChaser{}
+	heading := chaser.Heading(createTestAIBoat(pos, 50, wind), ctx)
+	if tackSide(heading, wind.dir) != -1 {
+		t.Errorf("Expected shift chaser to tack onto starboard on a 5° header, wants heading %.0f°", heading)
+	}
+
+	layline := &LaylineSailer{}
+	heading = layline.Heading(createTestAIBoat(pos, 50, wind), ctx)
+	if tackSide(heading, wind.dir) != 1 {
+		t.Errorf("Expected layline sailer to hold port tack through the header, wants heading %.0f°", heading)
+	}
+
+	aggressive := &AggressiveStarter{}
+	heading = aggressive.Heading(createTestAIBoat(pos, 50, wind), ctx)
+	if tackSide(heading, wind.dir) != 1 {
+		t.Errorf("Expected aggressive starter to ignore a small header, wants heading %.0f°", heading)
+	}
+}
+
+func TestShiftChaser_HoldsTackOnLift(t *testing.T) {
+	// Port tack with the wind backed 5°: a lift
+	wind := &shiftedWind{dir: -5, median: 0, speed: 10}
+	ctx := createTestAIContext(wind, AILegBeat)
+
+	chaser := &ShiftChaser{}
+	heading := chaser.Heading(createTestAIBoat(geometry.Point{X: 1000, Y: 2200}, 40, wind), ctx)
+	if tackSide(heading, wind.dir) != 1 {
+		t.Errorf("Expected shift chaser to stay on the lifted tack, wants heading %.0f°", heading)
+	}
+}
+
+func TestShiftChaser_WaitsBetweenTacks(t *testing.T) {
+	wind := &shiftedWind{dir: 5, median: 0, speed: 10}
+	ctx := createTestAIContext(wind, AILegBeat)
+	boat := createTestAIBoat(geometry.Point{X: 1000, Y: 2200}, 50, wind)
+	chaser := &ShiftChaser{}
+
+	chaser.Heading(boat, ctx) // Tacks onto starboard
+	if chaser.tacks.side != -1 {
+		t.Fatal("Expected first header to trigger a tack")
+	}
+
+	// Wind swings back: now a header on starboard, but too soon to tack again
+	wind.dir = -5
+	chaser.Heading(boat, ctx)
+	if chaser.tacks.side != -1 {
+		t.Error("Expected shift chaser not to tack again straight away")
+	}
+}
+
+func TestLaylineSailer_TacksAtLayline(t *testing.T) {
+	wind := &world.ConstantWind{Direction: 0, Speed: 10}
+	ctx := createTestAIContext(wind, AILegBeat)
+
+	// Starboard tack (heading NW), below the port layline: keeps going
+	below := &LaylineSailer{}
+	heading := below.Heading(createTestAIBoat(geometry.Point{X: 1000, Y: 2200}, 315, wind), ctx)
+	if tackSide(heading, 0) != -1 {
+		t.Errorf("Expected layline sailer to stay on starboard below the layline, wants heading %.0f°", heading)
+	}
+
+	// Far out on the left, past the port layline: tacks
+	past := &LaylineSailer{}
+	heading = past.Heading(createTestAIBoat(geometry.Point{X: 600, Y: 2200}, 315, wind), ctx)
+	if tackSide(heading, 0) != 1 {
+		t.Errorf("Expected layline sailer to tack onto port at the layline, wants heading %.0f°", heading)
+	}
+}
+
+func TestAggressiveStarter_StartsCloserToGun(t *testing.T) {
+	wind := &world.ConstantWind{Direction: 0, Speed: 10}
+	timeToGun := 60 * time.Second
+
+	// Seconds after the gun that each opponent's bow crosses the line
+	startDelay := func(controller AIController) float64 {
+		ctx := createTestAIContext(wind, AILegPreStart)
+		o := &Opponent{
+			Boat:       createTestAIBoat(geometry.Point{X: 1000, Y: 2550}, 0, wind),
+			Controller: controller,
+			Skill:      AISkillExpert,
+			StartSpot:  geometry.Point{X: 1000, Y: 2400},
+		}
+		for frame := 0; frame < 120*60; frame++ {
+			ctx.TimeToStart = timeToGun - time.Duration(frame)*time.Second/60
+			o.Step(ctx, 0)
+			if o.Boat.GetBowPosition().Y <= 2400 {
+				return -ctx.TimeToStart.Seconds()
+			}
+		}
+		t.Fatalf("%s never crossed the line", controller.Name())
+		return 0
+	}
+
+	aggressive := startDelay(&AggressiveStarter{})
+	conservative := startDelay(&LaylineSailer{})
+
+	if aggressive < 0 {
+		t.Errorf("Expected aggressive starter not to cross early, crossed %.1fs before the gun", -aggressive)
+	}
+	if aggressive >= conservative {
+		t.Errorf("Expected aggressive starter to cross closer to the gun: aggressive +%.1fs, layline sailer +%.1fs", aggressive, conservative)
+	}
+	if aggressive > 3 {
+		t.Errorf("Expected aggressive starter within 3s of the gun, got +%.1fs", aggressive)
+	}
+}
+
+func TestOpponents_SailWholeCourse(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.settings.Opponents = 3
+	g.settings.AISkill = AISkillClub
+	g.setupFleet()
+
+	if len(g.opponents) != 3 {
+		t.Fatalf("Expected 3 opponents, got %d", len(g.opponents))
+	}
+
+	// Gun at once, then up to 15 minutes of racing
+	g.elapsedTime = g.timerDuration
+	finished := func() bool {
+		for _, o := range g.opponents {
+			if o.Leg != AILegFinished {
+				return false
+			}
+		}
+		return true
+	}
+	for frame := 0; frame < 15*60*60 && !finished(); frame++ {
+		g.raceTimer = time.Duration(frame) * time.Second / 60
+		g.updateOpponents()
+	}
+
+	for _, o := range g.opponents {
+		if o.Leg != AILegFinished {
+			t.Errorf("Expected %s to finish, still on leg %d at %v", o.Name, o.Leg, o.Boat.Pos)
+			continue
+		}
+		if o.FinishTime <= 0 || math.IsNaN(o.Boat.Pos.X) {
+			t.Errorf("Expected %s to record a finish time, got %v", o.Name, o.FinishTime)
+		}
+	}
+}
+
+func TestOpponent_FliesSpinnakerOnTheRun(t *testing.T) {
+	wind := &world.ConstantWind{Direction: 0, Speed: 10}
+	o := &Opponent{
+		Boat:       createTestAIBoat(geometry.Point{X: 1000, Y: 2000}, 150, wind),
+		Controller: &LaylineSailer{},
+		Leg:        AILegRun,
+	}
+	ctx := createTestAIContext(wind, AILegRun)
+
+	o.trimSpinnaker(ctx)
+	if !o.Boat.SpinnakerUp {
+		t.Fatal("Expected the spinnaker up running at TWA 150°")
+	}
+
+	// Luffed above a beam reach, the kite comes down
+	o.Boat.Heading = 60
+	o.trimSpinnaker(ctx)
+	if o.Boat.SpinnakerUp {
+		t.Error("Expected the spinnaker down at TWA 60°")
+	}
+
+	// Never flown on the beat, however deep
+	o.Leg = AILegBeat
+	o.Boat.Heading = 150
+	o.trimSpinnaker(ctx)
+	if o.Boat.SpinnakerUp {
+		t.Error("Expected no spinnaker on the beat")
+	}
+}
+
+func TestParseAISkill(t *testing.T) {
+	for _, s := range []AISkill{AISkillNovice, AISkillClub, AISkillExpert} {
+		got, err := ParseAISkill(s.String())
+		if err != nil || got != s {
+			t.Errorf("ParseAISkill(%q) = %v, %v", s.String(), got, err)
+		}
+	}
+	if _, err := ParseAISkill("pro"); err == nil {
+		t.Error("Expected an error for an unknown skill tier")
+	}
+}
+
+func TestFleetResults_FinishingOrder(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.settings.Opponents = 3
+	g.setupFleet()
+
+	// One opponent beat the player, one finished behind and one is still racing
+	g.raceFinished = true
+	g.finishTime = 200 * time.Second
+	g.opponents[0].Leg, g.opponents[0].FinishTime = AILegFinished, 210*time.Second
+	g.opponents[1].Leg = AILegRun
+	g.opponents[2].Leg, g.opponents[2].FinishTime = AILegFinished, 190*time.Second
+
+	results := g.fleetResults()
+	want := []string{g.opponents[2].Name, "You", g.opponents[0].Name, g.opponents[1].Name}
+	for i, name := range want {
+		if results[i].Name != name {
+			t.Errorf("Expected %s in place %d, got %s", name, i+1, results[i].Name)
+		}
+	}
+	if place := g.fleetPlace(); place != 2 {
+		t.Errorf("Expected the player to place 2nd, got %d", place)
+	}
+}
+
+func TestFleetResultsText(t *testing.T) {
+	text := fleetResultsText([]FleetResult{
+		{Name: "You", FinishTime: 192400 * time.Millisecond, Player: true},
+		{Name: "Shift Chaser"},
+	})
+	want := "FLEET RESULTS\n1. You                03:12.40\n2. Shift Chaser       racing"
+	if text != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, text)
+	}
+}
+
+func TestOpponents_SailThroughGate(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	addTestGate(g)
+	g.settings.Opponents = 3
+	g.setupFleet()
+
+	g.elapsedTime = g.timerDuration
+	finished := func() bool {
+		for _, o := range g.opponents {
+			if o.Leg != AILegFinished {
+				return false
+			}
+		}
+		return true
+	}
+	for frame := 0; frame < 15*60*60 && !finished(); frame++ {
+		g.raceTimer = time.Duration(frame) * time.Second / 60
+		g.updateOpponents()
+	}
+
+	for _, o := range g.opponents {
+		if !o.GatePassed || o.Leg != AILegFinished {
+			t.Errorf("Expected %s to pass the gate and finish, gate %v leg %d at %v", o.Name, o.GatePassed, o.Leg, o.Boat.Pos)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"math"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// autoTack is a one-key tack in progress: the boat turns through the wind at full helm
+// until it reaches the close-hauled heading on the other tack
+type autoTack struct {
+	active bool
+	target float64 // Heading to settle on, degrees
+}
+
+// autoTackTarget returns the close-hauled heading on the opposite tack to the one the boat is on
+func (g *GameState) autoTackTarget() float64 {
+	windDir, windSpeed := g.Wind.GetWind(g.Boat.Pos)
+	beatAngle := polars.OptimalBeatAngle(g.Boat.Polars, windSpeed)
+	return math.Mod(windDir-tackSide(g.Boat.Heading, windDir)*beatAngle+360, 360)
+}
+
+// toggleAutoTack starts an auto-tack, or cancels the one in progress
+func (g *GameState) toggleAutoTack() {
+	if g.autoTack.active {
+		g.autoTack.active = false
+		return
+	}
+	g.autoTack = autoTack{active: true, target: g.autoTackTarget()}
+}
+
+// steerAutoTack turns the boat one step toward the auto-tack heading at the class turn rate,
+// so the tack takes as long as it would with the helm held over
+func (g *GameState) steerAutoTack() {
+	if !g.autoTack.active {
+		return
+	}
+	g.Boat.Heading = steerToward(g.Boat.Heading, g.autoTack.target, g.Boat.TurnRate())
+	g.Boat.RateOfTurn = 0 // The helm is centered when the tack completes
+	if math.Abs(geometry.AngleDiff(g.Boat.Heading, g.autoTack.target)) < 1e-6 {
+		g.autoTack.active = false
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+func TestAutoTack_TurnsToOppositeCloseHauled(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.Boat.Wind = g.Wind
+	beatAngle := polars.OptimalBeatAngle(g.Boat.Polars, 10)
+	g.Boat.Heading = beatAngle // Close-hauled on port
+
+	g.toggleAutoTack()
+	steps := 0
+	for ; g.autoTack.active && steps < 10*60; steps++ {
+		g.steerAutoTack()
+		g.Boat.Update()
+		if math.Abs(geometry.AngleDiff(g.Boat.Heading, 0)) > beatAngle+1e-6 {
+			t.Fatalf("Expected the tack to turn through the wind, heading went to %.1f°", g.Boat.Heading)
+		}
+	}
+
+	if g.autoTack.active {
+		t.Fatalf("Expected the auto-tack to complete, heading is %.1f°", g.Boat.Heading)
+	}
+	if want := 360 - beatAngle; math.Abs(geometry.AngleDiff(g.Boat.Heading, want)) > 1e-6 {
+		t.Errorf("Expected to settle close-hauled on starboard at %.1f°, got %.1f°", want, g.Boat.Heading)
+	}
+	if seconds := float64(steps) / 60; seconds < 1 {
+		t.Errorf("Expected the tack to take a realistic time, took %.2fs", seconds)
+	}
+}
+
+func TestAutoTack_ToggleCancels(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.Boat.Heading = 315 // Starboard
+
+	g.toggleAutoTack()
+	if !g.autoTack.active || tackSide(g.autoTack.target, 0) != 1 {
+		t.Fatalf("Expected an auto-tack onto port, target %.1f°", g.autoTack.target)
+	}
+
+	g.toggleAutoTack()
+	g.steerAutoTack()
+	if g.autoTack.active || g.Boat.Heading != 315 {
+		t.Errorf("Expected a second press to cancel the tack, heading %.1f°", g.Boat.Heading)
+	}
+}
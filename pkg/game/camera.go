@@ -0,0 +1,137 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// CameraMode selects how the camera frames the course
+type CameraMode int
+
+const (
+	CameraFollow    CameraMode = iota // Pan to keep the player's boat in view
+	CameraCommittee                   // Fixed at the committee boat, looking down the line and up the course
+	cameraModeCount
+)
+
+// Committee camera placement: the committee boat sits in the bottom-right corner so the
+// line runs off to the left and the course opens up above it
+const (
+	committeeCameraRight  = 150.0 // Committee boat distance from the right screen edge
+	committeeCameraBottom = 120.0 // Committee boat distance from the bottom screen edge
+)
+
+// String returns the camera mode name shown on the help screen
+func (m CameraMode) String() string {
+	switch m {
+	case CameraCommittee:
+		return "Committee"
+	default:
+		return "Follow"
+	}
+}
+
+// Next returns the following camera mode in the cycle
+func (m CameraMode) Next() CameraMode {
+	return (m + 1) % cameraModeCount
+}
+
+// How quickly the camera eases toward its target, per second of game time: it closes
+// 1-e^(-rate*dt) of the remaining gap each frame, so the pan is the same at any frame rate
+const cameraSmoothing = 6.0
+
+// cameraTarget returns where the selected camera mode wants the camera
+func (g *GameState) cameraTarget() (float64, float64) {
+	switch g.settings.Camera {
+	case CameraCommittee:
+		return g.committeeCamera()
+	default:
+		return g.followCameraTarget()
+	}
+}
+
+// After a two-finger pan the camera stays where the player left it this long of game
+// time before easing back to its target
+const panHold = 3 * time.Second
+
+// panCamera moves the view by a two-finger drag of dx, dy screen pixels; the course moves
+// with the fingers
+func (g *GameState) panCamera(dx, dy float64) {
+	g.CameraX -= dx / g.zoom()
+	g.CameraY -= dy / g.zoom()
+	g.clampCamera()
+	g.cameraHoldUntil = g.elapsedTime + panHold
+}
+
+// cameraHeld reports whether the camera is still holding after a pan. A rehearsal rewind
+// sets the clock back, and ends the hold rather than stretching it.
+func (g *GameState) cameraHeld() bool {
+	return g.elapsedTime < g.cameraHoldUntil && g.cameraHoldUntil-g.elapsedTime <= panHold
+}
+
+// updateCamera eases the camera toward its target over deltaTime, within the world bounds
+func (g *GameState) updateCamera(deltaTime time.Duration) {
+	if g.cameraHeld() {
+		return
+	}
+	targetX, targetY := g.cameraTarget()
+	ease := 1 - math.Exp(-cameraSmoothing*deltaTime.Seconds())
+	g.CameraX += (targetX - g.CameraX) * ease
+	g.CameraY += (targetY - g.CameraY) * ease
+	g.clampCamera()
+}
+
+// snapCamera moves the camera straight to its target, as when switching camera mode while
+// the game is paused and no frames run to ease it there
+func (g *GameState) snapCamera() {
+	g.CameraX, g.CameraY = g.cameraTarget()
+	g.clampCamera()
+}
+
+// committeeCamera returns the fixed camera offset for the broadcast view from the committee boat
+func (g *GameState) committeeCamera() (float64, float64) {
+	committee := g.Arena.Line.Committee.Pos
+	width, height := g.viewSize()
+	zoom := g.zoom()
+	return committee.X - (width - committeeCameraRight/zoom),
+		committee.Y - (height - committeeCameraBottom/zoom)
+}
+
+// Zoom limits: zoomed out far enough to see the line and the upwind mark together on a
+// laptop screen, and in close enough to watch the boat round a mark
+const (
+	minZoom  = 0.4
+	maxZoom  = 2.5
+	zoomStep = 1.25 // Zoom factor per +/- key press
+)
+
+// clampZoom keeps a zoom level within the limits
+func clampZoom(zoom float64) float64 {
+	return math.Max(minZoom, math.Min(zoom, maxZoom))
+}
+
+// zoom returns the world zoom level, screen pixels per meter, 1 until it's been set
+func (g *GameState) zoom() float64 {
+	if g.settings.Zoom <= 0 {
+		return 1
+	}
+	return g.settings.Zoom
+}
+
+// viewSize returns how much of the world the screen shows at the current zoom, in meters
+func (g *GameState) viewSize() (float64, float64) {
+	width, height := g.screenSize()
+	zoom := g.zoom()
+	return float64(width) / zoom, float64(height) / zoom
+}
+
+// setZoom changes the zoom level within the limits, keeping the middle of the screen
+// where it is
+func (g *GameState) setZoom(zoom float64) {
+	width, height := g.viewSize()
+	centerX, centerY := g.CameraX+width/2, g.CameraY+height/2
+	g.settings.Zoom = clampZoom(zoom)
+	width, height = g.viewSize()
+	g.CameraX, g.CameraY = centerX-width/2, centerY-height/2
+	g.clampCamera()
+}
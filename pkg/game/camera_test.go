@@ -0,0 +1,225 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestUpdateCamera_BoatFramedAtWorldCorners(t *testing.T) {
+	// Boat should stay at least this far from the screen edges
+	const visibleBand = 150.0
+
+	corners := []struct {
+		name string
+		pos  geometry.Point
+	}{
+		{"Top-left", geometry.Point{X: 0, Y: 0}},
+		{"Top-right", geometry.Point{X: WorldWidth, Y: 0}},
+		{"Bottom-left", geometry.Point{X: 0, Y: WorldHeight}},
+		{"Bottom-right", geometry.Point{X: WorldWidth, Y: WorldHeight}},
+		{"Near upwind mark at top edge", geometry.Point{X: 1000, Y: 20}},
+	}
+
+	for _, tt := range corners {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createTestGame()
+			g.CameraX = (WorldWidth - ScreenWidth) / 2
+			g.CameraY = (WorldHeight - ScreenHeight) / 2
+			g.Boat.Pos = tt.pos
+
+			// Let the camera settle
+			for i := 0; i < 3; i++ {
+				g.updateCamera(time.Second)
+			}
+
+			screenX := g.Boat.Pos.X - g.CameraX
+			screenY := g.Boat.Pos.Y - g.CameraY
+			if screenX < visibleBand || screenX > ScreenWidth-visibleBand {
+				t.Errorf("Boat at screen X=%.0f, expected within [%.0f, %.0f]", screenX, visibleBand, ScreenWidth-visibleBand)
+			}
+			if screenY < visibleBand || screenY > ScreenHeight-visibleBand {
+				t.Errorf("Boat at screen Y=%.0f, expected within [%.0f, %.0f]", screenY, visibleBand, ScreenHeight-visibleBand)
+			}
+		})
+	}
+}
+
+func TestUpdateCamera_OverscrollIsLimited(t *testing.T) {
+	g := createTestGame()
+
+	// Boat far outside the world - camera stops at the over-scroll limit
+	g.Boat.Pos = geometry.Point{X: -5000, Y: -5000}
+	g.updateCamera(time.Second)
+
+	if g.CameraX != -cameraOverscroll || g.CameraY != -cameraOverscroll {
+		t.Errorf("Expected camera clamped to (%.0f, %.0f), got (%.0f, %.0f)", -cameraOverscroll, -cameraOverscroll, g.CameraX, g.CameraY)
+	}
+}
+
+func TestCommitteeCamera_FramesCommitteeBoatAndLine(t *testing.T) {
+	g := createTestGame()
+	g.settings.Camera = CameraFollow.Next()
+	if g.settings.Camera != CameraCommittee {
+		t.Fatalf("Expected the camera cycle to go from Follow to Committee, got %v", g.settings.Camera)
+	}
+
+	// The camera stays put wherever the boat sails
+	g.Boat.Pos = geometry.Point{X: 300, Y: 500}
+	g.snapCamera()
+
+	committee := g.Arena.Line.Committee.Pos
+	pin := g.Arena.Line.Pin.Pos
+	if g.CameraX != committee.X-(ScreenWidth-committeeCameraRight) || g.CameraY != committee.Y-(ScreenHeight-committeeCameraBottom) {
+		t.Errorf("Expected camera at the committee boat offset, got (%.0f, %.0f)", g.CameraX, g.CameraY)
+	}
+	for _, p := range []geometry.Point{committee, pin} {
+		x, y := p.X-g.CameraX, p.Y-g.CameraY
+		if x < 0 || x > ScreenWidth || y < 0 || y > ScreenHeight {
+			t.Errorf("Expected line end (%.0f, %.0f) on screen, at (%.0f, %.0f)", p.X, p.Y, x, y)
+		}
+	}
+
+	// Cycling again goes back to following the boat
+	if g.settings.Camera.Next() != CameraFollow {
+		t.Errorf("Expected the camera cycle to wrap back to Follow")
+	}
+}
+
+func TestUpdateCamera_EasesTowardTarget(t *testing.T) {
+	g := createTestGame()
+	g.CameraX, g.CameraY = 500, 1000
+	g.Boat.Pos = geometry.Point{X: 500 + ScreenWidth/2, Y: 1000 + ScreenHeight + 300}
+	targetX, targetY := g.cameraTarget()
+
+	// One frame only covers part of the way, without overshooting
+	g.updateCamera(time.Second / 60)
+	if g.CameraY <= 1000 || g.CameraY >= targetY {
+		t.Errorf("Expected the camera part way from 1000 to %.0f, got %.1f", targetY, g.CameraY)
+	}
+	if g.CameraX != targetX {
+		t.Errorf("Expected no horizontal pan with the boat mid-screen, got %.1f", g.CameraX)
+	}
+
+	// Two half-length frames end up in the same place as one
+	other := createTestGame()
+	other.CameraX, other.CameraY = 500, 1000
+	other.Boat.Pos = g.Boat.Pos
+	other.updateCamera(time.Second / 120)
+	other.updateCamera(time.Second / 120)
+	if math.Abs(other.CameraY-g.CameraY) > 1e-9 {
+		t.Errorf("Expected the ease to be frame-rate independent, got %.4f and %.4f", other.CameraY, g.CameraY)
+	}
+
+	// And it settles on the target within a couple of seconds
+	for i := 0; i < 120; i++ {
+		g.updateCamera(time.Second / 60)
+	}
+	if math.Abs(g.CameraY-targetY) > 1 {
+		t.Errorf("Expected the camera to settle at %.0f, got %.1f", targetY, g.CameraY)
+	}
+}
+
+func TestZoom_ClampedAndKeepsScreenCenter(t *testing.T) {
+	g := createTestGame()
+	g.CameraX, g.CameraY = 400, 1000
+	centerX, centerY := 400+ScreenWidth/2.0, 1000+ScreenHeight/2.0
+
+	g.setZoom(2)
+	width, height := g.viewSize()
+	if width != ScreenWidth/2 || height != ScreenHeight/2 {
+		t.Errorf("Expected half the world on screen at 2x, got %.0fx%.0f", width, height)
+	}
+	if g.CameraX+width/2 != centerX || g.CameraY+height/2 != centerY {
+		t.Errorf("Expected the screen center to stay at (%.0f, %.0f), got (%.0f, %.0f)",
+			centerX, centerY, g.CameraX+width/2, g.CameraY+height/2)
+	}
+
+	// The world is drawn zoomed; the view scale carries it
+	if x, _ := g.worldView().ToScreen(g.CameraX+100, g.CameraY); x != 200 {
+		t.Errorf("Expected 100m to span 200px at 2x, got %.0f", x)
+	}
+
+	g.setZoom(100)
+	if g.zoom() != maxZoom {
+		t.Errorf("Expected zoom capped at %.1f, got %.1f", maxZoom, g.zoom())
+	}
+	g.setZoom(0.01)
+	if g.zoom() != minZoom {
+		t.Errorf("Expected zoom limited to %.1f, got %.1f", minZoom, g.zoom())
+	}
+}
+
+func TestZoom_CameraClampAccountsForZoom(t *testing.T) {
+	g := createTestGame()
+	g.settings.Zoom = 0.5
+
+	// Zoomed out the screen covers twice the world, so the far limit comes in, and the
+	// world is centered across a screen wider than it
+	g.CameraX, g.CameraY = WorldWidth, WorldHeight
+	g.clampCamera()
+	width, height := g.viewSize()
+	if g.CameraX != (WorldWidth-width)/2 || g.CameraY != WorldHeight-height+cameraOverscroll {
+		t.Errorf("Expected camera clamped to (%.0f, %.0f), got (%.0f, %.0f)",
+			(WorldWidth-width)/2, WorldHeight-height+cameraOverscroll, g.CameraX, g.CameraY)
+	}
+
+	// The follow camera keeps the boat on the zoomed screen
+	g.settings.Zoom = 2
+	g.CameraX, g.CameraY = 500, 1000
+	g.Boat.Pos = geometry.Point{X: 1400, Y: 1900}
+	g.snapCamera()
+	x, y := g.worldView().ToScreen(g.Boat.Pos.X, g.Boat.Pos.Y)
+	if x < 0 || x > ScreenWidth || y < 0 || y > ScreenHeight {
+		t.Errorf("Expected the boat on screen at 2x, at (%.0f, %.0f)", x, y)
+	}
+}
+
+func TestPinchZoom(t *testing.T) {
+	if z := pinchZoom(0, 200); z != 0 {
+		t.Errorf("Expected no zoom as the pinch starts, got %.2f", z)
+	}
+	if z := pinchZoom(100, 150); z != 1.5 {
+		t.Errorf("Expected fingers spreading 100 to 150px to zoom 1.5x, got %.2f", z)
+	}
+	if z := pinchZoom(200, 100); z != 0.5 {
+		t.Errorf("Expected fingers closing 200 to 100px to zoom 0.5x, got %.2f", z)
+	}
+}
+
+func TestPanCamera_HoldsBeforeFollowingAgain(t *testing.T) {
+	g := createTestGame()
+	g.settings.Zoom = 2
+	g.CameraX, g.CameraY = 500, 1000
+	g.Boat.Pos = geometry.Point{X: 500 + ScreenWidth/4, Y: 1000 + ScreenHeight/4}
+
+	// Dragging two fingers right by 100px moves the course right, so the camera goes left
+	// by 50m at 2x
+	g.panCamera(100, -40)
+	if g.CameraX != 450 || g.CameraY != 1020 {
+		t.Errorf("Expected camera at (450, 1020), got (%.0f, %.0f)", g.CameraX, g.CameraY)
+	}
+
+	// The follow camera leaves it there for a moment
+	g.Boat.Pos = geometry.Point{X: 2000, Y: 2800}
+	g.updateCamera(time.Second)
+	if g.CameraX != 450 || g.CameraY != 1020 {
+		t.Errorf("Expected the camera to hold after a pan, moved to (%.0f, %.0f)", g.CameraX, g.CameraY)
+	}
+
+	// Then, once that much game time has passed, it goes back to following the boat
+	g.elapsedTime += panHold
+	g.updateCamera(time.Second)
+	if g.CameraX == 450 && g.CameraY == 1020 {
+		t.Error("Expected the camera to follow the boat again once the hold is over")
+	}
+
+	// A rehearsal rewind right after a pan doesn't stretch the hold
+	g.panCamera(10, 0)
+	g.elapsedTime -= 30 * time.Second
+	if g.cameraHeld() {
+		t.Error("Expected a rewind to end the hold")
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Course validation errors
+var (
+	ErrMissingStartLine = errors.New("course has no valid start line: need two distinct points (pin and committee)")
+	ErrMissingMark      = errors.New("course has no rounding mark")
+	ErrExtraMarks       = errors.New("course can only have one rounding mark, the upwind mark")
+	ErrAngledLine       = errors.New("start line must run straight across the course (pin and committee at the same y)")
+	ErrMarkBelowLine    = errors.New("upwind mark must be north of the start line (smaller y)")
+	ErrInvalidGate      = errors.New("leeward gate needs two marks at different positions")
+	ErrInvalidRounding  = errors.New("mark rounding must be \"port\" or \"starboard\"")
+	ErrInvalidWind      = errors.New("course wind speeds can't be negative")
+	ErrOutOfBounds      = errors.New("course line and marks must be inside the sailing area, clear of any shallows")
+)
+
+// Which side a mark is left on when rounding it
+const (
+	RoundingPort      = "port"      // Mark on the boat's left (counter-clockwise), the default
+	RoundingStarboard = "starboard" // Mark on the boat's right (clockwise)
+)
+
+// CourseMark is a rounding mark on the course
+type CourseMark struct {
+	Name     string         `json:"name"`
+	Pos      geometry.Point `json:"pos"`
+	Rounding string         `json:"rounding,omitempty"` // RoundingPort ("" = port) or RoundingStarboard
+}
+
+// CourseConfig describes a race course layout. It can be loaded from JSON with LoadCourse,
+// so courses can be designed without changing the code.
+type CourseConfig struct {
+	StartLine []geometry.Point `json:"start_line"`     // Pin end, then committee end (also used as finish line)
+	Marks     []CourseMark     `json:"marks"`          // The upwind mark, the only rounding mark (a list for the JSON layout)
+	Axis      float64          `json:"wind_direction"` // Median wind direction the course is laid out for (0 = North)
+	// Leeward gate passed between after rounding the marks, before finishing (nil = no gate)
+	Gate []CourseMark `json:"gate,omitempty"`
+	// Times round the course before the finish (0 or 1 = a single lap)
+	Laps int `json:"laps,omitempty"`
+	// Wind speeds in knots on the left and right of the course looking upwind (either 0 =
+	// 14 and 8 knots with a random side stronger)
+	WindLeft  float64 `json:"wind_left,omitempty"`
+	WindRight float64 `json:"wind_right,omitempty"`
+	// Average wind speeds in knots across the bottom and top of the course, so the wind
+	// builds or fades up the beat (either 0 = the same all the way up)
+	WindBottom float64 `json:"wind_bottom,omitempty"`
+	WindTop    float64 `json:"wind_top,omitempty"`
+	// Edge of the sailing area: "wall" (the default) or "shallows"
+	Boundary world.BoundaryMode `json:"boundary,omitempty"`
+}
+
+// DefaultCourseConfig returns the standard windward course: a 400m start line in the
+// center of the world with the upwind mark visible at the top of the screen
+func DefaultCourseConfig() CourseConfig {
+	// Position starting line in center of world, optimized for 720p view
+	pinX := float64(WorldWidth/2 - 200)       // Pin end (left) - shorter line
+	committeeX := float64(WorldWidth/2 + 200) // Committee end (right) - shorter line
+	lineY := float64(2400)                    // Positioned to accommodate upwind mark
+
+	return CourseConfig{
+		StartLine: []geometry.Point{
+			{X: pinX, Y: lineY},
+			{X: committeeX, Y: lineY},
+		},
+		Marks: []CourseMark{
+			// Center of starting line, visible at top of screen with margin
+			{Name: "Upwind", Pos: geometry.Point{X: (pinX + committeeX) / 2, Y: lineY - float64(ScreenHeight) + 100}},
+		},
+	}
+}
+
+// WindwardLeewardCourseConfig returns the standard course with an 80m leeward gate
+// 150m above the middle of the start line, sailed through on the run back to the finish
+func WindwardLeewardCourseConfig() CourseConfig {
+	course := DefaultCourseConfig()
+	pin, committee := course.StartLine[0], course.StartLine[1]
+	midX, gateY := (pin.X+committee.X)/2, (pin.Y+committee.Y)/2-150
+	course.Gate = []CourseMark{
+		{Name: "Gate Left", Pos: geometry.Point{X: midX - 40, Y: gateY}},
+		{Name: "Gate Right", Pos: geometry.Point{X: midX + 40, Y: gateY}},
+	}
+	return course
+}
+
+// rounding converts the mark's rounding side to the arena's
+func (m CourseMark) rounding() world.Rounding {
+	if m.Rounding == RoundingStarboard {
+		return world.RoundToStarboard
+	}
+	return world.RoundToPort
+}
+
+// LoadCourse reads a course layout from JSON and validates it
+func LoadCourse(r io.Reader) (CourseConfig, error) {
+	var course CourseConfig
+	if err := json.NewDecoder(r).Decode(&course); err != nil {
+		return CourseConfig{}, fmt.Errorf("invalid course JSON: %w", err)
+	}
+	if err := course.Validate(); err != nil {
+		return CourseConfig{}, err
+	}
+	return course, nil
+}
+
+// signature identifies the race sailed on this course in the given boat class
+// ("keelboat_1lap_9f2c01ab"), so stored results are only compared with the same race.
+// The hash covers the layout, wind and boundary; laps and class are spelled out.
+func (c CourseConfig) signature(class *objects.BoatClass) string {
+	if class == nil {
+		class = &objects.Keelboat
+	}
+	c.Laps = max(1, c.Laps)
+	layout, _ := json.Marshal(c)
+	hash := fnv.New32a()
+	hash.Write(layout)
+	return fmt.Sprintf("%s_%dlap_%08x", class.Name, c.Laps, hash.Sum32())
+}
+
+// windGradient returns the average wind speeds across the bottom and top of the course,
+// or false if the course doesn't set both
+func (c CourseConfig) windGradient() (bottom, top float64, ok bool) {
+	return c.WindBottom, c.WindTop, c.WindBottom > 0 && c.WindTop > 0
+}
+
+// windSpeeds returns the wind speeds on the left and right of the course, picking a
+// stronger side at random unless the course sets both
+func (c CourseConfig) windSpeeds(leftStronger bool) (left, right float64) {
+	if c.WindLeft > 0 && c.WindRight > 0 {
+		return c.WindLeft, c.WindRight
+	}
+	if leftStronger {
+		return 14, 8
+	}
+	return 8, 14
+}
+
+// Validate checks that the course has a two-point start line running east-west, a single
+// upwind mark north of it with a known rounding side, either no leeward gate or a two-mark
+// one, no negative wind speeds, and every line end and mark inside the sailing area and
+// clear of the shallows. The race logic sails one beat up from a level line, so
+// extra marks and angled lines are rejected rather than drawn but never raced.
+func (c CourseConfig) Validate() error {
+	if len(c.StartLine) != 2 {
+		return fmt.Errorf("%w (got %d points)", ErrMissingStartLine, len(c.StartLine))
+	}
+	if c.StartLine[0] == c.StartLine[1] {
+		return fmt.Errorf("%w (pin and committee are at the same position)", ErrMissingStartLine)
+	}
+	if c.StartLine[0].Y != c.StartLine[1].Y {
+		return fmt.Errorf("%w (pin at y %.0f, committee at y %.0f)", ErrAngledLine, c.StartLine[0].Y, c.StartLine[1].Y)
+	}
+	if len(c.Marks) == 0 {
+		return ErrMissingMark
+	}
+	if len(c.Marks) > 1 {
+		return fmt.Errorf("%w (got %d marks)", ErrExtraMarks, len(c.Marks))
+	}
+	if c.Marks[0].Pos.Y >= c.StartLine[0].Y {
+		return fmt.Errorf("%w (mark %q at y %.0f, line at y %.0f)", ErrMarkBelowLine, c.Marks[0].Name, c.Marks[0].Pos.Y, c.StartLine[0].Y)
+	}
+	for _, m := range c.Marks {
+		if m.Rounding != "" && m.Rounding != RoundingPort && m.Rounding != RoundingStarboard {
+			return fmt.Errorf("%w (mark %q has %q)", ErrInvalidRounding, m.Name, m.Rounding)
+		}
+	}
+	if len(c.Gate) != 0 && (len(c.Gate) != 2 || c.Gate[0].Pos == c.Gate[1].Pos) {
+		return fmt.Errorf("%w (got %d marks)", ErrInvalidGate, len(c.Gate))
+	}
+	if c.WindLeft < 0 || c.WindRight < 0 || c.WindBottom < 0 || c.WindTop < 0 {
+		return ErrInvalidWind
+	}
+	return c.validateBounds()
+}
+
+// validateBounds checks that the start line, mark and gate are in open water
+func (c CourseConfig) validateBounds() error {
+	bounds := c.boundary()
+	clearance := 0.0
+	if bounds.Mode == world.BoundaryShallows {
+		clearance = world.ShallowsWidth
+	}
+	check := func(what string, pos geometry.Point) error {
+		if bounds.DistanceToEdge(pos) < clearance {
+			return fmt.Errorf("%w (%s at %.0f,%.0f, sailing area %.0fx%.0fm with %s edges)",
+				ErrOutOfBounds, what, pos.X, pos.Y, bounds.Width, bounds.Height, bounds.Mode)
+		}
+		return nil
+	}
+	for i, pos := range c.StartLine {
+		end := "pin"
+		if i == 1 {
+			end = "committee"
+		}
+		if err := check(end, pos); err != nil {
+			return err
+		}
+	}
+	for _, m := range append(append([]CourseMark{}, c.Marks...), c.Gate...) {
+		if err := check(fmt.Sprintf("mark %q", m.Name), m.Pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boundary returns the edge of the world as the course's boundary
+func (c CourseConfig) boundary() *world.Boundary {
+	return &world.Boundary{Width: WorldWidth, Height: WorldHeight, Mode: c.Boundary}
+}
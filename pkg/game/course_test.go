@@ -0,0 +1,258 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestNewGameWithConfig_RejectsMissingStartLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		startLine []geometry.Point
+	}{
+		{"No start line", nil},
+		{"Single point", []geometry.Point{{X: 800, Y: 2400}}},
+		{"Pin and committee at same position", []geometry.Point{{X: 800, Y: 2400}, {X: 800, Y: 2400}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			course := DefaultCourseConfig()
+			course.StartLine = tt.startLine
+
+			g, err := NewGameWithConfig(course, nil)
+			if !errors.Is(err, ErrMissingStartLine) {
+				t.Errorf("Expected ErrMissingStartLine, got %v", err)
+			}
+			if g != nil {
+				t.Error("Expected no game for an invalid course")
+			}
+		})
+	}
+}
+
+func TestNewGameWithConfig_RejectsMissingMark(t *testing.T) {
+	course := DefaultCourseConfig()
+	course.Marks = nil
+
+	g, err := NewGameWithConfig(course, nil)
+	if !errors.Is(err, ErrMissingMark) {
+		t.Errorf("Expected ErrMissingMark, got %v", err)
+	}
+	if g != nil {
+		t.Error("Expected no game for an invalid course")
+	}
+}
+
+func TestNewGameWithConfig_ValidCourse(t *testing.T) {
+	course := CourseConfig{
+		StartLine: []geometry.Point{{X: 700, Y: 2000}, {X: 1300, Y: 2000}},
+		Marks:     []CourseMark{{Name: "Upwind", Pos: geometry.Point{X: 1000, Y: 1000}}},
+	}
+
+	g, err := NewGameWithConfig(course, nil)
+	if err != nil {
+		t.Fatalf("Expected valid course to be accepted, got %v", err)
+	}
+
+	if len(g.Arena.Marks) != 3 {
+		t.Fatalf("Expected pin, committee and upwind marks, got %d marks", len(g.Arena.Marks))
+	}
+	if pin, committee := g.Dashboard.Line.Ends(); pin != course.StartLine[0] || committee != course.StartLine[1] {
+		t.Errorf("Expected dashboard line to match course, got %v - %v", pin, committee)
+	}
+	if g.Dashboard.UpwindMark != course.Marks[0].Pos {
+		t.Errorf("Expected upwind mark at %v, got %v", course.Marks[0].Pos, g.Dashboard.UpwindMark)
+	}
+	// Boat starts below the middle of the line
+	if g.Boat.Pos.X != 1000 || g.Boat.Pos.Y <= 2000 {
+		t.Errorf("Expected boat to start below the middle of the line, got %v", g.Boat.Pos)
+	}
+}
+
+func TestStartLineResize_UpdatesBoundsAndDashboard(t *testing.T) {
+	g := NewGame(nil)
+	pin, committee := g.Arena.Line.Ends()
+	mid := g.Arena.Line.Midpoint()
+
+	// Just outside the committee end of the default 400m line
+	outside := geometry.Point{X: committee.X + 50, Y: committee.Y}
+	if g.isWithinLineBounds(outside) {
+		t.Fatalf("Expected %v to be outside the %.0fm line", outside, g.Arena.Line.Length())
+	}
+
+	g.Arena.Line.SetLength(600)
+
+	if !g.isWithinLineBounds(outside) {
+		t.Errorf("Expected %v to be within the lengthened line", outside)
+	}
+	if g.Arena.Marks[0].Pos.X != pin.X-100 || g.Arena.Marks[1].Pos.X != committee.X+100 {
+		t.Errorf("Expected pin and committee marks to move 100m out, got %v - %v", g.Arena.Marks[0].Pos, g.Arena.Marks[1].Pos)
+	}
+	if g.Dashboard.Line.Midpoint() != mid {
+		t.Errorf("Expected line to stay centered on %v, got %v", mid, g.Dashboard.Line.Midpoint())
+	}
+	if panel := g.Dashboard.CalculateStartPanel(time.Minute); panel.LineLength != 600 {
+		t.Errorf("Expected start panel to show a 600m line, got %.0fm", panel.LineLength)
+	}
+}
+
+func TestNewGameWithConfig_RejectsInvalidGate(t *testing.T) {
+	tests := []struct {
+		name string
+		gate []CourseMark
+	}{
+		{"Single mark", []CourseMark{{Name: "Gate Left", Pos: geometry.Point{X: 960, Y: 2250}}}},
+		{"Marks at the same position", []CourseMark{
+			{Name: "Gate Left", Pos: geometry.Point{X: 960, Y: 2250}},
+			{Name: "Gate Right", Pos: geometry.Point{X: 960, Y: 2250}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			course := DefaultCourseConfig()
+			course.Gate = tt.gate
+			if _, err := NewGameWithConfig(course, nil); !errors.Is(err, ErrInvalidGate) {
+				t.Errorf("Expected ErrInvalidGate, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewGameWithConfig_WindwardLeeward(t *testing.T) {
+	g, err := NewGameWithConfig(WindwardLeewardCourseConfig(), nil)
+	if err != nil {
+		t.Fatalf("Expected the windward-leeward course to be accepted, got %v", err)
+	}
+
+	if len(g.Arena.Marks) != 5 {
+		t.Fatalf("Expected pin, committee, upwind and two gate marks, got %d marks", len(g.Arena.Marks))
+	}
+	gate := g.Arena.Gate
+	if gate == nil || gate.Left != g.Arena.Marks[3] || gate.Right != g.Arena.Marks[4] {
+		t.Fatal("Expected the gate to refer to the last two arena marks")
+	}
+	if g.Dashboard.Gate != gate {
+		t.Error("Expected the dashboard to share the arena's gate")
+	}
+	// Between the upwind mark and the start line
+	if mid := gate.Midpoint(); mid.Y <= g.Dashboard.UpwindMark.Y || mid.Y >= g.Arena.Line.Midpoint().Y {
+		t.Errorf("Expected the gate between the upwind mark and the line, got %+v", mid)
+	}
+
+	// The default course still has no gate
+	if NewGame(nil).Arena.Gate != nil {
+		t.Error("Expected no gate on the default course")
+	}
+}
+
+func TestLoadCourse(t *testing.T) {
+	course, err := LoadCourse(strings.NewReader(`{
+		"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+		"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}, "rounding": "starboard"}],
+		"wind_direction": 10,
+		"laps": 2,
+		"wind_left": 12,
+		"wind_right": 9,
+		"wind_bottom": 8,
+		"wind_top": 13,
+		"boundary": "shallows"
+	}`))
+	if err != nil {
+		t.Fatalf("Expected the course to load, got %v", err)
+	}
+	if course.StartLine[1] != (geometry.Point{X: 1300, Y: 2000}) || course.Marks[0].Pos != (geometry.Point{X: 1000, Y: 1000}) {
+		t.Errorf("Expected the positions from the JSON, got %+v", course)
+	}
+	if course.Marks[0].Rounding != RoundingStarboard || course.Axis != 10 || course.Laps != 2 {
+		t.Errorf("Expected rounding, wind direction and laps from the JSON, got %+v", course)
+	}
+	if course.Boundary != world.BoundaryShallows {
+		t.Errorf("Expected shallows from the JSON, got %v", course.Boundary)
+	}
+
+	g, err := NewGameWithConfig(course, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Arena.Marks[2].Rounding != world.RoundToStarboard {
+		t.Error("Expected the upwind mark to be rounded to starboard")
+	}
+	if left, right := course.windSpeeds(false); left != 12 || right != 9 {
+		t.Errorf("Expected the course's wind speeds, got %.0f and %.0f kts", left, right)
+	}
+
+	// The wind builds up the beat, from the line toward the mark
+	g.SetGusts(world.GustConfig{})
+	_, atLine := g.Wind.GetWind(geometry.Point{X: 1000, Y: 2000})
+	_, pastMark := g.Wind.GetWind(geometry.Point{X: 1000, Y: 900})
+	if pastMark <= atLine {
+		t.Errorf("Expected more wind up the course, got %.2f kts at the line and %.2f past the mark", atLine, pastMark)
+	}
+}
+
+func TestLoadCourse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want error
+	}{
+		{"No start line", `{"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}]}`, ErrMissingStartLine},
+		{"No marks", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}]}`, ErrMissingMark},
+		{"Unknown rounding", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}, "rounding": "left"}]}`, ErrInvalidRounding},
+		{"Negative wind", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}], "wind_left": -3}`, ErrInvalidWind},
+		{"Unknown boundary", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}], "boundary": "lava"}`, world.ErrInvalidBoundary},
+		{"Mark off the world", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": -50}}]}`, ErrOutOfBounds},
+		{"Line end off the world", `{"start_line": [{"x": 700, "y": 2000}, {"x": 2300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}]}`, ErrOutOfBounds},
+		{"Gate in the shallows", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}],
+			"gate": [{"name": "Gate Left", "pos": {"x": 60, "y": 1800}}, {"name": "Gate Right", "pos": {"x": 140, "y": 1800}}],
+			"boundary": "shallows"}`, ErrOutOfBounds},
+		{"Extra mark", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}, {"name": "Wing", "pos": {"x": 1400, "y": 1400}}]}`, ErrExtraMarks},
+		{"Angled line", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 1900}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}]}`, ErrAngledLine},
+		{"Mark below the line", `{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+			"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 2500}}]}`, ErrMarkBelowLine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadCourse(strings.NewReader(tt.json)); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// The same gate is fine when the edge is a wall
+	if _, err := LoadCourse(strings.NewReader(`{"start_line": [{"x": 700, "y": 2000}, {"x": 1300, "y": 2000}],
+		"marks": [{"name": "Upwind", "pos": {"x": 1000, "y": 1000}}],
+		"gate": [{"name": "Gate Left", "pos": {"x": 60, "y": 1800}}, {"name": "Gate Right", "pos": {"x": 140, "y": 1800}}]}`)); err != nil {
+		t.Errorf("Expected a gate near a wall to load, got %v", err)
+	}
+
+	if _, err := LoadCourse(strings.NewReader(`{"marks": [`)); err == nil {
+		t.Error("Expected an error for malformed JSON")
+	}
+}
+
+func TestCourseWindSpeeds_RandomSideByDefault(t *testing.T) {
+	course := DefaultCourseConfig()
+	if left, right := course.windSpeeds(true); left != 14 || right != 8 {
+		t.Errorf("Expected a stronger left side, got %.0f and %.0f kts", left, right)
+	}
+	if left, right := course.windSpeeds(false); left != 8 || right != 14 {
+		t.Errorf("Expected a stronger right side, got %.0f and %.0f kts", left, right)
+	}
+}
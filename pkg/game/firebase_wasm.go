@@ -3,10 +3,14 @@
 package game
 
 import (
+	"sort"
 	"syscall/js"
 	"time"
 )
 
+// Most results loaded for a leaderboard tab, fastest first
+const firebaseLeaderboardLimit = 50
+
 // FirebaseClient handles Firebase Firestore operations in WASM
 type FirebaseClient struct {
 	firestore js.Value
@@ -32,6 +36,11 @@ func (fc *FirebaseClient) Initialize() {
 	fc.isReady = true
 }
 
+// Online is true: results are shared through Firestore
+func (fc *FirebaseClient) Online() bool {
+	return true
+}
+
 // SubmitScore submits a race result to Firestore
 func (fc *FirebaseClient) SubmitScore(result *RaceResult, callback func(bool, string)) {
 	if !fc.isReady {
@@ -52,6 +61,8 @@ func (fc *FirebaseClient) SubmitScore(result *RaceResult, callback func(bool, st
 		"mark_rounded":      result.MarkRounded,
 		"distance_sailed":   result.DistanceSailed,
 		"average_speed":     result.AverageSpeed,
+		"laps":              result.Laps,
+		"course":            result.Course,
 		"timestamp":         result.Timestamp.Unix(),
 	}
 
@@ -89,8 +100,8 @@ func (fc *FirebaseClient) SubmitScore(result *RaceResult, callback func(bool, st
 	promise.Call("catch", errorCallback)
 }
 
-// GetLeaderboard retrieves the top race results from Firestore
-func (fc *FirebaseClient) GetLeaderboard(callback func([]RaceResult, string)) {
+// GetLeaderboard retrieves the top race results since the given time from Firestore
+func (fc *FirebaseClient) GetLeaderboard(since time.Time, callback func([]RaceResult, string)) {
 	if !fc.isReady {
 		fc.Initialize()
 	}
@@ -100,11 +111,19 @@ func (fc *FirebaseClient) GetLeaderboard(callback func([]RaceResult, string)) {
 		return
 	}
 
-	// Query Firestore for race results, ordered by race time, limited to 50
+	// Query Firestore for the fastest race results. Firestore wants the first orderBy on a
+	// range-filtered field, so a tab's results are ordered by timestamp and loaded in full,
+	// then sorted by race time and cut to the limit here.
 	collection := fc.firestore.Call("collection", "race_results")
 	query := collection.Call("where", "mark_rounded", "==", true)
-	query = query.Call("orderBy", "race_time_seconds", "asc")
-	query = query.Call("limit", 50)
+	if since.IsZero() {
+		query = query.Call("orderBy", "race_time_seconds", "asc")
+		query = query.Call("limit", firebaseLeaderboardLimit)
+	} else {
+		// Timestamps are stored as Unix seconds (needs a mark_rounded + timestamp index)
+		query = query.Call("where", "timestamp", ">=", since.Unix())
+		query = query.Call("orderBy", "timestamp", "asc")
+	}
 
 	// Create success callback - don't use defer, release manually in callback
 	var successCallback js.Func
@@ -135,12 +154,19 @@ func (fc *FirebaseClient) GetLeaderboard(callback func([]RaceResult, string)) {
 				MarkRounded:     getBoolValue(data, "mark_rounded"),
 				DistanceSailed:  getFloatValue(data, "distance_sailed"),
 				AverageSpeed:    getFloatValue(data, "average_speed"),
+				Laps:            int(getFloatValue(data, "laps")),
+				Course:          getStringValue(data, "course"),
 				Timestamp:       time.Unix(int64(getFloatValue(data, "timestamp")), 0),
 			}
 
 			results = append(results, result)
 		}
 
+		sort.SliceStable(results, func(i, j int) bool { return results[i].RaceTimeSeconds < results[j].RaceTimeSeconds })
+		if len(results) > firebaseLeaderboardLimit {
+			results = results[:firebaseLeaderboardLimit]
+		}
+
 		callback(results, "")
 		return nil
 	})
@@ -15,7 +15,6 @@ import (
 	"github.com/mpihlak/gosailing2/pkg/game/objects"
 	"github.com/mpihlak/gosailing2/pkg/game/world"
 	"github.com/mpihlak/gosailing2/pkg/geometry"
-	"github.com/mpihlak/gosailing2/pkg/polars"
 )
 
 const (
@@ -23,27 +22,42 @@ const (
 	ScreenHeight = 720
 	// Real world scale: 1 pixel = 1 meter for easier calculations
 	PixelsPerMeter = 1.0
-	WorldWidth     = 2000                 // World is larger than screen
-	WorldHeight    = 3000                 // Expanded to accommodate upwind mark at Y=-1200
-	inputDelay     = 0 * time.Millisecond // Delay between keystroke readings
+	WorldWidth     = 2000 // World is larger than screen
+	WorldHeight    = 3000 // Expanded to accommodate upwind mark at Y=-1200
+	// Camera can scroll this far past the world edges so the boat stays framed at corners
+	cameraOverscroll = 200.0
+	// Meters added or removed per keypress when resizing the start line
+	startLineResizeStep = 20.0
 )
 
 type GameState struct {
-	Boat           *objects.Boat
-	Arena          *world.Arena
-	Wind           world.Wind
-	Dashboard      *dashboard.Dashboard
-	CameraX        float64 // Camera offset for panning
-	CameraY        float64
-	lastInput      time.Time // Last time input was processed
-	isPaused       bool      // Game pause state
-	lastPauseInput time.Time // Last time pause key was pressed
+	Boat            *objects.Boat
+	Arena           *world.Arena
+	Wind            world.Wind
+	Current         world.Current // Tidal current (nil = slack water)
+	Dashboard       *dashboard.Dashboard
+	CameraX         float64 // Camera offset for panning
+	CameraY         float64
+	cameraHoldUntil time.Duration // Game time the camera holds still until after a two-finger pan
+	isPaused        bool          // Game pause state
+	lastPauseInput  time.Time     // Last time pause key was pressed
+	// AI opponents
+	opponents []*Opponent
+	// Simulation speed (bullet time)
+	stepAccumulator float64 // Fractional physics steps carried over between frames
+	slowMotion      bool    // Whether the simulation is currently slowed down
 	// Mobile controls
 	mobileControls *MobileControls
+	gamepad        *GamepadControls
 	// Telltales for sailing feedback
 	telltales *Telltales
 	// Reusable images to avoid creating new ones every frame
-	worldImage *ebiten.Image
+	worldImage  *ebiten.Image // Visible world at internal render resolution
+	hudImage    *ebiten.Image // Logical-size UI layer, scaled up when supersampling
+	supersample int           // Internal render resolution multiplier (1 = native, 2 = 2x)
+
+	// Logical screen size, following the window or browser canvas
+	screenWidth, screenHeight int
 	// Race start timer (elapsed time based for pause support)
 	timerDuration  time.Duration // Total duration for race start (30 seconds)
 	elapsedTime    time.Duration // Time elapsed since game start (only when not paused)
@@ -52,6 +66,8 @@ type GameState struct {
 	raceTimer      time.Duration // Time since race started (counts up from 0)
 	// OCS detection
 	isOCS bool // Whether boat is On Course Side
+	// Start verdict flashed with the START banner
+	startVerdict string
 	// Line crossing tracking
 	hasCrossedLine   bool           // Whether boat has crossed the starting line after race start
 	lineCrossingTime time.Duration  // When boat crossed the line (race timer, not elapsed time)
@@ -64,11 +80,24 @@ type GameState struct {
 	markRoundingPhase2 bool // Travelled to left (east to west while north)
 	markRoundingPhase3 bool // Sailed below mark (north to south)
 	markRounded        bool // All three phases completed
+	// On the layline for the other tack: tacking now lays the upwind mark
+	tackNow bool
+	// Rounding the mark the wrong way (leaving it on the wrong side)
+	markCrossings int     // Net times over the top of the mark in the rounding direction since last below it
+	markAcross    float64 // Last nonzero distance across the top of the mark while above it (0 = below it)
+	wrongRounding bool    // Circled the mark the wrong way; cleared by rounding it correctly
+	// Leeward gate (windward-leeward course only)
+	gatePassed bool // Passed between the gate marks after rounding
+	// Multi-lap races
+	lapsCompleted int // Laps finished so far; the race ends after the last
 	// Race completion
 	raceFinished     bool          // Whether boat has finished the race
 	finishTime       time.Duration // Race time when boat finished
 	showFinishBanner bool          // Whether to show finish banner
 	finishBannerTime time.Time     // When finish banner was triggered
+	newPersonalBest  bool          // Whether the finish beat the stored personal best
+	previousBest     time.Duration // Personal best before this race (0 if this was the first finish)
+	scoreboardShown  bool          // Whether the scoreboard has been offered for this finish
 	// Restart banner
 	showRestartBanner bool      // Whether to show restart banner
 	restartBannerTime time.Time // When restart banner was triggered
@@ -80,54 +109,108 @@ type GameState struct {
 	// Collision tracking
 	penaltyCount      int                    // Total collision penalties
 	collisionHistory  []world.CollisionEvent // All collisions for review/display
-	lastCollisionTime time.Time              // Debounce repeated collisions
+	lastCollisionTime time.Duration          // Game time of the last foul, to debounce repeated collisions
 	// Collision visual feedback
 	showCollisionFlash bool      // Whether to show collision flash
 	collisionFlashTime time.Time // When collision flash was triggered
+	// Mark touched banner
+	touchedMark     string    // Name of the mark last touched ("" = banner hidden)
+	touchedMarkTime time.Time // When the mark was touched
+	// Penalty turns owed after touching a mark
+	penaltyPending bool    // Whether the boat must turn before it can finish
+	penaltyTurned  float64 // Heading change so far toward the penalty (signed, degrees)
 	// Distance tracking
-	distanceSailed     float64        // Total distance sailed since crossing start line (meters)
-	prevBoatPos        geometry.Point // Previous boat position for distance calculation
-	averageSpeed       float64        // Average speed over the race (knots)
-	speedSum           float64        // Sum of boat speeds for calculating average
-	speedSamples       int            // Number of speed samples taken
-}
-
-func NewGame() *GameState {
-	// 50:50 chance for which side has stronger wind
-	var leftSpeed, rightSpeed float64
-	if rand.Float32() < 0.5 {
-		// Left side stronger
-		leftSpeed = 14 // 14 kts on left side
-		rightSpeed = 8 // 8 kts on right side
-	} else {
-		// Right side stronger
-		leftSpeed = 8   // 8 kts on left side
-		rightSpeed = 14 // 14 kts on right side
+	distanceSailed float64 // Total distance sailed since crossing start line (meters)
+	averageSpeed   float64 // Average speed over the race (knots)
+	// Race replay
+	replay *ReplayState // Recorded race with seekable playback
+	ghost  *ReplayTrack // Personal best run sailed alongside the race (nil = no best yet)
+	// Course layout, boat class, gusts and wind shadows (kept for restarts)
+	course    CourseConfig
+	boatClass *objects.BoatClass
+	gusts     world.GustConfig
+	shadow    world.ShadowConfig
+	// Player preferences (kept for restarts)
+	settings Settings
+	// Start rehearsal: loop the final minute before the gun
+	rehearsalMode     bool               // Whether the start sequence loops
+	rehearsalSnapshot *rehearsalSnapshot // Boat state at the loop point
+	// Persistent storage (autosaved results) and the leaderboard set with SetLeaderboard
+	// (nil = the platform's default), both kept for restarts
+	store       KeyValueStore
+	leaderboard LeaderboardBackend
+	// Tack shift analysis
+	prevTWA float64      // Previous frame's TWA for tack detection
+	tacks   []TackRecord // Wind state at each tack during the race
+	// One-key tack in progress (T key)
+	autoTack autoTack
+	// Wind at the boat each second of this race, and a recorded log to sail in instead
+	// (kept for restarts)
+	windLog    world.WindRecorder
+	windReplay []world.WindSample
+	// Start sequence beeps (nil = silent)
+	playTone func(startTone)
+}
+
+// NewGame creates a game on the default windward course sailing the given boat class
+// (nil = keelboat)
+func NewGame(class *objects.BoatClass) *GameState {
+	return newGameWithCourse(DefaultCourseConfig(), class)
+}
+
+// NewGameWithConfig creates a game on a custom course, rejecting layouts the race
+// logic can't run on (no start line or no rounding mark)
+func NewGameWithConfig(course CourseConfig, class *objects.BoatClass) (*GameState, error) {
+	if err := course.Validate(); err != nil {
+		return nil, err
+	}
+	return newGameWithCourse(course, class), nil
+}
+
+// newGameWithCourse sets up wind, boat and marks for a validated course
+func newGameWithCourse(course CourseConfig, class *objects.BoatClass) *GameState {
+	if class == nil {
+		class = &objects.Keelboat
 	}
 
+	// The course can set the wind on each side; otherwise 50:50 chance for which side has stronger wind
+	leftSpeed, rightSpeed := course.windSpeeds(rand.Float32() < 0.5)
+
 	wind := world.NewOscillatingWind(
 		leftSpeed,  // Variable wind speed on left side
 		rightSpeed, // Variable wind speed on right side
 		WorldWidth, // Use world width for interpolation
+		course.Axis,
 	)
+	gusts := world.DefaultGustConfig()
+	wind.SetGusts(gusts, WorldHeight)
+	if bottom, top, ok := course.windGradient(); ok {
+		wind.SetGradient(bottom, top, WorldHeight)
+	}
+
+	pin := course.StartLine[0]
+	committee := course.StartLine[1]
+	upwind := course.Marks[0]
 
-	// Position starting line in center of world, optimized for 720p view
-	// Starting line at Y = 2400, shorter line (400m instead of 600m)
-	// Upwind mark positioned to be immediately visible at top of screen
-	pinX := float64(WorldWidth/2 - 200)       // Pin end (left) - shorter line
-	committeeX := float64(WorldWidth/2 + 200) // Committee end (right) - shorter line
-	lineY := float64(2400)                    // Positioned to accommodate upwind mark
+	// Strong and weak sides are measured across the course axis, which pivots about the
+	// middle of the world halfway up the beat
+	courseMidY := ((pin.Y+committee.Y)/2 + upwind.Pos.Y) / 2
+	wind.SetCourseCenter(geometry.Point{X: WorldWidth / 2, Y: courseMidY})
 
 	// Boat starts 180 meters below middle of line, sailing parallel to line towards committee boat
-	boatStartX := (pinX + committeeX) / 2 // Middle of the starting line
-	boatStartY := lineY + 180             // 180 meters below the line
+	boatStartX := (pin.X + committee.X) / 2   // Middle of the starting line
+	boatStartY := (pin.Y+committee.Y)/2 + 180 // 180 meters below the line
 
 	boat := &objects.Boat{
 		Pos:     geometry.Point{X: boatStartX, Y: boatStartY},
 		Heading: 90, // Sailing East (parallel to line, towards committee boat)
 		Speed:   0,  // Will be set to target speed
-		Polars:  &polars.RealisticPolar{},
+		Class:   class,
+		Polars:  class.Polars,
 		Wind:    wind,
+		// Dinghies can be overpowered on a broad reach
+		BroachEnabled: class.Broaches,
+		Boundary:      course.boundary(),
 	}
 
 	// Initialize boat at full target speed for current heading and wind conditions
@@ -147,41 +230,89 @@ func NewGame() *GameState {
 	boat.VelX = targetPixelSpeed * math.Sin(headingRad)
 	boat.VelY = -targetPixelSpeed * math.Cos(headingRad) // Y inverted
 
-	// Calculate upwind mark position (positioned to be visible at top of screen)
-	upwindMarkX := (pinX + committeeX) / 2             // Center of starting line
-	upwindMarkY := lineY - float64(ScreenHeight) + 100 // Visible at top of screen with margin
+	// Start line marks first, then rounding marks in course order
+	marks := []*world.Mark{
+		{Pos: pin, Name: "Pin"},
+		{Pos: committee, Name: "Committee", Solid: true},
+	}
+	for _, m := range course.Marks {
+		marks = append(marks, &world.Mark{Pos: m.Pos, Name: m.Name, Rounding: m.rounding()})
+	}
+
+	// The line refers to the pin and committee marks, so the arena, dashboard and
+	// OCS checks all see the same ends
+	line := &world.StartLine{Pin: marks[0], Committee: marks[1]}
+
+	// The leeward gate marks come last, and the gate refers to them the same way
+	var gate *world.Gate
+	if len(course.Gate) == 2 {
+		left := &world.Mark{Pos: course.Gate[0].Pos, Name: course.Gate[0].Name}
+		right := &world.Mark{Pos: course.Gate[1].Pos, Name: course.Gate[1].Name}
+		marks = append(marks, left, right)
+		gate = &world.Gate{Left: left, Right: right}
+	}
+
+	// Everyone sails in the disturbed air behind the marks and the committee boat
+	shadow := world.DefaultShadowConfig()
+	shadowedWind := world.NewShadowedWind(wind, marks, line.Committee, shadow)
+	boat.Wind = shadowedWind
 
 	arena := &world.Arena{
-		Marks: []*world.Mark{
-			{Pos: geometry.Point{X: pinX, Y: lineY}, Name: "Pin"},
-			{Pos: geometry.Point{X: committeeX, Y: lineY}, Name: "Committee"},
-			{Pos: geometry.Point{X: upwindMarkX, Y: upwindMarkY}, Name: "Upwind"},
-		},
+		Marks:  marks,
+		Line:   line,
+		Gate:   gate,
+		Polars: boat.Polars,
 	}
 	dash := &dashboard.Dashboard{
 		Boat:       boat,
-		Wind:       wind,
+		Wind:       shadowedWind,
 		StartTime:  time.Now().Add(5 * time.Minute),
-		LineStart:  geometry.Point{X: pinX, Y: lineY},              // Pin end
-		LineEnd:    geometry.Point{X: committeeX, Y: lineY},        // Committee end
-		UpwindMark: geometry.Point{X: upwindMarkX, Y: upwindMarkY}, // Upwind mark
+		Line:       line,
+		UpwindMark: upwind.Pos, // Upwind mark
+		Gate:       gate,
 	}
 
+	// Results are autosaved on finish and cleared once the scoreboard has handled them. They
+	// stay in memory until SetStore hands over the player's real storage, so tests and
+	// headless simulations never touch it.
+	store := newMemoryStore()
+	scoreboard := NewScoreboard(newLeaderboardBackend(store, course.signature(class)))
+	scoreboard.store = store
+	scoreboard.nameFilter = defaultNameFilter()
+
+	// Race against the personal best run, if there is one
+	ghost, _ := LoadPersonalBestTrack(store, course.signature(class))
+
+	// Touch buttons where the player last put them
+	settings := DefaultSettings()
+	settings.Controls = LoadControlLayout(store)
+	mobileControls := NewMobileControls(ScreenWidth, ScreenHeight)
+	mobileControls.SetLayout(settings.Controls)
+
 	// Initialize camera to show full starting area (center on starting line)
-	cameraX := (pinX+committeeX)/2 - float64(ScreenWidth)/2 // Center line horizontally
-	cameraY := lineY - float64(ScreenHeight)/2 + 50         // Show line and upwind mark
+	cameraX := (pin.X+committee.X)/2 - float64(ScreenWidth)/2       // Center line horizontally
+	cameraY := (pin.Y+committee.Y)/2 - float64(ScreenHeight)/2 + 50 // Show line and upwind mark
 
 	return &GameState{
 		Boat:           boat,
 		Arena:          arena,
-		Wind:           wind,
+		Wind:           shadowedWind,
 		Dashboard:      dash,
 		CameraX:        cameraX,
 		CameraY:        cameraY,
-		mobileControls: NewMobileControls(ScreenWidth, ScreenHeight),
+		course:         course,
+		boatClass:      class,
+		gusts:          gusts,
+		shadow:         shadow,
+		settings:       settings,
+		mobileControls: mobileControls,
+		gamepad:        NewGamepadControls(),
 		telltales:      NewTelltales(ScreenWidth, ScreenHeight),
-		scoreboard:     NewScoreboard(),
-		worldImage:     ebiten.NewImage(WorldWidth, WorldHeight),
+		scoreboard:     scoreboard,
+		store:          store,
+		playTone:       playStartTone,
+		replay:         NewReplayState(ScreenWidth, ScreenHeight),
+		ghost:          ghost,
 		isPaused:       true,             // Start game in paused mode
 		timerDuration:  30 * time.Second, // Race starts after 30 seconds
 		elapsedTime:    0,                // No time elapsed yet
@@ -206,9 +337,12 @@ func NewGame() *GameState {
 }
 
 func (g *GameState) Update() error {
-	// Process mobile touch input
-	g.mobileControls.Update()
-	mobileInput := g.mobileControls.GetMobileInput()
+	// Process mobile touch and gamepad input; keyboard and all other sources feed one input path
+	g.mobileControls.Update(g.renderScale())
+	g.gamepad.Update()
+	keyboardLeft := ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA)
+	keyboardRight := ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD)
+	input := combineInput(keyboardLeft, keyboardRight, g.mobileControls.GetMobileInput(), g.gamepad.GetGamepadInput())
 
 	// Update scoreboard (handles input when visible)
 	g.scoreboard.Update()
@@ -232,10 +366,9 @@ func (g *GameState) Update() error {
 			g.mobileControls.ToggleControlsOverride()
 		}
 
-		// Handle restart key (keyboard or mobile)
-		if inpututil.IsKeyJustPressed(ebiten.KeyR) || mobileInput.RestartPressed {
-			newGame := NewGame()
-			*g = *newGame
+		// Handle restart key (keyboard, mobile or gamepad)
+		if inpututil.IsKeyJustPressed(ebiten.KeyR) || input.RestartPressed {
+			g.restart()
 			// Unpause and show restart banner
 			g.isPaused = false
 			g.showRestartBanner = true
@@ -252,36 +385,153 @@ func (g *GameState) Update() error {
 			}
 		}
 
-		// Handle 'L' key to show leaderboard (WASM only)
-		if inpututil.IsKeyJustPressed(ebiten.KeyL) && IsWASM() {
+		// Handle '[' and ']' keys to shorten or lengthen the start line during setup
+		if !g.raceStarted {
+			if inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+				g.Arena.Line.SetLength(g.Arena.Line.Length() - startLineResizeStep)
+			}
+			if inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+				g.Arena.Line.SetLength(g.Arena.Line.Length() + startLineResizeStep)
+			}
+		}
+
+		// Handle 'H' key to show or hide the full help on pause
+		if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+			g.settings.ShowHelpOnPause = !g.settings.ShowHelpOnPause
+		}
+
+		// Handle 'N' key to toggle numeric wind speed labels
+		if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+			g.settings.ShowWindLabels = !g.settings.ShowWindLabels
+		}
+
+		// Handle 'E' key to toggle start rehearsal (before the gun)
+		if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+			g.toggleRehearsal()
+		}
+
+		// Handle 'P' key to toggle the apparent wind arrow on the compass rose
+		if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+			g.settings.ShowApparentWind = !g.settings.ShowApparentWind
+		}
+
+		// Handle 'G' key to toggle the line sag coaching overlay
+		if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+			g.settings.ShowLineSag = !g.settings.ShowLineSag
+		}
+
+		// Handle 'B' key to toggle bullet time (easy mode)
+		if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+			g.settings.BulletTime = !g.settings.BulletTime
+		}
+
+		// Handle 'K' key to toggle the target speed readout
+		if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+			g.settings.ShowTargetSpeed = !g.settings.ShowTargetSpeed
+		}
+
+		// Handle 'Y' key to toggle the TACK NOW layline cue
+		if inpututil.IsKeyJustPressed(ebiten.KeyY) {
+			g.settings.ShowTackNow = !g.settings.ShowTackNow
+		}
+
+		// Handle 'M' key to mute the start sequence beeps
+		if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+			g.settings.Muted = !g.settings.Muted
+		}
+
+		// Handle 'I' key to toggle between VMG to the wind and VMG to the mark
+		if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+			g.settings.ShowVMC = !g.settings.ShowVMC
+		}
+
+		// Handle 'F' key to cycle the camera mode
+		if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+			g.settings.Camera = g.settings.Camera.Next()
+			g.snapCamera()
+		}
+
+		// Handle 'U' key or the layout button on the pause screen to cycle the touch button layout
+		if inpututil.IsKeyJustPressed(ebiten.KeyU) || (input.LayoutPressed && g.isPaused) {
+			g.cycleControlLayout()
+		}
+
+		// Handle 'O' key to toggle steering toward the mouse cursor
+		if inpututil.IsKeyJustPressed(ebiten.KeyO) {
+			g.settings.MouseSteering = !g.settings.MouseSteering
+		}
+
+		// Handle '+' and '-' keys to zoom the course in and out
+		if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadAdd) {
+			g.setZoom(g.zoom() * zoomStep)
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadSubtract) {
+			g.setZoom(g.zoom() / zoomStep)
+		}
+		if input.Zoom > 0 {
+			g.setZoom(g.zoom() * input.Zoom)
+		}
+		if input.PanX != 0 || input.PanY != 0 {
+			g.panCamera(input.PanX, input.PanY)
+		}
+
+		// Handle 'T' key to auto-tack onto the close-hauled heading on the other tack
+		if inpututil.IsKeyJustPressed(ebiten.KeyT) && !g.raceFinished {
+			g.toggleAutoTack()
+		}
+
+		// Handle 'Z' key or gamepad X to hoist or drop the spinnaker (hoisting only when sailing deep)
+		if (inpututil.IsKeyJustPressed(ebiten.KeyZ) || input.SpinnakerPressed) && !g.raceFinished {
+			g.Boat.ToggleSpinnaker()
+		}
+
+		// Handle 'V' key to watch the replay once the race is finished
+		if inpututil.IsKeyJustPressed(ebiten.KeyV) && g.raceFinished && !g.scoreboard.IsVisible() {
+			if g.replay.Active {
+				g.replay.Stop()
+			} else {
+				g.replay.Start()
+			}
+			g.lastUpdateTime = time.Now()
+		}
+
+		// Handle 'L' key to show leaderboard
+		if inpututil.IsKeyJustPressed(ebiten.KeyL) {
 			g.isPaused = true
 			g.scoreboard.ShowLeaderboardOnly(nil)
 		}
 	}
 
-	// Handle pause toggle (keyboard or mobile)
+	// Replay mode takes over the game loop until exited
+	if g.replay.Active {
+		now := time.Now()
+		g.replay.Update(now.Sub(g.lastUpdateTime), float64(g.renderScale()))
+		g.lastUpdateTime = now
+		g.updateReplayCamera()
+		return nil
+	}
+
+	// Handle pause toggle (keyboard, mobile or gamepad)
 	// Skip pause handling when scoreboard is capturing input (except mobile touch)
 	var pauseTogglePressed bool
 	if !g.scoreboard.IsCapturingInput() {
-		pauseTogglePressed = inpututil.IsKeyJustPressed(ebiten.KeySpace) || mobileInput.PausePressed
+		pauseTogglePressed = inpututil.IsKeyJustPressed(ebiten.KeySpace) || input.PausePressed
 
-		// On mobile, any touch when paused should unpause (except on buttons)
+		// On mobile, a tap when paused should unpause (except on buttons); lifting the
+		// fingers after a pinch or pan leaves it paused
 		if g.isPaused && g.mobileControls.hasTouchInput {
-			justPressedTouchIDs := inpututil.AppendJustPressedTouchIDs(nil)
-			for _, touchID := range justPressedTouchIDs {
-				x, y := ebiten.TouchPosition(touchID)
-				// Only unpause if touch is not on any mobile control buttons
-				if !g.mobileControls.pauseButton.Contains(x, y) &&
-					!g.mobileControls.leftButton.Contains(x, y) &&
-					!g.mobileControls.rightButton.Contains(x, y) &&
-					!g.mobileControls.restartButton.Contains(x, y) {
-					pauseTogglePressed = true
-					break
-				}
+			// Only unpause if touch is not on any mobile control buttons
+			if x, y, ok := g.mobileControls.Tap(); ok && !g.mobileControls.overButton(x, y) {
+				pauseTogglePressed = true
 			}
 		}
 	}
 
+	// Pause when the gamepad is unplugged so the boat doesn't sail on unattended
+	if input.ControllerLost && !g.isPaused {
+		pauseTogglePressed = true
+	}
+
 	if pauseTogglePressed {
 		g.isPaused = !g.isPaused
 		if !g.isPaused {
@@ -295,31 +545,100 @@ func (g *GameState) Update() error {
 		return nil
 	}
 
-	// Update wind oscillations (only when not paused)
-	if oscillatingWind, ok := g.Wind.(*world.OscillatingWind); ok {
-		oscillatingWind.UpdateWithElapsedTime(g.elapsedTime.Seconds())
-	}
-
-	// Update elapsed time (only when not paused)
+	// Update elapsed time (only when not paused), slowed down in bullet time
 	now := time.Now()
-	deltaTime := now.Sub(g.lastUpdateTime)
-	g.elapsedTime += deltaTime
+	scale := g.timeScale()
+	g.slowMotion = scale < 1
+	deltaTime := time.Duration(float64(now.Sub(g.lastUpdateTime)) * scale)
 	g.lastUpdateTime = now
+	prevElapsed := g.elapsedTime
+	g.advanceClock(deltaTime)
+	g.updateStartBeeps(prevElapsed)
+
+	// Rewind to one minute before the gun shortly after the start when rehearsing
+	g.updateRehearsal()
 
 	// Hide restart banner after 2 seconds
 	if g.showRestartBanner && time.Since(g.restartBannerTime) > 2*time.Second {
 		g.showRestartBanner = false
 	}
 
+	// Bring up the scoreboard shortly after the finish
+	g.updateFinishScoreboard()
+
 	// Hide finish banner after 5 seconds
 	if g.showFinishBanner && time.Since(g.finishBannerTime) > 5*time.Second {
 		g.showFinishBanner = false
 	}
 
+	// Start, rounding and finish checks on the game clock
+	g.updateRace(deltaTime)
+
+	// Combined keyboard, mobile and gamepad steering, or the mouse when it's on and nothing
+	// else is steering; none while the scoreboard takes text input
+	turn := input.Turn
+	if turn == 0 && g.settings.MouseSteering && !g.scoreboard.IsVisible() {
+		turn = g.mouseTurn()
+	}
+	if g.scoreboard.IsCapturingInput() {
+		turn = 0
+	}
+
+	// Steering and boat physics run in fixed steps; fewer steps per frame in bullet time
+	for step := g.simulationSteps(scale); step > 0; step-- {
+		g.stepPhysics(turn)
+	}
+
+	// Hide collision flash after 250ms
+	if g.showCollisionFlash && time.Since(g.collisionFlashTime) > 250*time.Millisecond {
+		g.showCollisionFlash = false
+	}
+
+	// Hide mark touched banner after 2 seconds
+	if g.touchedMark != "" && time.Since(g.touchedMarkTime) > 2*time.Second {
+		g.touchedMark = ""
+	}
+
+	// Update telltales based on current boat performance
+	g.telltales.Update(g.Boat, g.Wind, g.Dashboard)
+
+	// Calculate distance to line crossing point (during pre-start)
+	g.distanceToLineCrossing = g.calculateDistanceToLineCrossing()
+	g.timeToCross = g.calculateTimeToCross()
+
+	// Update camera to follow boat when it moves out of bounds
+	g.updateCamera(deltaTime)
+
+	return nil
+}
+
+// advanceClock moves the game clock on by deltaTime, bringing the wind up to date and
+// logging it at the boat
+func (g *GameState) advanceClock(deltaTime time.Duration) {
+	g.elapsedTime += deltaTime
+
+	// Update wind oscillations (or the recorded wind) on the game clock, so they hold still while paused
+	switch wind := g.undisturbedWind().(type) {
+	case *world.OscillatingWind:
+		wind.UpdateWithElapsedTime(g.elapsedTime.Seconds())
+	case *world.ReplayWind:
+		wind.SetElapsed(g.elapsedTime.Seconds())
+	}
+
+	// Log the wind the boat sails in so the race can be sailed again in the same conditions
+	if !g.raceFinished {
+		g.windLog.Record(g.elapsedTime.Seconds(), g.undisturbedWind(), g.Boat.Pos)
+	}
+}
+
+// updateRace starts the race at the gun and runs the OCS, start line, mark rounding and
+// finish checks against where the bow has got to since the last update
+func (g *GameState) updateRace(deltaTime time.Duration) {
 	// Check race start timer based on elapsed time
 	if !g.raceStarted && g.elapsedTime >= g.timerDuration {
 		g.raceStarted = true
 		g.raceTimer = 0 // Initialize race timer when race starts
+		g.startVerdict = startVerdict(g.startMetrics())
 	}
 
 	// Update race timer if race has started but not finished
@@ -328,8 +647,8 @@ func (g *GameState) Update() error {
 	}
 
 	// OCS detection and clearing - check if boat's bow is above (course side of) the starting line
-	// Starting line is at Y = 2400, boat is OCS if bow crosses between pin and committee boat before race start
-	startLineY := 2400.0
+	// Boat is OCS if bow crosses the starting line between pin and committee boat before race start
+	startLineY := g.Arena.Line.Pin.Pos.Y
 	bowPos := g.Boat.GetBowPosition()
 
 	if !g.raceStarted {
@@ -355,70 +674,77 @@ func (g *GameState) Update() error {
 			if g.prevBowPos.Y > startLineY && bowPos.Y <= startLineY && g.isWithinLineBounds(bowPos) {
 				g.hasCrossedLine = true
 				g.lineCrossingTime = g.raceTimer // Capture race timer at line crossing
+				g.replay.AddEvent(ReplayEventStart, g.elapsedTime)
 				// Calculate how late the boat was (time after race start)
 				g.secondsLate = (g.elapsedTime - g.timerDuration).Seconds()
 				// Calculate VMG at crossing
 				g.vmgAtCrossing = g.Dashboard.CalculateVMG()
 				// Calculate speed at crossing as percentage of target beat speed
-				_, windSpeed := g.Wind.GetWind(g.Boat.Pos)
-				// Target beat speed is typically at 45-50 degree TWA - use 45 degrees
-				targetBeatSpeed := g.Boat.Polars.GetBoatSpeed(45.0, windSpeed)
-				if targetBeatSpeed > 0 {
-					g.speedPercentage = (g.Boat.Speed / targetBeatSpeed) * 100
-				} else {
-					g.speedPercentage = 0
-				}
+				g.speedPercentage = g.targetSpeedPercentage()
 				// Initialize distance tracking
-				g.prevBoatPos = g.Boat.Pos
 				g.distanceSailed = 0
-				// Initialize speed averaging
-				g.speedSum = 0
-				g.speedSamples = 0
 			}
 		}
 
-		// Track distance and speed after crossing start line
+		// Record wind shifts at tacks while racing
 		if g.hasCrossedLine && !g.raceFinished {
-			dx := g.Boat.Pos.X - g.prevBoatPos.X
-			dy := g.Boat.Pos.Y - g.prevBoatPos.Y
-			distanceThisFrame := math.Sqrt(dx*dx + dy*dy)
-			g.distanceSailed += distanceThisFrame
-			g.prevBoatPos = g.Boat.Pos
-			// Track speed for averaging
-			g.speedSum += g.Boat.Speed
-			g.speedSamples++
+			windDir, _ := g.Wind.GetWind(g.Boat.Pos)
+			twa := g.Boat.Heading - windDir
+			if twa < -180 {
+				twa += 360
+			} else if twa > 180 {
+				twa -= 360
+			}
+			g.detectTack(twa)
 		}
 
 		// Mark rounding detection (only if race has started and boat has crossed starting line)
 		if g.hasCrossedLine && !g.raceFinished {
+			wasRounded := g.markRounded
 			g.updateMarkRounding()
+			if !wasRounded && g.markRounded {
+				g.replay.AddEvent(ReplayEventMarkRounding, g.elapsedTime)
+			}
+		}
+
+		g.updateTackNow()
+
+		// Leeward gate detection on the run (only on courses with a gate)
+		if g.hasCrossedLine && g.markRounded && !g.gatePassed && !g.raceFinished {
+			g.updateGatePassing(bowPos)
 		}
 
-		// Finish line detection (only if boat has started and rounded the mark)
-		if g.hasCrossedLine && g.markRounded && !g.raceFinished {
+		// Finish line detection (only if boat has started, rounded the mark and passed any gate)
+		if g.hasCrossedLine && g.markRounded && g.gateCleared() && !g.raceFinished {
+			lapsBefore := g.lapsCompleted
 			g.checkFinishLineCrossing()
+			if g.lapsCompleted > lapsBefore {
+				g.replay.AddEvent(ReplayEventLap, g.elapsedTime)
+			}
+			if g.raceFinished {
+				g.replay.AddEvent(ReplayEventFinish, g.elapsedTime)
+			}
 		}
 	}
 
 	// Update previous bow position for next frame's crossing detection
 	g.prevBowPos = bowPos
+}
 
-	// Input handling with delay to prevent overturning
-	// Skip boat movement input when scoreboard is capturing text input
-	if time.Since(g.lastInput) >= inputDelay && !g.scoreboard.IsCapturingInput() {
-		// Check keyboard input
-		keyboardLeft := ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA)
-		keyboardRight := ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD)
-
-		// Combine keyboard and mobile input
-		if keyboardLeft || mobileInput.TurnLeft {
-			g.Boat.Heading -= 1
-			g.lastInput = time.Now()
-		}
-		if keyboardRight || mobileInput.TurnRight {
-			g.Boat.Heading += 1
-			g.lastInput = time.Now()
-		}
+// stepPhysics runs one fixed physics step with the helm at turn (-1 port to +1 starboard):
+// steering, boat movement, fouls, the fleet and the replay recording
+func (g *GameState) stepPhysics(turn float64) {
+	prevHeading := g.Boat.Heading
+
+	// Manual steering takes over from an auto-tack
+	if turn != 0 {
+		g.autoTack.active = false
+	}
+	g.steerAutoTack()
+
+	// The helm moves the boat's rate of turn, which builds up and eases off with it
+	if !g.autoTack.active {
+		g.Boat.Steer(turn)
 	}
 
 	// Normalize heading
@@ -430,93 +756,345 @@ func (g *GameState) Update() error {
 	}
 
 	g.Boat.Update()
+	g.trackDistanceSailed()
+	g.trackPenaltyTurns(prevHeading)
 
-	// Check for collisions (during pre-start and active race, but not when finished)
-	if !g.raceFinished {
-		collisions := g.Arena.CheckCollisions(g.Boat.Pos, objects.BoatRadius)
-
-		// Process collisions with debouncing (avoid counting same collision multiple times)
-		for _, collision := range collisions {
-			// Only count if enough time has passed since last collision (0.5 second debounce)
-			if time.Since(g.lastCollisionTime) > 500*time.Millisecond {
-				g.penaltyCount++
-				g.collisionHistory = append(g.collisionHistory, collision)
-				g.lastCollisionTime = time.Now()
-				g.showCollisionFlash = true
-				g.collisionFlashTime = time.Now()
-			}
-		}
-	}
+	// Touching a mark is a foul; the committee boat is solid too
+	g.checkCollisions()
+	g.bounceOffSolidMarks()
+	g.updateOpponents()
 
-	// Hide collision flash after 250ms
-	if g.showCollisionFlash && time.Since(g.collisionFlashTime) > 250*time.Millisecond {
-		g.showCollisionFlash = false
+	// Record the race for replay (until the finish)
+	if !g.raceFinished {
+		g.replay.Record(ReplayFrame{
+			Time:    g.elapsedTime,
+			Pos:     g.Boat.Pos,
+			Heading: g.Boat.Heading,
+			Speed:   g.Boat.Speed,
+		})
 	}
-
-	// Update telltales based on current boat performance
-	g.telltales.Update(g.Boat, g.Wind, g.Dashboard)
-
-	// Calculate distance to line crossing point (during pre-start)
-	g.distanceToLineCrossing = g.calculateDistanceToLineCrossing()
-	g.timeToCross = g.calculateTimeToCross()
-
-	// Update camera to follow boat when it moves out of bounds
-	g.updateCamera()
-
-	return nil
 }
 
-// updateCamera pans the camera to keep the boat visible
-func (g *GameState) updateCamera() {
+// followCameraTarget returns where the camera pans to keep the boat visible
+func (g *GameState) followCameraTarget() (float64, float64) {
+	targetX, targetY := g.CameraX, g.CameraY
 	boatScreenX := g.Boat.Pos.X - g.CameraX
 	boatScreenY := g.Boat.Pos.Y - g.CameraY
 
 	// Camera margins - start panning when boat gets within this distance from edge
-	margin := 200.0
+	// (a quarter of the screen on small screens, so the margins never overlap)
+	screenWidth, screenHeight := g.screenSize()
+	width, height := g.viewSize()
+	margin := math.Min(200.0, float64(min(screenWidth, screenHeight))/4) / g.zoom()
 
 	// Pan horizontally if boat is near screen edges
 	if boatScreenX < margin {
-		g.CameraX = g.Boat.Pos.X - margin
-	} else if boatScreenX > float64(ScreenWidth)-margin {
-		g.CameraX = g.Boat.Pos.X - (float64(ScreenWidth) - margin)
+		targetX = g.Boat.Pos.X - margin
+	} else if boatScreenX > width-margin {
+		targetX = g.Boat.Pos.X - (width - margin)
 	}
 
-	// Pan vertically if boat is near screen edges (200px from top/bottom)
+	// Pan vertically if boat is near screen edges
 	if boatScreenY < margin {
-		g.CameraY = g.Boat.Pos.Y - margin
-	} else if boatScreenY > float64(ScreenHeight)-margin {
-		g.CameraY = g.Boat.Pos.Y - (float64(ScreenHeight) - margin)
+		targetY = g.Boat.Pos.Y - margin
+	} else if boatScreenY > height-margin {
+		targetY = g.Boat.Pos.Y - (height - margin)
+	}
+
+	return targetX, targetY
+}
+
+// clampCamera keeps the camera within the world bounds plus the over-scroll margin
+func (g *GameState) clampCamera() {
+	width, height := g.viewSize()
+	g.CameraX = clampCameraAxis(g.CameraX, width, WorldWidth)
+	g.CameraY = clampCameraAxis(g.CameraY, height, WorldHeight)
+}
+
+// clampCameraAxis limits the camera along one axis; zoomed out so far that the screen
+// spans more than the world and its margins, the world is centered instead
+func clampCameraAxis(pos, view, size float64) float64 {
+	if view > size+2*cameraOverscroll {
+		return (size - view) / 2
+	}
+	return math.Max(-cameraOverscroll, math.Min(pos, size-view+cameraOverscroll))
+}
+
+// updateReplayCamera centers the camera on the replayed boat
+func (g *GameState) updateReplayCamera() {
+	frame := g.replay.CurrentFrame()
+	width, height := g.viewSize()
+	g.CameraX = frame.Pos.X - width/2
+	g.CameraY = frame.Pos.Y - height/2
+
+	g.clampCamera()
+}
+
+// Hull color of the personal best run, as the ghost while racing and overlaid in the replay
+var personalBestColor = color.RGBA{255, 215, 0, 160}
+
+// drawReplayUI renders the replayed boat's readout and the scrubber timeline
+func (g *GameState) drawReplayUI(screen *ebiten.Image) {
+	frame := g.replay.CurrentFrame()
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Speed: %.1f kts  Heading: %03.0f", frame.Speed, frame.Heading), 10, 10)
+
+	// Live gap to the personal best run, toward the mark this run was sailing to
+	target := g.Dashboard.UpwindMark
+	if rounded, ok := g.replay.EventTime(ReplayEventMarkRounding); ok && g.replay.Cursor >= rounded {
+		target = g.Arena.Line.Midpoint()
+	}
+	if gap, ok := g.replay.CompareGap(g.replay.Cursor, target); ok {
+		speedAlong := frame.Speed * math.Cos((frame.Heading-geometry.Bearing(frame.Pos, target))*math.Pi/180)
+		ebitenutil.DebugPrintAt(screen, "vs Personal Best (gold): "+gapReadout(gap, speedAlong), 10, 25)
+	}
+
+	g.replay.Draw(screen)
+}
+
+// restart sets up a fresh race on the same course, keeping the player's preferences, the
+// storage and leaderboard, and the conditions set up from the command line
+func (g *GameState) restart() {
+	newGame := newGameWithCourse(g.course, g.boatClass)
+	if g.store != nil {
+		newGame.SetStore(g.store)
+	}
+	if g.leaderboard != nil {
+		newGame.SetLeaderboard(g.leaderboard)
+	}
+	newGame.SetNameFilter(g.scoreboard.nameFilter)
+	newGame.supersample = g.supersample
+	newGame.settings = g.settings
+	newGame.gamepad = g.gamepad
+	newGame.SetCurrent(g.Current)
+	newGame.SetGusts(g.gusts)
+	newGame.SetWindShadow(g.shadow)
+	if g.windReplay != nil {
+		newGame.SetWindReplay(g.windReplay)
+	}
+	newGame.setupFleet()
+	*g = *newGame
+}
+
+// SetSupersampling sets the internal render resolution multiplier (1 = native, 2 = 2x).
+// The frame is rendered at the higher resolution and downsampled to the window, which
+// keeps thin lines crisp on high-DPI displays.
+func (g *GameState) SetSupersampling(factor int) {
+	if factor < 1 {
+		factor = 1
+	} else if factor > 2 {
+		factor = 2
+	}
+	g.supersample = factor
+}
+
+// SetLeaderboard sends finished races to the given leaderboard instead of the default
+// (Firebase in the browser, a local file on desktop)
+func (g *GameState) SetLeaderboard(backend LeaderboardBackend) {
+	g.leaderboard = backend
+	g.scoreboard.backend = backend
+}
+
+// SetStore keeps results, personal bests and the touch layout in store between sessions
+// (NewLocalStore for the player's own device), and picks up what it already holds
+func (g *GameState) SetStore(store KeyValueStore) {
+	g.store = store
+	g.scoreboard.store = store
+	if g.leaderboard == nil {
+		g.scoreboard.backend = newLeaderboardBackend(store, g.courseSignature())
+	}
+	g.ghost, _ = LoadPersonalBestTrack(store, g.courseSignature())
+	g.settings.Controls = LoadControlLayout(store)
+	g.mobileControls.SetLayout(g.settings.Controls)
+}
+
+// SetAutosave sets whether a finished result is saved straight away, so it can be offered
+// again on the next launch if the game closes before the scoreboard is done (on by default)
+func (g *GameState) SetAutosave(enabled bool) {
+	g.settings.NoAutosave = !enabled
+}
+
+// SetNameFilter replaces the built-in blocklist for names submitted to the leaderboard
+func (g *GameState) SetNameFilter(filter *NameFilter) {
+	g.scoreboard.nameFilter = filter
+}
+
+// SetCurrent sets the tidal current that carries the boats and is drawn on the course.
+// nil means slack water.
+func (g *GameState) SetCurrent(current world.Current) {
+	g.Current = current
+	g.Boat.Current = current
+	g.Arena.Current = current
+	for _, o := range g.opponents {
+		o.Boat.Current = current
+	}
+}
+
+// SetGusts sets how often gusts and lulls form on the course and how strong they are.
+// A zero frequency turns them off.
+func (g *GameState) SetGusts(config world.GustConfig) {
+	g.gusts = config
+	if oscillatingWind, ok := g.undisturbedWind().(*world.OscillatingWind); ok {
+		oscillatingWind.SetGusts(config, WorldHeight)
+	}
+}
+
+// SetWindShadow sets how far downwind the committee boat's wind shadow reaches and how
+// much wind it takes; the marks' shadows scale with it. A zero length turns them off.
+func (g *GameState) SetWindShadow(config world.ShadowConfig) {
+	g.shadow = config
+	if shadowedWind, ok := g.Wind.(*world.ShadowedWind); ok {
+		shadowedWind.Config = config
+	}
+}
+
+// undisturbedWind returns the wind on the course without the shadows of the marks
+// and the committee boat
+func (g *GameState) undisturbedWind() world.Wind {
+	if shadowedWind, ok := g.Wind.(*world.ShadowedWind); ok {
+		return shadowedWind.Undisturbed()
+	}
+	return g.Wind
+}
+
+// SetWindReplay replaces the live wind with a recorded wind log, so the race is sailed
+// in exactly the conditions it was recorded in
+func (g *GameState) SetWindReplay(samples []world.WindSample) {
+	g.windReplay = samples
+	replay := world.NewReplayWind(samples)
+	replay.SetElapsed(g.elapsedTime.Seconds())
+	wind := world.NewShadowedWind(replay, g.Arena.Marks, g.Arena.Line.Committee, g.shadow)
+
+	g.Wind = wind
+	g.Boat.Wind = wind
+	g.Dashboard.Wind = wind
+	for _, o := range g.opponents {
+		o.Boat.Wind = wind
+	}
+}
+
+// WindLog returns the wind recorded at the boat once a second since this race's sequence began
+func (g *GameState) WindLog() []world.WindSample {
+	return g.windLog.Samples
+}
+
+// renderScale returns the internal render pixels per logical screen pixel
+func (g *GameState) renderScale() int {
+	if g.supersample < 1 {
+		return 1
 	}
+	return g.supersample
+}
+
+// screenSize returns the logical screen size, the default 1280x720 until the first layout
+func (g *GameState) screenSize() (int, int) {
+	if g.screenWidth <= 0 || g.screenHeight <= 0 {
+		return ScreenWidth, ScreenHeight
+	}
+	return g.screenWidth, g.screenHeight
+}
+
+// renderSize returns the internal render resolution
+func (g *GameState) renderSize() (int, int) {
+	scale := g.renderScale()
+	width, height := g.screenSize()
+	return width * scale, height * scale
+}
 
-	// Clamp camera to world bounds
-	g.CameraX = math.Max(0, math.Min(g.CameraX, float64(WorldWidth-ScreenWidth)))
-	g.CameraY = math.Max(0, math.Min(g.CameraY, float64(WorldHeight-ScreenHeight)))
+// worldView maps world coordinates to internal render pixels for the current camera and zoom
+func (g *GameState) worldView() world.View {
+	return world.View{OffsetX: g.CameraX, OffsetY: g.CameraY, Scale: float64(g.renderScale()) * g.zoom()}
+}
+
+// ensureRenderTargets (re)allocates the world and UI images to match the render resolution
+func (g *GameState) ensureRenderTargets() {
+	width, height := g.renderSize()
+	if g.worldImage == nil || g.worldImage.Bounds().Dx() != width || g.worldImage.Bounds().Dy() != height {
+		g.worldImage = ebiten.NewImage(width, height)
+	}
+	screenWidth, screenHeight := g.screenSize()
+	if g.renderScale() > 1 && (g.hudImage == nil || g.hudImage.Bounds().Dx() != screenWidth || g.hudImage.Bounds().Dy() != screenHeight) {
+		g.hudImage = ebiten.NewImage(screenWidth, screenHeight)
+	}
 }
 
 func (g *GameState) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0, 105, 148, 255}) // Blue for water
+	g.ensureRenderTargets()
+
+	// Clear and redraw the visible world (reuse existing image instead of creating new one).
+	// The water is already on the screen; the barbs and the static course come from cached
+	// layers in the arena, so only the moving parts are drawn from scratch each frame.
+	g.worldImage.Clear()
+	view := g.worldView()
+	g.Arena.ShowWindLabels = g.settings.ShowWindLabels
+	g.Arena.ShowLineSag = g.settings.ShowLineSag
+	g.Dashboard.ShowApparentWind = g.settings.ShowApparentWind
+	g.Dashboard.ShowVMC = g.settings.ShowVMC
+	g.Dashboard.ShowTargetSpeed = g.settings.ShowTargetSpeed
+	g.Dashboard.PenaltyTurned, g.Dashboard.PenaltyRequired = g.penaltyProgress()
+	g.Dashboard.GatePassed = g.gatePassed
+	g.Dashboard.Phase = g.racePhase().String()
+	g.Dashboard.Leg, g.Dashboard.Legs = g.raceLeg()
+	g.Dashboard.Status = g.raceStatus()
+	g.Dashboard.ShowWindShift = g.windShiftVisible()
+	g.Dashboard.Lap, g.Dashboard.Laps = min(g.lapsCompleted+1, g.totalLaps()), g.totalLaps()
+	g.Boat.DrawWake = g.settings.ShowWake
+	g.Boat.OCS = g.isOCS
+
+	if g.replay.Active {
+		// Draw arena and the recorded boat at the playback cursor
+		frame := g.replay.CurrentFrame()
+		g.Arena.Draw(g.worldImage, true, g.Wind, view)
+		if best, ok := g.replay.CompareFrameAt(g.replay.Cursor); ok {
+			bestBoat := &objects.Boat{Pos: best.Pos, Heading: best.Heading, Speed: best.Speed, Color: personalBestColor}
+			bestBoat.Draw(g.worldImage, view)
+		}
+		replayBoat := &objects.Boat{Pos: frame.Pos, Heading: frame.Heading, Speed: frame.Speed}
+		replayBoat.Draw(g.worldImage, view)
+	} else {
+		// Draw arena (which includes marks) to world
+		g.Arena.Draw(g.worldImage, g.raceStarted, g.Wind, view)
 
-	// Apply camera transform
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(-g.CameraX, -g.CameraY)
+		// Draw the AI fleet and the personal best ghost under the player's boat
+		g.drawOpponents(g.worldImage, view)
+		g.drawGhost(g.worldImage, view)
 
-	// Clear and redraw world image (reuse existing image instead of creating new one)
-	g.worldImage.Fill(color.RGBA{0, 105, 148, 255}) // Blue for water
+		// Draw boat (which includes its history trail) to world
+		g.Boat.Draw(g.worldImage, view)
+	}
 
-	// Draw arena (which includes marks) to world
-	g.Arena.Draw(g.worldImage, g.raceStarted, g.Wind)
+	// Draw the world to screen (camera offset is applied by the view)
+	screen.DrawImage(g.worldImage, nil)
 
-	// Draw boat (which includes its history trail) to world
-	g.Boat.Draw(g.worldImage)
+	// UI is laid out in logical screen coordinates; when supersampling it is drawn
+	// to a separate layer and scaled up to the render resolution
+	ui := screen
+	if g.renderScale() > 1 {
+		g.hudImage.Clear()
+		ui = g.hudImage
+	}
 
-	// Draw the world to screen with camera offset
-	screen.DrawImage(g.worldImage, op)
+	if g.replay.Active {
+		g.drawReplayUI(ui)
+	} else {
+		g.drawUI(ui)
+	}
 
+	if ui != screen {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(float64(g.renderScale()), float64(g.renderScale()))
+		screen.DrawImage(ui, op)
+	}
+}
+
+// drawUI draws the dashboard, timers, banners and overlays on top of the world
+func (g *GameState) drawUI(screen *ebiten.Image) {
 	// Draw dashboard directly to screen (UI always visible)
 	g.Dashboard.Draw(screen, g.raceStarted, g.isOCS, g.timerDuration, g.elapsedTime, g.hasCrossedLine, g.secondsLate, g.speedPercentage, g.markRounded, g.raceFinished, g.distanceToLineCrossing, g.timeToCross, g.penaltyCount, g.distanceSailed)
 
 	// Draw race timer at top center (when race hasn't started)
 	g.drawRaceTimer(screen)
+	g.drawStartSignals(screen)
 
 	// Draw OCS warning below timer
 	g.drawOCSWarning(screen)
@@ -551,21 +1129,81 @@ func (g *GameState) Draw(screen *ebiten.Image) {
 	if g.showCollisionFlash {
 		g.drawCollisionFlash(screen)
 	}
+	if g.touchedMark != "" {
+		g.drawMarkTouchedBanner(screen)
+	}
+
+	// Layline guidance on the beat: laying the mark, or below the laylines and should keep going
+	if hint := g.laylineHint(); hint != "" {
+		g.drawLaylineHint(screen, hint)
+	}
 
-	// Draw help screen when paused
+	// Show the gap to the closest opponent while racing, and how the fleet finished
+	g.drawGapReadout(screen)
+	g.drawFleetResults(screen)
+
+	// Show broach warning while the boat is out of control
+	if g.Boat.IsBroaching() {
+		g.drawBoatWarning(screen, "  Broach!", broachWarningColor)
+	} else if g.Boat.IsInIrons() && !g.raceFinished {
+		g.drawBoatWarning(screen, " IN IRONS", inIronsWarningColor)
+	} else if warning := g.boundaryWarning(); warning != "" {
+		g.drawBoatWarning(screen, warning, boundaryWarningColor)
+	}
+
+	// Draw help screen (or just a pause indicator) when paused
 	if g.isPaused {
-		g.drawHelpScreen(screen)
+		g.drawPauseOverlay(screen)
 	}
 
 	// Draw scoreboard (always on top)
 	g.scoreboard.Draw(screen)
 }
 
+// Help screen line for the gamepad controls
+const gamepadHelpLine = "  Gamepad         - Stick/D-pad Steer, A/Start Pause, Y/Back Restart, X Spinnaker\n"
+
+// Shown instead of the full help when the player has turned off help on pause
+const pauseIndicatorText = "PAUSED - SPACE to resume, H for help"
+
+// pauseOverlayText returns the text shown while paused: the full help, or a one-line
+// indicator for players who have turned it off
+func (g *GameState) pauseOverlayText() string {
+	if g.settings.ShowHelpOnPause {
+		return g.helpText()
+	}
+	return pauseIndicatorText
+}
+
+// drawPauseOverlay displays the help screen or the minimal pause indicator
+func (g *GameState) drawPauseOverlay(screen *ebiten.Image) {
+	text := g.pauseOverlayText()
+	if text != pauseIndicatorText {
+		g.drawHelpScreen(screen, text)
+		return
+	}
+
+	x := screen.Bounds().Dx()/2 - len(text)*3 - 5
+	y := screen.Bounds().Dy() / 2
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(len(text)*6+10), 16, color.RGBA{0, 0, 0, 180}, false)
+	ebitenutil.DebugPrintAt(screen, text, x+5, y)
+}
+
 // drawHelpScreen displays the help overlay when game is paused
-func (g *GameState) drawHelpScreen(screen *ebiten.Image) {
+func (g *GameState) drawHelpScreen(screen *ebiten.Image, helpText string) {
 	// Draw semi-transparent overlay using vector instead of creating new image
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 180}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 180}, false)
+
+	// Center the help text
+	bounds := screen.Bounds()
+	x := bounds.Dx()/2 - 200
+	y := bounds.Dy()/2 - 150
 
+	ebitenutil.DebugPrintAt(screen, helpText, x, y)
+}
+
+// helpText returns the pause help: how to play, plus the keyboard controls on desktop
+func (g *GameState) helpText() string {
 	var helpText string
 
 	// Check if we're on mobile (touch input detected)
@@ -581,16 +1219,22 @@ How to Play:
 * Return and cross finish line to complete race
 * Use wind angles for optimal speed
 
-Use Touch Controls to turn left/right, pause or restart.
+Use Touch Controls to turn left/right, pause or restart,
+or drag a finger sideways on the lower half to steer.
+Tap Buttons (top right) to resize or move the controls.
 
 Tap anywhere to continue...`
 	} else {
 		// Desktop help text - include keyboard shortcuts
 		quitText := "Quit Game"
-		leaderboardLine := ""
 		if IsWASM() {
 			quitText = "Pause Game"
-			leaderboardLine = "  L               - View Leaderboard\n"
+		}
+
+		// Gamepad buttons are only listed while one is plugged in
+		gamepadText := ""
+		if g.gamepad.Connected() {
+			gamepadText = gamepadHelpLine
 		}
 
 		helpText = fmt.Sprintf(`SAILING GAME - PAUSED
@@ -606,21 +1250,35 @@ How to Play:
 Controls:
   Left Arrow / A  - Turn Left
   Right Arrow / D - Turn Right
+  T               - Auto-Tack (steer to cancel)
+  Z               - Hoist / Drop Spinnaker (TWA 90° or deeper)
   Space           - Pause/Resume
-  J               - Jump Timer +10 sec (pre start)
+  H               - Show / Hide This Help on Pause
+%s  J               - Jump Timer +10 sec (pre start)
+  E               - Toggle Start Rehearsal (pre start)
+  [ / ]           - Shorten / Lengthen Start Line (pre start)
   R               - Restart Game
+  V               - Watch Replay (after finish)
+  N               - Toggle Wind Speed Labels
+  P               - Toggle Apparent Wind on Compass
+  I               - Toggle VMC (VMG to the Mark)
+  K               - Toggle Target Speed Readout
+  Y               - Toggle TACK NOW Layline Cue
+  M               - Mute / Unmute Start Beeps
+  B               - Toggle Bullet Time (easy mode)
+  G               - Toggle Line Sag Overlay (pre start)
+  F               - Cycle Camera (Follow / Committee)
+  + / -           - Zoom In / Out
+  O               - Toggle Mouse Steering
+  U               - Cycle Touch Button Layout
   C               - Toggle Touch Controls (testing)
-%s  Q               - %s
+  L               - View Leaderboard
+  Q               - %s
 
-Press SPACE to continue...`, leaderboardLine, quitText)
+Press SPACE to continue...`, gamepadText, quitText)
 	}
 
-	// Center the help text
-	bounds := screen.Bounds()
-	x := bounds.Dx()/2 - 200
-	y := bounds.Dy()/2 - 150
-
-	ebitenutil.DebugPrintAt(screen, helpText, x, y)
+	return helpText
 }
 
 // drawStartBanner displays the START banner when race begins
@@ -628,7 +1286,7 @@ func (g *GameState) drawStartBanner(screen *ebiten.Image) {
 	bounds := screen.Bounds()
 
 	// Semi-transparent overlay using vector drawing
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 100}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 100}, false)
 
 	// START banner text
 	startText := "*** RACE START! ***"
@@ -638,6 +1296,11 @@ func (g *GameState) drawStartBanner(screen *ebiten.Image) {
 	y := bounds.Dy()/2 - 20
 
 	ebitenutil.DebugPrintAt(screen, startText, x, y)
+
+	// Verdict on the start just below
+	if g.startVerdict != "" {
+		ebitenutil.DebugPrintAt(screen, g.startVerdict, bounds.Dx()/2-len(g.startVerdict)*3, y+20)
+	}
 }
 
 // drawRestartBanner displays the RESTART banner when game is restarted
@@ -645,7 +1308,7 @@ func (g *GameState) drawRestartBanner(screen *ebiten.Image) {
 	bounds := screen.Bounds()
 
 	// Semi-transparent overlay using vector drawing
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 100}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 100}, false)
 
 	// RESTART banner text
 	restartText := "*** RESTARTED ***"
@@ -662,6 +1325,16 @@ func (g *GameState) drawRaceTimer(screen *ebiten.Image) {
 	bounds := screen.Bounds()
 	y := 20 // Top of screen with some margin
 
+	// Rehearsal indicator left of the timer
+	if g.rehearsalMode {
+		ebitenutil.DebugPrintAt(screen, "REHEARSAL", bounds.Dx()/2-130, y)
+	}
+
+	// Bullet time indicator right of the timer
+	if g.slowMotion {
+		ebitenutil.DebugPrintAt(screen, "SLOW-MO 0.5x", bounds.Dx()/2+70, y)
+	}
+
 	if !g.raceStarted {
 		// Show countdown timer before race starts
 		remaining := g.timerDuration - g.elapsedTime
@@ -726,14 +1399,8 @@ func (g *GameState) drawOCSWarning(screen *ebiten.Image) {
 	ocsWidth := 80
 	ocsHeight := 15
 
-	// Create red rectangle
-	redRect := ebiten.NewImage(ocsWidth, ocsHeight)
-	redRect.Fill(color.RGBA{255, 0, 0, 255}) // Red background
-
-	// Draw red rectangle
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(ocsX), float64(ocsY))
-	screen.DrawImage(redRect, op)
+	// Draw red rectangle straight onto the screen, like the banners, rather than a new image every frame
+	vector.DrawFilledRect(screen, float32(ocsX), float32(ocsY), float32(ocsWidth), float32(ocsHeight), color.RGBA{255, 0, 0, 255}, false)
 
 	// Draw white text on red background
 	ebitenutil.DebugPrintAt(screen, "*** OCS ***", ocsX, ocsY)
@@ -805,14 +1472,8 @@ func (g *GameState) drawTimingBar(screen *ebiten.Image) {
 // isWithinLineBounds checks if the boat's bow position is within the start/finish line bounds
 // (between pin and committee boat)
 func (g *GameState) isWithinLineBounds(bowPos geometry.Point) bool {
-	lineStart := g.Dashboard.LineStart
-	lineEnd := g.Dashboard.LineEnd
-
 	// Check if X coordinate is between pin and committee boat
-	minX := math.Min(lineStart.X, lineEnd.X)
-	maxX := math.Max(lineStart.X, lineEnd.X)
-
-	return bowPos.X >= minX && bowPos.X <= maxX
+	return g.Arena.Line.WithinBounds(bowPos.X)
 }
 
 // calculateDistanceToLineCrossing calculates the distance from the boat's bow to where its heading would intersect the starting line
@@ -823,10 +1484,8 @@ func (g *GameState) calculateDistanceToLineCrossing() float64 {
 		return -1
 	}
 
-	lineStart := g.Dashboard.LineStart // Pin end
-	lineEnd := g.Dashboard.LineEnd     // Committee end
-	bowPos := g.Boat.GetBowPosition()  // Use bow position instead of center
-	lineY := lineStart.Y               // Starting line Y coordinate (horizontal line)
+	bowPos := g.Boat.GetBowPosition() // Use bow position instead of center
+	lineY := g.Arena.Line.Pin.Pos.Y   // Starting line Y coordinate (horizontal line)
 
 	// Check if bow is below the line
 	if bowPos.Y <= lineY {
@@ -856,10 +1515,7 @@ func (g *GameState) calculateDistanceToLineCrossing() float64 {
 	intersectY := lineY
 
 	// Check if intersection is between pin and committee boat
-	minX := math.Min(lineStart.X, lineEnd.X)
-	maxX := math.Max(lineStart.X, lineEnd.X)
-
-	if intersectX < minX || intersectX > maxX {
+	if !g.Arena.Line.WithinBounds(intersectX) {
 		return -1 // Intersection is outside the starting line bounds
 	}
 
@@ -906,6 +1562,9 @@ func (g *GameState) updateMarkRounding() {
 	upwindMark := g.Arena.Marks[2] // Upwind mark
 
 	boatPos := g.Boat.Pos
+	// How far the boat has crossed over the top of the mark in the rounding direction:
+	// east to west when leaving it to port, west to east when leaving it to starboard
+	across := (upwindMark.Pos.X - boatPos.X) * roundingDirection(upwindMark)
 
 	// Phase 1: Sailed past mark (south to north of mark)
 	if !g.markRoundingPhase1 {
@@ -916,12 +1575,12 @@ func (g *GameState) updateMarkRounding() {
 		}
 	}
 
-	// Phase 2: Travelled to left (east to west while north of mark)
+	// Phase 2: Crossed over the top of the mark (east to west for a port rounding)
 	if g.markRoundingPhase1 && !g.markRoundingPhase2 {
 		// Only check this phase while boat is north of the mark
 		if boatPos.Y < upwindMark.Pos.Y {
-			// Check if boat has moved from east (X > markX) to west (X < markX) of mark
-			if boatPos.X <= upwindMark.Pos.X-1 {
+			// Check if boat has moved to the far side of the mark
+			if across >= 1 {
 				g.markRoundingPhase2 = true
 			}
 		} else {
@@ -937,57 +1596,132 @@ func (g *GameState) updateMarkRounding() {
 		if boatPos.Y >= upwindMark.Pos.Y+1 {
 			g.markRoundingPhase3 = true
 			g.markRounded = true // All phases complete
+			g.wrongRounding = false
 		}
 	}
 
-	// Reset phase 2 if boat drifts back to east while still north of mark
+	g.trackWrongRounding(upwindMark, across)
+
+	// Reset phase 2 if boat drifts back to the near side while still north of mark
 	if g.markRoundingPhase2 && !g.markRoundingPhase3 && boatPos.Y < upwindMark.Pos.Y {
-		if boatPos.X > upwindMark.Pos.X {
+		if across < 0 {
 			g.markRoundingPhase2 = false
 		}
 	}
 }
 
+// trackWrongRounding counts the boat's crossings over the top of the mark, and flags a
+// wrong-way rounding when it gets back below the mark having crossed more times against
+// the rounding direction than with it. Overshooting and coming back cancels out.
+func (g *GameState) trackWrongRounding(mark *world.Mark, across float64) {
+	if g.Boat.Pos.Y < mark.Pos.Y {
+		if g.markAcross < 0 && across > 0 {
+			g.markCrossings++
+		} else if g.markAcross > 0 && across < 0 {
+			g.markCrossings--
+		}
+		if across != 0 {
+			g.markAcross = across
+		}
+		return
+	}
+	g.markAcross = 0
+	if g.Boat.Pos.Y >= mark.Pos.Y+1 {
+		if g.markCrossings < 0 && !g.markRounded {
+			g.wrongRounding = true
+		}
+		g.markCrossings = 0
+	}
+}
+
+// roundingDirection returns 1 for a mark left to port, which is crossed over the top east
+// to west, and -1 for a mark left to starboard, crossed west to east
+func roundingDirection(mark *world.Mark) float64 {
+	if mark.Rounding == world.RoundToStarboard {
+		return -1
+	}
+	return 1
+}
+
+// updateGatePassing detects the bow passing between the leeward gate marks on the way
+// down from the upwind mark
+func (g *GameState) updateGatePassing(bowPos geometry.Point) {
+	if g.Arena.Gate == nil || len(g.Arena.Marks) < 3 {
+		return
+	}
+	if g.Arena.Gate.Passed(g.prevBowPos, bowPos, g.Arena.Marks[2].Pos) {
+		g.gatePassed = true
+	}
+}
+
+// gateCleared reports whether the boat is free to finish: it has passed the leeward
+// gate, or the course has none
+func (g *GameState) gateCleared() bool {
+	return g.Arena.Gate == nil || g.gatePassed
+}
+
 // checkFinishLineCrossing detects when boat crosses finish line from course side
 func (g *GameState) checkFinishLineCrossing() {
 	// Finish line is same as starting line
-	startLineY := 2400.0
+	startLineY := g.Arena.Line.Pin.Pos.Y
 	bowPos := g.Boat.GetBowPosition()
 
 	// Check if bow crosses the Y coordinate from above (prevBowPos.Y < startLineY) to below (bowPos.Y >= startLineY)
 	// AND the boat is within line bounds at the moment of crossing
 	// Boat must be coming from course side (north) and cross to finish side (south) while between pin and committee boat
 	if g.prevBowPos.Y < startLineY && bowPos.Y >= startLineY && g.isWithinLineBounds(bowPos) {
+		// Laps before the last go round the marks again
+		if !g.onFinalLap() {
+			g.startNextLap()
+			return
+		}
+
+		// No finish until the penalty turns are done
+		if g.penaltyPending {
+			return
+		}
+
 		// Boat has finished the race!
 		g.raceFinished = true
 		g.finishTime = g.raceTimer
 		g.showFinishBanner = true
 		g.finishBannerTime = time.Now()
 
-		// Calculate average speed from running average of boat speed
-		if g.speedSamples > 0 {
-			g.averageSpeed = g.speedSum / float64(g.speedSamples)
-		}
+		g.averageSpeed = averageSpeedKnots(g.distanceSailed, g.finishTime)
 
-		// Show scoreboard after a short delay (let finish banner show first)
-		go func() {
-			time.Sleep(3 * time.Second)
-			if g.raceFinished && !g.scoreboard.IsVisible() {
-				g.showScoreboard()
-			}
-		}()
+		// Autosave immediately so the result survives a crash or closed tab before name entry
+		g.autosaveResult()
+		g.recordPersonalBest()
 	}
 }
 
-// showScoreboard displays the scoreboard with current race result
-func (g *GameState) showScoreboard() {
-	// Only show scoreboard in WASM version
-	if !IsWASM() {
+// Delay before the scoreboard comes up after the finish, so the finish banner shows first
+const scoreboardDelay = 3 * time.Second
+
+// updateFinishScoreboard offers the scoreboard once, a short while after the finish.
+// Runs on the game loop rather than a timer so the game state is only touched there.
+func (g *GameState) updateFinishScoreboard() {
+	if !g.raceFinished || g.scoreboardShown || time.Since(g.finishBannerTime) < scoreboardDelay {
 		return
 	}
+	g.scoreboardShown = true
+	if !g.scoreboard.IsVisible() {
+		g.showScoreboard()
+	}
+}
 
-	// Create race result from current game state
-	result := &RaceResult{
+// averageSpeedKnots returns the average speed over a race of distance meters sailed in
+// raceTime, in the game's knots (0 for a zero race time)
+func averageSpeedKnots(distance float64, raceTime time.Duration) float64 {
+	if raceTime <= 0 {
+		return 0
+	}
+	return objects.Knots(distance / raceTime.Seconds())
+}
+
+// raceResult creates a race result from the current game state
+func (g *GameState) raceResult() *RaceResult {
+	return &RaceResult{
 		PlayerName:      "", // Will be filled by user
 		RaceTimeSeconds: g.finishTime.Seconds(),
 		SecondsLate:     g.secondsLate,
@@ -995,8 +1729,75 @@ func (g *GameState) showScoreboard() {
 		MarkRounded:     g.markRounded,
 		DistanceSailed:  g.distanceSailed,
 		AverageSpeed:    g.averageSpeed,
+		Laps:            g.totalLaps(),
+		Course:          g.courseSignature(),
 		Timestamp:       time.Now(),
 	}
+}
+
+// courseSignature identifies the race being sailed, so its results are only compared
+// with others on the same course, laps and boat class
+func (g *GameState) courseSignature() string {
+	return g.course.signature(g.boatClass)
+}
+
+// autosaveResult writes the finished race result to local storage, unless autosave is off
+func (g *GameState) autosaveResult() {
+	if g.store == nil || g.settings.NoAutosave {
+		return
+	}
+	SavePendingResult(g.store, g.raceResult())
+}
+
+// recordPersonalBest compares the finish time with the stored personal best and keeps the faster one
+func (g *GameState) recordPersonalBest() {
+	if g.store == nil {
+		return
+	}
+	g.newPersonalBest, g.previousBest = UpdatePersonalBest(g.store, g.courseSignature(), g.finishTime)
+	if g.replay == nil {
+		return
+	}
+
+	// Replay this run against the previous best; a new best becomes the one to beat
+	g.replay.GunTime = g.timerDuration
+	if best, ok := LoadPersonalBestTrack(g.store, g.courseSignature()); ok {
+		g.replay.Compare = best
+	}
+	if g.newPersonalBest {
+		SavePersonalBestTrack(g.store, g.courseSignature(), g.replay.Track())
+	}
+}
+
+// personalBestBanner returns the celebration text for a new personal best
+// ("NEW PERSONAL BEST! -4.2s"); the first ever finish has nothing to compare against
+func personalBestBanner(finish, previous time.Duration) string {
+	if previous <= 0 {
+		return "FIRST PERSONAL BEST SET!"
+	}
+	return fmt.Sprintf("NEW PERSONAL BEST! -%.1fs", (previous - finish).Seconds())
+}
+
+// RecoverPendingResult offers a result saved by a previous session that never made it
+// through the scoreboard. Returns true if a result was recovered.
+func (g *GameState) RecoverPendingResult() bool {
+	if g.store == nil {
+		return false
+	}
+	result, ok := LoadPendingResult(g.store)
+	if !ok {
+		return false
+	}
+
+	g.isPaused = true
+	g.scoreboard.ShowWithTopCheck(result)
+	return true
+}
+
+// showScoreboard displays the scoreboard with current race result
+func (g *GameState) showScoreboard() {
+	// Create race result from current game state
+	result := g.raceResult()
 
 	// Check if on touch device - skip name entry entirely
 	if g.mobileControls.hasTouchInput {
@@ -1013,30 +1814,221 @@ func (g *GameState) drawFinishBanner(screen *ebiten.Image) {
 	bounds := screen.Bounds()
 
 	// Semi-transparent overlay using vector drawing
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 100}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 100}, false)
 
 	// Calculate finish time in minutes and seconds
 	minutes := int(g.finishTime.Minutes())
 	seconds := int(g.finishTime.Seconds()) % 60
 	centiseconds := int((g.finishTime.Milliseconds() % 1000) / 10)
 
-	// FINISH banner text with race time, distance, and average speed
-	finishText := fmt.Sprintf("*** RACE FINISHED! ***\nTime: %02d:%02d.%02d\nDistance: %.0fm\nAvg Speed: %.1f kts",
-		minutes, seconds, centiseconds, g.distanceSailed, g.averageSpeed)
+	// FINISH banner text with race time, distance, average speed and tack analysis
+	finishText := fmt.Sprintf("*** RACE FINISHED! ***\nTime: %02d:%02d.%02d\nDistance: %.0fm\nAvg Speed: %.1f kts\n%s",
+		minutes, seconds, centiseconds, g.distanceSailed, g.averageSpeed, tackSummary(g.tacks))
 
 	// Center the text
 	x := bounds.Dx()/2 - 100 // Approximate centering (wider than other banners)
 	y := bounds.Dy()/2 - 50  // Adjusted for more lines
 
+	// Where the player placed against the AI
+	if len(g.opponents) > 0 {
+		finishText += fmt.Sprintf("\nPlace: %d of %d", g.fleetPlace(), len(g.opponents)+1)
+	}
+
 	ebitenutil.DebugPrintAt(screen, finishText, x, y)
+
+	// Gold celebration strip above the results when the personal best was beaten
+	if g.newPersonalBest {
+		pbText := personalBestBanner(g.finishTime, g.previousBest)
+		vector.DrawFilledRect(screen, float32(x-10), float32(y-30), 220, 20, color.RGBA{255, 200, 0, 220}, false)
+		ebitenutil.DebugPrintAt(screen, pbText, x, y-28)
+	}
 }
 
 // drawCollisionFlash displays a red flash overlay when collision occurs
 func (g *GameState) drawCollisionFlash(screen *ebiten.Image) {
 	// Red flash overlay (semi-transparent)
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{255, 0, 0, 50}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{255, 0, 0, 50}, false)
+}
+
+// trackDistanceSailed adds the distance the boat moved through the water this step
+// (1 pixel = 1 meter), counting from crossing the start line to the finish
+func (g *GameState) trackDistanceSailed() {
+	if !g.hasCrossedLine || g.raceFinished || g.isPaused {
+		return
+	}
+	g.distanceSailed += math.Hypot(g.Boat.VelX, g.Boat.VelY)
+}
+
+// checkCollisions records a foul when the boat touches a mark or the committee boat.
+// Only runs between the start and the finish, so maneuvering around the committee boat
+// before the gun isn't flagged.
+func (g *GameState) checkCollisions() {
+	if !g.raceStarted || g.raceFinished {
+		return
+	}
+	collisions := g.Arena.CheckCollisions(g.Boat.Pos, objects.BoatRadius)
+
+	// Process collisions with debouncing (avoid counting same collision multiple times)
+	for _, collision := range collisions {
+		// Only count if enough game time has passed since last collision (0.5 second debounce)
+		if len(g.collisionHistory) == 0 || g.elapsedTime-g.lastCollisionTime > 500*time.Millisecond {
+			g.penaltyCount++
+			g.collisionHistory = append(g.collisionHistory, collision)
+			g.replay.AddEvent(ReplayEventFoul, g.elapsedTime)
+			g.lastCollisionTime = g.elapsedTime
+			g.showCollisionFlash = true
+			g.collisionFlashTime = time.Now()
+			g.touchedMark = collision.MarkName
+			g.touchedMarkTime = time.Now()
+			g.takePenalty()
+		}
+	}
+}
+
+// bounceOffSolidMarks bounces the boat off the committee boat instead of sailing through it
+func (g *GameState) bounceOffSolidMarks() {
+	for _, mark := range g.Arena.Marks {
+		if mark.Solid {
+			g.Boat.Bounce(mark.Pos, mark.Radius())
+		}
+	}
+}
+
+// drawMarkTouchedBanner displays the MARK TOUCHED banner after hitting a mark
+func (g *GameState) drawMarkTouchedBanner(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	text := fmt.Sprintf("*** MARK TOUCHED: %s ***", g.touchedMark)
+
+	// Below the center so it doesn't cover the boat
+	x := bounds.Dx()/2 - len(text)*3
+	y := bounds.Dy()/2 + 60
+
+	vector.DrawFilledRect(screen, float32(x-10), float32(y-5), float32(len(text)*6+20), 25, color.RGBA{200, 0, 0, 200}, false)
+	ebitenutil.DebugPrintAt(screen, text, x, y)
+}
+
+var (
+	broachWarningColor   = color.RGBA{255, 140, 0, 255}  // Orange: rounding up out of control
+	inIronsWarningColor  = color.RGBA{200, 0, 0, 255}    // Red: stalled head to wind, bear away
+	boundaryWarningColor = color.RGBA{160, 110, 40, 255} // Sandy brown: near the edge of the sailing area
+)
+
+// Meters from a wall at which the boat is warned that it is reaching the edge of the course
+const boundaryWarningDistance = 60.0
+
+// boundaryWarning returns the warning for a boat in the shallows or close to the wall at the
+// edge of the sailing area, or "" in open water
+func (g *GameState) boundaryWarning() string {
+	bd := g.Boat.Boundary
+	switch {
+	case bd == nil:
+		return ""
+	case bd.ShallowsDepth(g.Boat.Pos) > 0:
+		return " SHALLOWS"
+	case bd.DistanceToEdge(g.Boat.Pos) < boundaryWarningDistance:
+		return "COURSE EDGE"
+	}
+	return ""
+}
+
+// drawBoatWarning labels the boat with a warning while it is out of control: broaching,
+// or stalled in irons
+func (g *GameState) drawBoatWarning(screen *ebiten.Image, text string, background color.Color) {
+	// Position just below the boat on screen
+	x := int((g.Boat.Pos.X-g.CameraX)*g.zoom()) - 40
+	y := int((g.Boat.Pos.Y-g.CameraY)*g.zoom()) + 25
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), 80, 15, background, false)
+	ebitenutil.DebugPrintAt(screen, text, x, y)
+}
+
+// Layline guidance shown below the race timer
+const (
+	layingMarkHint = "LAYING MARK"
+	keepGoingHint  = "CAN'T LAY - KEEP GOING"
+	tackNowHint    = "TACK NOW"
+)
+
+// Degrees back below the layline before TACK NOW clears, so shifts and steering around the
+// layline don't make it flicker
+const tackNowClearMargin = 3.0
+
+// updateTackNow raises TACK NOW when a boat beating to the upwind mark reaches the layline
+// for the other tack, and holds it until the boat tacks or drops clearly back below it
+func (g *GameState) updateTackNow() {
+	margin, beating := g.Arena.LaylineMargin(g.Boat.Pos, g.Boat.Heading, g.Wind)
+	switch {
+	case !beating || !g.hasCrossedLine || g.markRounded || g.raceFinished:
+		g.tackNow = false
+	case margin >= 0:
+		g.tackNow = true
+	case margin < -tackNowClearMargin:
+		g.tackNow = false
+	}
+}
+
+// laylineHint returns the layline guidance for the player on the beat, or "" when there is
+// none (before the start, after rounding, or between the laylines with a tack to make and
+// the TACK NOW cue turned off)
+func (g *GameState) laylineHint() string {
+	if !g.hasCrossedLine || g.markRounded {
+		return ""
+	}
+	switch {
+	case g.tackNow && g.settings.ShowTackNow:
+		return tackNowHint
+	case g.Arena.CanFetchMark(g.Boat.Pos, g.Boat.Heading, g.Wind):
+		return layingMarkHint
+	case g.Arena.BelowLaylines(g.Boat.Pos, g.Boat.Heading, g.Wind):
+		return keepGoingHint
+	}
+	return ""
+}
+
+// drawLaylineHint displays the layline guidance below the race timer: green when laying
+// the mark, amber when the boat should keep going
+func (g *GameState) drawLaylineHint(screen *ebiten.Image, hint string) {
+	width := len(hint)*6 + 12
+	x := screen.Bounds().Dx()/2 - width/2
+	y := 50
+
+	hintColor := color.RGBA{0, 160, 0, 255}
+	if hint == keepGoingHint {
+		hintColor = color.RGBA{200, 130, 0, 255}
+	}
+	if hint == tackNowHint {
+		// Flashing red: on for 0.4s of every 0.6s
+		if g.elapsedTime%(600*time.Millisecond) >= 400*time.Millisecond {
+			return
+		}
+		hintColor = color.RGBA{200, 0, 0, 255}
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), 15, hintColor, false)
+	ebitenutil.DebugPrintAt(screen, hint, x+6, y)
 }
 
 func (g *GameState) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return ScreenWidth, ScreenHeight
+	if width, height := g.screenSize(); outsideWidth > 0 && outsideHeight > 0 &&
+		(outsideWidth != width || outsideHeight != height) {
+		g.resize(outsideWidth, outsideHeight)
+	}
+	return g.renderSize()
+}
+
+// resize makes the logical screen match the window or canvas, moving the touch buttons,
+// telltales and replay scrubber to the new edges
+func (g *GameState) resize(width, height int) {
+	g.screenWidth, g.screenHeight = width, height
+	g.mobileControls.Layout(width, height)
+	g.telltales.Layout(width)
+	g.replay.Scrubber = newScrubber(width, height)
+}
+
+// cycleControlLayout moves the touch buttons to the next layout and saves the choice
+func (g *GameState) cycleControlLayout() {
+	g.settings.Controls = g.settings.Controls.Next()
+	g.mobileControls.SetLayout(g.settings.Controls)
+	if g.store != nil {
+		SaveControlLayout(g.store, g.settings.Controls)
+	}
 }
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Analog stick deflection below this is treated as centered (sticks rarely rest at exactly 0)
+const stickDeadZone = 0.15
+
+// GamepadControls reads steering and actions from the first connected gamepad
+type GamepadControls struct {
+	activeID  ebiten.GamepadID
+	connected bool
+
+	// Per-frame input state
+	turn             float64
+	pausePressed     bool
+	restartPressed   bool
+	spinnakerPressed bool
+	disconnected     bool
+}
+
+// GamepadInput represents the current gamepad input state
+type GamepadInput struct {
+	Turn             float64 // -1 (full left) to 1 (full right); proportional for the analog stick
+	PausePressed     bool
+	RestartPressed   bool
+	SpinnakerPressed bool
+	Disconnected     bool // The gamepad in use was unplugged this frame
+}
+
+// NewGamepadControls creates a gamepad input reader; gamepads are picked up when connected
+func NewGamepadControls() *GamepadControls {
+	return &GamepadControls{}
+}
+
+// Update polls the active gamepad, switching to another one when it is disconnected
+func (gc *GamepadControls) Update() {
+	gc.turn = 0
+	gc.pausePressed = false
+	gc.restartPressed = false
+	gc.spinnakerPressed = false
+	gc.disconnected = false
+
+	if gc.connected && inpututil.IsGamepadJustDisconnected(gc.activeID) {
+		gc.connected = false
+		gc.disconnected = true
+	}
+
+	if !gc.connected {
+		ids := ebiten.AppendGamepadIDs(nil)
+		if len(ids) == 0 {
+			return
+		}
+		gc.activeID = ids[0]
+		gc.connected = true
+	}
+
+	id := gc.activeID
+	if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+		// Unknown layout: the first axis is almost always the left stick
+		if ebiten.GamepadAxisCount(id) > 0 {
+			gc.turn = stickToTurn(ebiten.GamepadAxisValue(id, 0))
+		}
+		return
+	}
+
+	gc.turn = stickToTurn(ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal))
+	if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftLeft) {
+		gc.turn = -1
+	}
+	if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftRight) {
+		gc.turn = 1
+	}
+
+	gc.pausePressed = inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonCenterRight) || // Start
+		inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom) // A / Cross
+	gc.restartPressed = inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonCenterLeft) || // Back/Select
+		inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightTop) // Y / Triangle
+	gc.spinnakerPressed = inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightLeft) // X / Square
+}
+
+// Connected reports whether a gamepad is in use
+func (gc *GamepadControls) Connected() bool {
+	return gc != nil && gc.connected
+}
+
+// GetGamepadInput returns the current gamepad input state
+func (gc *GamepadControls) GetGamepadInput() GamepadInput {
+	return GamepadInput{
+		Turn:             gc.turn,
+		PausePressed:     gc.pausePressed,
+		RestartPressed:   gc.restartPressed,
+		SpinnakerPressed: gc.spinnakerPressed,
+		Disconnected:     gc.disconnected,
+	}
+}
+
+// stickToTurn maps an analog stick deflection (-1..1) to a proportional turn amount (-1..1).
+// Deflection inside the dead zone is ignored and the rest of the travel is rescaled so a
+// small push just past the dead zone gives a gentle turn.
+func stickToTurn(axis float64) float64 {
+	magnitude := math.Abs(axis)
+	if magnitude < stickDeadZone || math.IsNaN(axis) {
+		return 0
+	}
+	turn := math.Min(1, (magnitude-stickDeadZone)/(1-stickDeadZone))
+	return math.Copysign(turn, axis)
+}
+
+// ControlInput is the combined steering and action input from keyboard, touch and gamepad
+type ControlInput struct {
+	Turn             float64 // -1 (full left) to 1 (full right)
+	Zoom             float64 // Pinch zoom factor this frame, 0 when not pinching
+	PanX, PanY       float64 // Two-finger pan this frame in screen pixels
+	PausePressed     bool
+	RestartPressed   bool
+	LayoutPressed    bool // The touch layout button was tapped
+	SpinnakerPressed bool // Hoist or drop the spinnaker
+	ControllerLost   bool // A gamepad was disconnected; the game pauses so the player can reconnect
+}
+
+// combineInput merges all input sources into one. Turn inputs add up and are clamped,
+// so pressing left on one device and right on another cancels out.
+func combineInput(keyboardLeft, keyboardRight bool, mobile MobileInput, pad GamepadInput) ControlInput {
+	turn := pad.Turn + mobile.Turn
+	if keyboardLeft || mobile.TurnLeft {
+		turn -= 1
+	}
+	if keyboardRight || mobile.TurnRight {
+		turn += 1
+	}
+
+	return ControlInput{
+		Turn:             math.Max(-1, math.Min(1, turn)),
+		Zoom:             mobile.Zoom,
+		PanX:             mobile.PanX,
+		PanY:             mobile.PanY,
+		PausePressed:     mobile.PausePressed || pad.PausePressed,
+		RestartPressed:   mobile.RestartPressed || pad.RestartPressed,
+		LayoutPressed:    mobile.LayoutPressed,
+		SpinnakerPressed: pad.SpinnakerPressed,
+		ControllerLost:   pad.Disconnected,
+	}
+}
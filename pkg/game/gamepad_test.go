@@ -0,0 +1,98 @@
+package game
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestStickToTurn(t *testing.T) {
+	tests := []struct {
+		name     string
+		axis     float64
+		expected float64
+	}{
+		{"Centered", 0, 0},
+		{"Inside dead zone", 0.1, 0},
+		{"Inside dead zone left", -0.14, 0},
+		{"At dead zone edge", stickDeadZone, 0},
+		{"Half right", 0.575, 0.5},
+		{"Half left", -0.575, -0.5},
+		{"Full right", 1, 1},
+		{"Full left", -1, -1},
+		{"Over-range clamped", 1.2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stickToTurn(tt.axis); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("stickToTurn(%.3f) = %.3f, expected %.3f", tt.axis, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStickToTurn_Monotonic(t *testing.T) {
+	prev := 0.0
+	for axis := stickDeadZone; axis <= 1.0; axis += 0.05 {
+		turn := stickToTurn(axis)
+		if turn < prev {
+			t.Fatalf("Expected turn to grow with stick deflection, %.2f gave %.3f after %.3f", axis, turn, prev)
+		}
+		prev = turn
+	}
+}
+
+func TestCombineInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyboardLeft  bool
+		keyboardRight bool
+		mobile        MobileInput
+		pad           GamepadInput
+		expectedTurn  float64
+	}{
+		{"No input", false, false, MobileInput{}, GamepadInput{}, 0},
+		{"Keyboard left", true, false, MobileInput{}, GamepadInput{}, -1},
+		{"Touch right", false, false, MobileInput{TurnRight: true}, GamepadInput{}, 1},
+		{"Analog stick", false, false, MobileInput{}, GamepadInput{Turn: 0.4}, 0.4},
+		{"Keyboard and stick same way clamped", false, true, MobileInput{}, GamepadInput{Turn: 0.4}, 1},
+		{"Opposite inputs cancel", true, true, MobileInput{}, GamepadInput{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := combineInput(tt.keyboardLeft, tt.keyboardRight, tt.mobile, tt.pad)
+			if math.Abs(input.Turn-tt.expectedTurn) > 1e-9 {
+				t.Errorf("Expected turn %.2f, got %.2f", tt.expectedTurn, input.Turn)
+			}
+		})
+	}
+
+	input := combineInput(false, false, MobileInput{PausePressed: true}, GamepadInput{RestartPressed: true, Disconnected: true})
+	if !input.PausePressed || !input.RestartPressed || !input.ControllerLost {
+		t.Errorf("Expected actions from all sources to be merged, got %+v", input)
+	}
+}
+
+func TestHelpText_ListsGamepadWhenConnected(t *testing.T) {
+	g := createTestGame()
+	g.mobileControls = NewMobileControls(ScreenWidth, ScreenHeight)
+	g.gamepad = NewGamepadControls()
+
+	if text := g.helpText(); strings.Contains(text, "Gamepad") {
+		t.Errorf("Expected no gamepad controls without a gamepad, got %q", text)
+	}
+
+	g.gamepad.connected = true
+	if text := g.helpText(); !strings.Contains(text, gamepadHelpLine) {
+		t.Errorf("Expected the gamepad controls with a gamepad connected, got %q", text)
+	}
+}
+
+func TestCombineInput_PassesTouchGestures(t *testing.T) {
+	input := combineInput(false, false, MobileInput{Zoom: 1.1, PanX: 12, PanY: -4}, GamepadInput{})
+	if input.Zoom != 1.1 || input.PanX != 12 || input.PanY != -4 {
+		t.Errorf("Expected the pinch zoom and pan passed through, got %+v", input)
+	}
+}
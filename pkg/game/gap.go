@@ -0,0 +1,114 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Below this speed toward the mark (knots) the time gap is meaningless and left out
+const minGapSpeed = 0.5
+
+// alongCourseGap returns how far boat a is ahead of boat b (negative = behind) in meters,
+// measured along the course axis with bearing courseDir (degrees, 0 = North)
+func alongCourseGap(a, b geometry.Point, courseDir float64) float64 {
+	rad := courseDir * math.Pi / 180
+	return (a.X-b.X)*math.Sin(rad) - (a.Y-b.Y)*math.Cos(rad)
+}
+
+// gapReadout formats a gap as distance and time at the given speed along the course
+// ("+12m / +3.1s"); positive means ahead
+func gapReadout(gap, speedAlongCourse float64) string {
+	if speedAlongCourse < minGapSpeed {
+		return fmt.Sprintf("%+.0fm", gap)
+	}
+	seconds := gap / objects.MetersPerSecond(speedAlongCourse)
+	return fmt.Sprintf("%+.0fm / %+.1fs", gap, seconds)
+}
+
+// playerLeg returns the part of the course the player is sailing, in the opponents' terms
+func (g *GameState) playerLeg() AILeg {
+	switch {
+	case g.raceFinished:
+		return AILegFinished
+	case !g.hasCrossedLine:
+		return AILegPreStart
+	case !g.markRounded:
+		return AILegBeat
+	default:
+		return AILegRun
+	}
+}
+
+// nextMarkPos returns where the player is racing to: the upwind mark, any leeward gate,
+// then the finish line
+func (g *GameState) nextMarkPos() geometry.Point {
+	if g.markRounded && !g.gateCleared() {
+		return g.Arena.Gate.Midpoint()
+	}
+	if g.markRounded {
+		return g.Arena.Line.Midpoint()
+	}
+	return g.Dashboard.UpwindMark
+}
+
+// gapRival is a boat the player can be measured against: an AI opponent or the ghost
+type gapRival struct {
+	Name string
+	Pos  geometry.Point
+	Leg  AILeg
+	Lap  int // Laps completed
+}
+
+// gapRivals lists every boat racing the player: the AI fleet, then the personal best ghost
+func (g *GameState) gapRivals() []gapRival {
+	rivals := make([]gapRival, 0, len(g.opponents)+1)
+	for _, o := range g.opponents {
+		rivals = append(rivals, gapRival{Name: o.Name, Pos: o.Boat.Pos, Leg: o.Leg, Lap: o.LapsCompleted})
+	}
+	if frame, ok := g.ghostFrame(); ok {
+		if leg, lap, ok := g.ghost.LegAt(frame.Time); ok {
+			rivals = append(rivals, gapRival{Name: "Personal best", Pos: frame.Pos, Leg: leg, Lap: lap})
+		}
+	}
+	return rivals
+}
+
+// closestRival returns the rival on the same leg of the same lap nearest to the player
+// along the course and the player's gap to it (positive = ahead)
+func (g *GameState) closestRival() (gapRival, float64, bool) {
+	leg := g.playerLeg()
+	if leg != AILegBeat && leg != AILegRun {
+		return gapRival{}, 0, false
+	}
+
+	courseDir := geometry.Bearing(g.Boat.Pos, g.nextMarkPos())
+	var rival gapRival
+	var rivalGap float64
+	found := false
+	for _, r := range g.gapRivals() {
+		if r.Leg != leg || r.Lap != g.lapsCompleted {
+			continue
+		}
+		gap := alongCourseGap(g.Boat.Pos, r.Pos, courseDir)
+		if !found || math.Abs(gap) < math.Abs(rivalGap) {
+			rival, rivalGap, found = r, gap, true
+		}
+	}
+	return rival, rivalGap, found
+}
+
+// drawGapReadout shows the distance and time to the closest rival on the same leg
+func (g *GameState) drawGapReadout(screen *ebiten.Image) {
+	rival, gap, ok := g.closestRival()
+	if !ok {
+		return
+	}
+	speed := g.Dashboard.CalculateVMC(g.nextMarkPos())
+	text := fmt.Sprintf("vs %s: %s", rival.Name, gapReadout(gap, speed))
+	ebitenutil.DebugPrintAt(screen, text, screen.Bounds().Dx()/2+85, 50)
+}
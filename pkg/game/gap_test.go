@@ -0,0 +1,101 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestAlongCourseGap(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      geometry.Point
+		courseDir float64
+		want      float64
+	}{
+		{"ahead upwind", geometry.Point{X: 1000, Y: 2000}, geometry.Point{X: 1000, Y: 2012}, 0, 12},
+		{"behind upwind", geometry.Point{X: 1000, Y: 2012}, geometry.Point{X: 1000, Y: 2000}, 0, -12},
+		{"side by side", geometry.Point{X: 900, Y: 2000}, geometry.Point{X: 1100, Y: 2000}, 0, 0},
+		{"ahead downwind", geometry.Point{X: 1000, Y: 2100}, geometry.Point{X: 1000, Y: 2000}, 180, 100},
+		{"diagonal course", geometry.Point{X: 1030, Y: 1960}, geometry.Point{X: 1000, Y: 2000}, 90, 30},
+	}
+	for _, tt := range tests {
+		got := alongCourseGap(tt.a, tt.b, tt.courseDir)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected gap %.1fm, got %.1fm", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestGapReadout(t *testing.T) {
+	// 6 kts toward the mark is 30 m/s, so 12m is 0.4s
+	if got := gapReadout(12, 6); got != "+12m / +0.4s" {
+		t.Errorf("Expected \"+12m / +0.4s\", got %q", got)
+	}
+	if got := gapReadout(-45, 6); got != "-45m / -1.5s" {
+		t.Errorf("Expected \"-45m / -1.5s\", got %q", got)
+	}
+	if got := gapReadout(12, 0); got != "+12m" {
+		t.Errorf("Expected distance only when not making way, got %q", got)
+	}
+}
+
+func TestClosestRival_SameLegOnly(t *testing.T) {
+	g := createTestGame()
+	g.hasCrossedLine = true
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2200}
+
+	rival := func(name string, pos geometry.Point, leg AILeg) *Opponent {
+		return &Opponent{Name: name, Boat: &objects.Boat{Pos: pos}, Leg: leg}
+	}
+	g.opponents = []*Opponent{
+		rival("Far", geometry.Point{X: 1000, Y: 2300}, AILegBeat),
+		rival("Near", geometry.Point{X: 1200, Y: 2180}, AILegBeat),
+		rival("Rounded", geometry.Point{X: 1000, Y: 2200}, AILegRun),
+	}
+
+	o, gap, ok := g.closestRival()
+	if !ok || o.Name != "Near" {
+		t.Fatalf("Expected the nearest boat on the beat, got %v", o)
+	}
+	if gap >= 0 {
+		t.Errorf("Expected the player to be behind a boat 20m further up the course, got %+.1fm", gap)
+	}
+
+	g.hasCrossedLine = false
+	if o, _, ok := g.closestRival(); ok {
+		t.Errorf("Expected no gap readout before the start, got %s", o.Name)
+	}
+}
+
+func TestClosestRival_IncludesGhost(t *testing.T) {
+	g := createGhostGame()
+	g.ghost.Events = []ReplayEvent{{Type: ReplayEventStart, Time: 3 * time.Second}, {Type: ReplayEventMarkRounding, Time: 6 * time.Second}}
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2470}
+	g.opponents = []*Opponent{{Name: "Far", Boat: &objects.Boat{Pos: geometry.Point{X: 1000, Y: 2200}}, Leg: AILegBeat}}
+
+	// 2s after the gun the ghost is on the beat at Y=2460, 10m up the course
+	g.raceTimer = 2 * time.Second
+	rival, gap, ok := g.closestRival()
+	if !ok || rival.Name != "Personal best" || math.Abs(gap-(-10)) > 1e-9 {
+		t.Fatalf("Expected to be 10m behind the ghost, got %+v at %+.1fm", rival, gap)
+	}
+
+	// Once the ghost has rounded it is on another leg and the AI boat is the rival again
+	g.raceTimer = 5 * time.Second
+	if rival, _, _ := g.closestRival(); rival.Name != "Far" {
+		t.Errorf("Expected the ghost left out once it rounded, got %+v", rival)
+	}
+
+	// A best recorded without its race moments can't be placed on a leg
+	g.ghost.Events = nil
+	g.raceTimer = 2 * time.Second
+	if rival, _, _ := g.closestRival(); rival.Name != "Far" {
+		t.Errorf("Expected a ghost without events left out, got %+v", rival)
+	}
+}
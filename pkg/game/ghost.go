@@ -0,0 +1,43 @@
+package game
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+)
+
+// ghostClock returns how far the race has got relative to the starting gun (negative
+// before it), which is where the ghost is replayed from on its own recording. It runs
+// on the game clock, so the ghost waits while paused and starts over on a restart.
+func (g *GameState) ghostClock() time.Duration {
+	if g.raceStarted {
+		return g.raceTimer
+	}
+	return g.elapsedTime - g.timerDuration
+}
+
+// ghostFrame returns where the personal best run was at the same point of the race, lined
+// up by the starting gun. False when there is no personal best yet, once the ghost has
+// finished, or once the player has.
+func (g *GameState) ghostFrame() (ReplayFrame, bool) {
+	if g.ghost == nil || len(g.ghost.Frames) == 0 || g.raceFinished {
+		return ReplayFrame{}, false
+	}
+	t := g.ghost.GunTime + g.ghostClock()
+	if t > g.ghost.Frames[len(g.ghost.Frames)-1].Time {
+		return ReplayFrame{}, false
+	}
+	return g.ghost.FrameAt(t), true
+}
+
+// drawGhost draws the personal best run as a translucent gold boat to race against
+func (g *GameState) drawGhost(screen *ebiten.Image, view world.View) {
+	frame, ok := g.ghostFrame()
+	if !ok {
+		return
+	}
+	ghost := &objects.Boat{Pos: frame.Pos, Heading: frame.Heading, Speed: frame.Speed, Color: personalBestColor}
+	ghost.Draw(screen, view)
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createGhostGame sets up a game racing a personal best that started 2s into its
+// recording and moved a meter north every 100ms
+func createGhostGame() *GameState {
+	g := createTestGame()
+	replay := createTestReplay()
+	replay.GunTime = 2 * time.Second
+	g.ghost = replay.Track()
+	return g
+}
+
+func TestGhost_FollowsRaceTimer(t *testing.T) {
+	g := createGhostGame()
+	g.raceStarted = true
+	g.elapsedTime = g.timerDuration + 3*time.Second
+	g.raceTimer = 3 * time.Second
+
+	// 3s after the gun is 5s into the recording
+	frame, ok := g.ghostFrame()
+	if !ok {
+		t.Fatal("Expected the ghost to be racing")
+	}
+	want := geometry.Point{X: 1000, Y: 2450}
+	if frame.Pos != want {
+		t.Errorf("Expected the ghost at %+v, got %+v", want, frame.Pos)
+	}
+}
+
+func TestGhost_LinesUpBeforeTheGun(t *testing.T) {
+	g := createGhostGame()
+	g.elapsedTime = g.timerDuration - time.Second
+
+	// A second before the gun is 1s into the recording
+	frame, ok := g.ghostFrame()
+	if !ok || frame.Pos.Y != 2490 {
+		t.Errorf("Expected the ghost at Y=2490 a second before the gun, got %+v (%v)", frame.Pos, ok)
+	}
+}
+
+func TestGhost_HiddenAfterFinish(t *testing.T) {
+	g := createGhostGame()
+	g.raceStarted = true
+
+	// The ghost's recording ends 8s after its gun
+	g.raceTimer = 9 * time.Second
+	if _, ok := g.ghostFrame(); ok {
+		t.Error("Expected no ghost after its run ended")
+	}
+
+	g.raceTimer = 3 * time.Second
+	g.raceFinished = true
+	if _, ok := g.ghostFrame(); ok {
+		t.Error("Expected no ghost once the player has finished")
+	}
+
+	g.raceFinished = false
+	g.ghost = nil
+	if _, ok := g.ghostFrame(); ok {
+		t.Error("Expected no ghost without a personal best")
+	}
+}
+
+func TestGhost_NewBestRacedAfterRestart(t *testing.T) {
+	g := createTestGame()
+	g.store = newMemoryStore()
+	g.replay = createTestReplay()
+	g.finishTime = 90 * time.Second
+	g.recordPersonalBest()
+
+	// A fresh game on the same store races the run just saved, from the start
+	track, ok := LoadPersonalBestTrack(g.store, g.courseSignature())
+	if !ok {
+		t.Fatal("Expected the personal best track to be saved")
+	}
+	restarted := createTestGame()
+	restarted.ghost = track
+	frame, ok := restarted.ghostFrame()
+	if !ok || frame.Pos != track.Frames[0].Pos {
+		t.Errorf("Expected the ghost to start at the beginning of the new best, got %+v (%v)", frame.Pos, ok)
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+// totalLaps returns how many times round the course the race is (at least one)
+func (g *GameState) totalLaps() int {
+	return max(1, g.course.Laps)
+}
+
+// onFinalLap reports whether crossing the line after the marks finishes the race rather
+// than starting another lap
+func (g *GameState) onFinalLap() bool {
+	return g.lapsCompleted+1 >= g.totalLaps()
+}
+
+// startNextLap counts a completed lap and sends the boat round the marks again. The race
+// timer and distance sailed carry on across laps.
+func (g *GameState) startNextLap() {
+	g.lapsCompleted++
+	g.markRoundingPhase1 = false
+	g.markRoundingPhase2 = false
+	g.markRoundingPhase3 = false
+	g.markRounded = false
+	g.markCrossings = 0
+	g.markAcross = 0
+	g.wrongRounding = false
+	g.gatePassed = false
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createLapsGame returns a started race of the given number of laps with the mark rounded,
+// running down toward the middle of the line
+func createLapsGame(laps int) *GameState {
+	g := createTestGame()
+	g.replay = createTestReplay()
+	g.course.Laps = laps
+	g.raceStarted, g.hasCrossedLine = true, true
+	g.markRoundingPhase1, g.markRoundingPhase2, g.markRoundingPhase3, g.markRounded = true, true, true, true
+	g.Boat.Heading = 180
+	return g
+}
+
+// crossLineDownwind moves the bow across the middle of the line from the course side
+func crossLineDownwind(g *GameState) {
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2380}
+	g.prevBowPos = g.Boat.GetBowPosition()
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2410}
+	g.updateRace(time.Second)
+}
+
+func TestLaps_CrossingStartsNextLap(t *testing.T) {
+	g := createLapsGame(3)
+	g.raceTimer = 200 * time.Second
+
+	crossLineDownwind(g)
+	if g.raceFinished {
+		t.Fatal("Expected the first lap not to finish a three-lap race")
+	}
+	if g.lapsCompleted != 1 {
+		t.Errorf("Expected one lap completed, got %d", g.lapsCompleted)
+	}
+	if g.markRoundingPhase1 || g.markRoundingPhase2 || g.markRoundingPhase3 || g.markRounded {
+		t.Error("Expected the mark rounding to start over for the next lap")
+	}
+	if g.raceTimer != 201*time.Second {
+		t.Errorf("Expected the race timer to keep running, got %v", g.raceTimer)
+	}
+
+	// Crossing again without rounding the mark doesn't count
+	crossLineDownwind(g)
+	if g.lapsCompleted != 1 || g.raceFinished {
+		t.Errorf("Expected no lap without rounding, got %d laps (finished %v)", g.lapsCompleted, g.raceFinished)
+	}
+}
+
+func TestLaps_FinishOnLastLap(t *testing.T) {
+	g := createLapsGame(2)
+	g.lapsCompleted = 1
+	g.raceTimer = 400 * time.Second
+
+	crossLineDownwind(g)
+	if !g.raceFinished {
+		t.Fatal("Expected the last lap to finish the race")
+	}
+	if g.finishTime != 401*time.Second {
+		t.Errorf("Expected the finish time to cover every lap, got %v", g.finishTime)
+	}
+}
+
+func TestLaps_SingleLapByDefault(t *testing.T) {
+	g := createLapsGame(0)
+	crossLineDownwind(g)
+	if !g.raceFinished || g.lapsCompleted != 0 {
+		t.Errorf("Expected a single-lap race to finish first time round, finished %v laps %d", g.raceFinished, g.lapsCompleted)
+	}
+}
+
+func TestLaps_PenaltyOnlyHoldsTheFinish(t *testing.T) {
+	g := createLapsGame(2)
+	g.takePenalty()
+
+	crossLineDownwind(g)
+	if g.lapsCompleted != 1 {
+		t.Fatalf("Expected the lap to count with a penalty owed, got %d laps", g.lapsCompleted)
+	}
+
+	g.markRounded = true
+	crossLineDownwind(g)
+	if g.raceFinished {
+		t.Error("Expected no finish with a penalty owed")
+	}
+}
+
+func TestOpponents_SailEveryLap(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.course.Laps = 2
+	g.settings.Opponents = 2
+	g.setupFleet()
+
+	g.elapsedTime = g.timerDuration
+	finished := func() bool {
+		for _, o := range g.opponents {
+			if o.Leg != AILegFinished {
+				return false
+			}
+		}
+		return true
+	}
+	for frame := 0; frame < 30*60*60 && !finished(); frame++ {
+		g.raceTimer = time.Duration(frame) * time.Second / 60
+		g.updateOpponents()
+	}
+
+	for _, o := range g.opponents {
+		if o.Leg != AILegFinished || o.LapsCompleted != 1 {
+			t.Errorf("Expected %s to finish after two laps, leg %d with %d laps completed", o.Name, o.Leg, o.LapsCompleted)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import "time"
+
+// LeaderboardBackend stores race results and serves the leaderboard. Callbacks may run
+// asynchronously (the online leaderboard) or before the call returns (the local one).
+type LeaderboardBackend interface {
+	// SubmitScore saves a result, reporting success or an error message
+	SubmitScore(result *RaceResult, callback func(bool, string))
+	// GetLeaderboard fetches the results saved since the given time (zero for all of them),
+	// or an error message. Backends may return older results too; the scoreboard filters them.
+	GetLeaderboard(since time.Time, callback func([]RaceResult, string))
+	// Online reports whether results are shared online rather than kept on this device
+	Online() bool
+}
+
+// LeaderboardPeriod is a leaderboard tab, showing the races sailed since its start
+type LeaderboardPeriod int
+
+const (
+	PeriodAllTime LeaderboardPeriod = iota
+	PeriodThisWeek
+	PeriodToday
+	leaderboardPeriods // Number of tabs
+)
+
+func (p LeaderboardPeriod) String() string {
+	switch p {
+	case PeriodThisWeek:
+		return "This week"
+	case PeriodToday:
+		return "Today"
+	default:
+		return "All time"
+	}
+}
+
+// Since returns when the period began as of now: midnight for today, Monday midnight for
+// this week, and the zero time for all time
+func (p LeaderboardPeriod) Since(now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch p {
+	case PeriodToday:
+		return midnight
+	case PeriodThisWeek:
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		return midnight.AddDate(0, 0, -daysSinceMonday)
+	default:
+		return time.Time{}
+	}
+}
+
+// localLeaderboard keeps the results submitted on this device in a KeyValueStore
+type localLeaderboard struct {
+	store  KeyValueStore
+	course string // Signature of the course whose results are shown ("" = unknown)
+}
+
+// SubmitScore adds the result to the local history
+func (l *localLeaderboard) SubmitScore(result *RaceResult, callback func(bool, string)) {
+	if err := SaveLocalResult(l.store, result); err != nil {
+		callback(false, err.Error())
+		return
+	}
+	callback(true, "")
+}
+
+// GetLeaderboard returns every result in the local history of the course
+func (l *localLeaderboard) GetLeaderboard(since time.Time, callback func([]RaceResult, string)) {
+	callback(LoadLocalResults(l.store, l.course), "")
+}
+
+// Online is false: the history stays on this device
+func (l *localLeaderboard) Online() bool {
+	return false
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Column headings of the exported leaderboard
+var csvHeader = []string{"Player", "Race Time (s)", "Seconds Late", "Speed %", "Distance (m)", "Avg Speed (kt)", "Timestamp"}
+
+// ExportCSV writes every result the leaderboard was loaded from as CSV, one row per race.
+// Names containing commas or quotes are quoted.
+func (s *Scoreboard) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range s.results {
+		row := []string{
+			r.PlayerName,
+			strconv.FormatFloat(r.RaceTimeSeconds, 'f', 2, 64),
+			strconv.FormatFloat(r.SecondsLate, 'f', 1, 64),
+			strconv.FormatFloat(r.SpeedPercentage, 'f', 1, 64),
+			strconv.FormatFloat(r.DistanceSailed, 'f', 0, 64),
+			strconv.FormatFloat(r.AverageSpeed, 'f', 1, 64),
+			r.Timestamp.Format(time.RFC3339),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportLeaderboard saves the results as a timestamped CSV file (a download in the browser)
+func (s *Scoreboard) exportLeaderboard() {
+	var buf bytes.Buffer
+	if err := s.ExportCSV(&buf); err != nil {
+		s.exportNote = "Export failed: " + err.Error()
+		return
+	}
+
+	filename := "leaderboard-" + time.Now().Format("20060102-150405") + ".csv"
+	if err := saveCSV(filename, buf.Bytes()); err != nil {
+		s.exportNote = "Export failed: " + err.Error()
+		return
+	}
+	s.exportNote = "Exported " + filename
+}
@@ -0,0 +1,10 @@
+//go:build !js || !wasm
+
+package game
+
+import "os"
+
+// saveCSV writes the CSV to a file in the working directory
+func saveCSV(filename string, data []byte) error {
+	return os.WriteFile(filename, data, 0o644)
+}
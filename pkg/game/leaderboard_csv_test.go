@@ -0,0 +1,32 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportCSV(t *testing.T) {
+	sb := NewScoreboard(&fakeBackend{results: []RaceResult{
+		{
+			PlayerName: "Skipper", RaceTimeSeconds: 95.5, SecondsLate: 1.25, SpeedPercentage: 92.34,
+			MarkRounded: true, DistanceSailed: 1234.4, AverageSpeed: 6.25,
+			Timestamp: time.Date(2026, 5, 1, 14, 30, 0, 0, time.UTC),
+		},
+		{PlayerName: `Smith, "Jr"`, RaceTimeSeconds: 120, MarkRounded: true,
+			Timestamp: time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)},
+	}})
+	sb.ShowLeaderboardOnly(nil)
+
+	var out strings.Builder
+	if err := sb.ExportCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Player,Race Time (s),Seconds Late,Speed %,Distance (m),Avg Speed (kt),Timestamp\n" +
+		"Skipper,95.50,1.2,92.3,1234,6.2,2026-05-01T14:30:00Z\n" +
+		`"Smith, ""Jr""",120.00,0.0,0.0,0,0.0,2026-05-01T15:00:00Z` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build js && wasm
+
+package game
+
+import "syscall/js"
+
+// saveCSV offers the CSV as a browser download through a data URL
+func saveCSV(filename string, data []byte) error {
+	document := js.Global().Get("document")
+	link := document.Call("createElement", "a")
+	link.Set("href", "data:text/csv;charset=utf-8,"+js.Global().Call("encodeURIComponent", string(data)).String())
+	link.Set("download", filename)
+	document.Get("body").Call("appendChild", link)
+	link.Call("click")
+	document.Get("body").Call("removeChild", link)
+	return nil
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How long a leaderboard server has to answer before the request fails
+const restTimeout = 3 * time.Second
+
+// Results fetched from a leaderboard server (matches the Firebase query)
+const restLeaderboardLimit = 50
+
+// Finished requests whose callbacks can wait for the game loop at once
+const restAnswerQueue = 8
+
+// RESTBackend keeps the leaderboard on an HTTP server, for sharing scores between native
+// games on a LAN. Results are POSTed as JSON to BaseURL/results and the leaderboard is a
+// GET of the same path returning a JSON array of results (limit and, for a leaderboard tab,
+// since are passed as query parameters). Requests run in the background, so a slow server
+// never stalls the game; their callbacks wait for Poll to run them on the game loop.
+type RESTBackend struct {
+	BaseURL string
+	Client  *http.Client
+	answers chan func() // Callbacks of finished requests, waiting for Poll
+}
+
+// NewRESTBackend creates a backend for the leaderboard server at baseURL
+func NewRESTBackend(baseURL string) *RESTBackend {
+	return &RESTBackend{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  &http.Client{Timeout: restTimeout},
+		answers: make(chan func(), restAnswerQueue),
+	}
+}
+
+// Poll runs the callbacks of the requests that have finished since the last call. The
+// scoreboard polls every frame, so callbacks run on the game loop like the local leaderboard's.
+func (r *RESTBackend) Poll() {
+	for {
+		select {
+		case callback := <-r.answers:
+			callback()
+		default:
+			return
+		}
+	}
+}
+
+func (r *RESTBackend) resultsURL() string {
+	return r.BaseURL + "/results"
+}
+
+// Online is true: results are shared through the server
+func (r *RESTBackend) Online() bool {
+	return true
+}
+
+// SubmitScore POSTs the result to the server in the background
+func (r *RESTBackend) SubmitScore(result *RaceResult, callback func(bool, string)) {
+	// Encoded now, as the game loop may change the result while the request runs
+	data, err := json.Marshal(result)
+	go func() {
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+		} else {
+			errMsg = r.post(data)
+		}
+		r.answers <- func() { callback(errMsg == "", errMsg) }
+	}()
+}
+
+// post sends an encoded result, returning an error message or ""
+func (r *RESTBackend) post(data []byte) string {
+	resp, err := r.Client.Post(r.resultsURL(), "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Sprintf("Failed to submit score: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Sprintf("Failed to submit score: server returned %s", resp.Status)
+	}
+	return ""
+}
+
+// GetLeaderboard GETs the top results from the server in the background, those since a
+// time passed as an RFC 3339 since parameter
+func (r *RESTBackend) GetLeaderboard(since time.Time, callback func([]RaceResult, string)) {
+	go func() {
+		results, errMsg := r.fetch(since)
+		r.answers <- func() { callback(results, errMsg) }
+	}()
+}
+
+// fetch GETs the results since a time, or an error message
+func (r *RESTBackend) fetch(since time.Time) ([]RaceResult, string) {
+	query := url.Values{"limit": {strconv.Itoa(restLeaderboardLimit)}}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+	resp, err := r.Client.Get(r.resultsURL() + "?" + query.Encode())
+	if err != nil {
+		return nil, fmt.Sprintf("Failed to load leaderboard: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Sprintf("Failed to load leaderboard: server returned %s", resp.Status)
+	}
+
+	var results []RaceResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Sprintf("Failed to load leaderboard: %v", err)
+	}
+	return results, ""
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestLeaderboardServer serves POST and GET of /results from memory
+func newTestLeaderboardServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	var results []RaceResult
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/results" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			var result RaceResult
+			if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			results = append(results, result)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			if r.URL.Query().Get("limit") != "50" {
+				t.Errorf("Expected a limit of 50 results, got %q", r.URL.RawQuery)
+			}
+			since := time.Time{}
+			if param := r.URL.Query().Get("since"); param != "" {
+				var err error
+				if since, err = time.Parse(time.RFC3339, param); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			recent := make([]RaceResult, 0)
+			for _, result := range results {
+				if !result.Timestamp.Before(since) {
+					recent = append(recent, result)
+				}
+			}
+			json.NewEncoder(w).Encode(recent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// pollUntil polls the backend until done reports the answer has arrived
+func pollUntil(t *testing.T, backend *RESTBackend, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the leaderboard server to answer")
+		}
+		time.Sleep(time.Millisecond)
+		backend.Poll()
+	}
+}
+
+// waitForScoreboard polls the scoreboard's backend until nothing is loading
+func waitForScoreboard(t *testing.T, sb *Scoreboard) {
+	t.Helper()
+	pollUntil(t, sb.backend.(*RESTBackend), func() bool { return !sb.isLoading })
+}
+
+func TestRESTBackend_SubmitAndFetch(t *testing.T) {
+	server := newTestLeaderboardServer(t)
+	sb := NewScoreboard(NewRESTBackend(server.URL + "/"))
+
+	sb.Show(&RaceResult{RaceTimeSeconds: 120, MarkRounded: true})
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	waitForScoreboard(t, sb)
+	if sb.submitError != "" {
+		t.Fatalf("Expected the score to be submitted, got %q", sb.submitError)
+	}
+	if sb.state != StateDisplayLeaderboard || len(sb.leaderboard) != 1 {
+		t.Fatalf("Expected the leaderboard with the result, got state %d and %+v", sb.state, sb.leaderboard)
+	}
+	if entry := sb.leaderboard[0]; entry.PlayerName != "Skipper" || entry.RaceTime != "02:00.00" || !entry.IsCurrentRace {
+		t.Errorf("Expected Skipper's 02:00.00 highlighted, got %+v", entry)
+	}
+}
+
+func TestRESTBackend_ServerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sb := NewScoreboard(NewRESTBackend(server.URL))
+	sb.Show(&RaceResult{RaceTimeSeconds: 120, MarkRounded: true})
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	waitForScoreboard(t, sb)
+	if sb.state != StateEnterName || !strings.Contains(sb.submitError, "503") {
+		t.Errorf("Expected name entry with the server error, got state %d and %q", sb.state, sb.submitError)
+	}
+
+	sb.ShowLeaderboardOnly(nil)
+	waitForScoreboard(t, sb)
+	if sb.state != StateError || !strings.Contains(sb.submitError, "503") {
+		t.Errorf("Expected the error screen with the server error, got state %d and %q", sb.state, sb.submitError)
+	}
+}
+
+func TestRESTBackend_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	backend := NewRESTBackend(server.URL)
+	backend.Client.Timeout = 50 * time.Millisecond
+
+	// The request runs in the background: the game loop isn't held up waiting for it
+	answered := false
+	var gotErr string
+	started := time.Now()
+	backend.GetLeaderboard(time.Time{}, func(results []RaceResult, err string) {
+		answered, gotErr = true, err
+	})
+	if time.Since(started) > 20*time.Millisecond || answered {
+		t.Fatal("Expected GetLeaderboard to return before the server answers")
+	}
+
+	pollUntil(t, backend, func() bool { return answered })
+	if gotErr == "" {
+		t.Error("Expected an error from a server that doesn't answer")
+	}
+}
+
+func TestRESTBackend_SinceBound(t *testing.T) {
+	server := newTestLeaderboardServer(t)
+	backend := NewRESTBackend(server.URL)
+	submitted := 0
+	countSubmit := func(ok bool, err string) {
+		if !ok {
+			t.Fatal(err)
+		}
+		submitted++
+	}
+	backend.SubmitScore(&RaceResult{PlayerName: "Old Timer", Timestamp: time.Now().AddDate(0, 0, -30)}, countSubmit)
+	backend.SubmitScore(&RaceResult{PlayerName: "Skipper", Timestamp: time.Now()}, countSubmit)
+	pollUntil(t, backend, func() bool { return submitted == 2 })
+
+	var got []RaceResult
+	fetched := false
+	backend.GetLeaderboard(PeriodToday.Since(time.Now()), func(results []RaceResult, err string) {
+		if err != "" {
+			t.Fatal(err)
+		}
+		got, fetched = results, true
+	})
+	pollUntil(t, backend, func() bool { return fetched })
+	if len(got) != 1 || got[0].PlayerName != "Skipper" {
+		t.Errorf("Expected only today's result from the server, got %+v", got)
+	}
+}
@@ -0,0 +1,300 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBackend is a LeaderboardBackend that answers straight away from a slice
+type fakeBackend struct {
+	results   []RaceResult
+	submitErr string
+	fetchErr  string
+}
+
+func (f *fakeBackend) SubmitScore(result *RaceResult, callback func(bool, string)) {
+	if f.submitErr != "" {
+		callback(false, f.submitErr)
+		return
+	}
+	f.results = append(f.results, *result)
+	callback(true, "")
+}
+
+func (f *fakeBackend) GetLeaderboard(since time.Time, callback func([]RaceResult, string)) {
+	callback(f.results, f.fetchErr)
+}
+
+func (f *fakeBackend) Online() bool {
+	return true
+}
+
+// finishedRaces returns n completed races, one second apart from 100s
+func finishedRaces(n int) []RaceResult {
+	results := make([]RaceResult, n)
+	for i := range results {
+		results[i] = RaceResult{PlayerName: "Sailor", RaceTimeSeconds: float64(100 + i), MarkRounded: true}
+	}
+	return results
+}
+
+func TestScoreboard_TopTenAsksForName(t *testing.T) {
+	backend := &fakeBackend{results: finishedRaces(5)}
+	sb := NewScoreboard(backend)
+
+	sb.ShowWithTopCheck(&RaceResult{RaceTimeSeconds: 90, MarkRounded: true})
+	if !sb.IsVisible() || sb.state != StateEnterName {
+		t.Fatalf("Expected name entry for a top ten time, got state %d", sb.state)
+	}
+
+	// Submitting saves the result and shows it highlighted on the leaderboard
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	if len(backend.results) != 6 {
+		t.Fatalf("Expected the result to be submitted, backend has %d", len(backend.results))
+	}
+	if sb.state != StateDisplayLeaderboard || !sb.nameSubmitted {
+		t.Fatalf("Expected the leaderboard after submitting, got state %d", sb.state)
+	}
+	if first := sb.leaderboard[0]; first.PlayerName != "Skipper" || !first.IsCurrentRace {
+		t.Errorf("Expected the new result first and highlighted, got %+v", first)
+	}
+}
+
+func TestScoreboard_OutsideTopTenSkipsName(t *testing.T) {
+	backend := &fakeBackend{results: finishedRaces(10)}
+	sb := NewScoreboard(backend)
+
+	sb.ShowWithTopCheck(&RaceResult{RaceTimeSeconds: 200, MarkRounded: true})
+	if !sb.IsVisible() || sb.state != StateDisplayLeaderboard {
+		t.Fatalf("Expected the leaderboard straight away outside the top ten, got state %d", sb.state)
+	}
+	if len(sb.leaderboard) != 10 || len(backend.results) != 10 {
+		t.Errorf("Expected ten entries and nothing submitted, got %d entries and %d results",
+			len(sb.leaderboard), len(backend.results))
+	}
+}
+
+func TestScoreboard_BackendErrors(t *testing.T) {
+	// A failed submission stays on name entry with the error
+	backend := &fakeBackend{submitErr: "offline"}
+	sb := NewScoreboard(backend)
+	sb.Show(&RaceResult{RaceTimeSeconds: 90, MarkRounded: true})
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	if sb.state != StateEnterName || sb.submitError != "offline" || sb.nameSubmitted {
+		t.Errorf("Expected name entry with the error, got state %d and %q", sb.state, sb.submitError)
+	}
+
+	// A failed fetch shows the error screen
+	backend.fetchErr = "offline"
+	sb.ShowLeaderboardOnly(nil)
+	if sb.state != StateError {
+		t.Errorf("Expected the error screen when the leaderboard can't load, got state %d", sb.state)
+	}
+}
+
+func TestLocalLeaderboard_KeepsSubmittedResults(t *testing.T) {
+	store := newMemoryStore()
+	sb := NewScoreboard(&localLeaderboard{store: store})
+	if sb.savedOnline() {
+		t.Error("Expected the local leaderboard not to report saving online")
+	}
+
+	sb.Show(&RaceResult{RaceTimeSeconds: 120, MarkRounded: true})
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	if results := LoadLocalResults(store, ""); len(results) != 1 || results[0].PlayerName != "Skipper" {
+		t.Fatalf("Expected the result in the local history, got %+v", results)
+	}
+
+	// A later session sees it
+	later := NewScoreboard(&localLeaderboard{store: store})
+	later.ShowLeaderboardOnly(nil)
+	if len(later.leaderboard) != 1 || later.leaderboard[0].PlayerName != "Skipper" {
+		t.Errorf("Expected the saved result on the leaderboard, got %+v", later.leaderboard)
+	}
+}
+
+func TestScoreboard_PlayerBestReadout(t *testing.T) {
+	store := newMemoryStore()
+	sb := NewScoreboard(&localLeaderboard{store: store})
+	sb.store = store
+
+	// First race under a name
+	sb.Show(&RaceResult{RaceTimeSeconds: 101.5, MarkRounded: true})
+	if got := sb.playerBestReadout(); got != "" {
+		t.Errorf("Expected no readout before a name is typed, got %q", got)
+	}
+	sb.playerName = "Skipper"
+	if got := sb.playerBestReadout(); got != "First race for this name - a new personal best!" {
+		t.Errorf("Unexpected first race readout %q", got)
+	}
+	sb.submitScore()
+
+	// Next session, 3.2s faster
+	sb.Show(&RaceResult{RaceTimeSeconds: 98.3, MarkRounded: true})
+	sb.playerName = "skipper"
+	if got := sb.playerBestReadout(); got != "Personal best: 01:41 (-3.2s)" {
+		t.Errorf("Unexpected readout %q", got)
+	}
+	sb.submitScore()
+	if best := LoadPlayerBests(store, "")["skipper"]; best != 98.3 {
+		t.Errorf("Expected the faster time to become the best, got %.1f", best)
+	}
+
+	// A slower race keeps the best
+	sb.Show(&RaceResult{RaceTimeSeconds: 110, MarkRounded: true})
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	if best := LoadPlayerBests(store, "")["skipper"]; best != 98.3 {
+		t.Errorf("Expected the best to stay at 98.3s, got %.1f", best)
+	}
+
+	// A 3-lap race is compared with 3-lap races only
+	sb.Show(&RaceResult{RaceTimeSeconds: 290, MarkRounded: true, Laps: 3, Course: "keelboat_3lap_a"})
+	sb.playerName = "Skipper"
+	if got := sb.playerBestReadout(); got != "First race for this name - a new personal best!" {
+		t.Errorf("Expected no best yet on the 3-lap course, got %q", got)
+	}
+	sb.submitScore()
+	if best := LoadPlayerBests(store, "keelboat_3lap_a")["skipper"]; best != 290 {
+		t.Errorf("Expected a 3-lap best of 290s, got %.1f", best)
+	}
+	if best := LoadPlayerBests(store, "")["skipper"]; best != 98.3 {
+		t.Errorf("Expected the 1-lap best to stay at 98.3s, got %.1f", best)
+	}
+}
+
+func TestLeaderboardPeriod_Since(t *testing.T) {
+	wednesday := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	sunday := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	midnight := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		period LeaderboardPeriod
+		now    time.Time
+		want   time.Time
+	}{
+		{PeriodToday, wednesday, midnight},
+		{PeriodThisWeek, wednesday, monday},
+		{PeriodThisWeek, sunday, monday},
+		{PeriodThisWeek, monday, monday},
+		{PeriodAllTime, wednesday, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Since(tt.now); !got.Equal(tt.want) {
+			t.Errorf("%s at %s: expected since %s, got %s", tt.period, tt.now, tt.want, got)
+		}
+	}
+}
+
+func TestScoreboard_PeriodTabs(t *testing.T) {
+	now := time.Now()
+	current := RaceResult{PlayerName: "Skipper", RaceTimeSeconds: 110, MarkRounded: true, Timestamp: now}
+	backend := &fakeBackend{results: []RaceResult{
+		{PlayerName: "Old Timer", RaceTimeSeconds: 90, MarkRounded: true, Timestamp: now.AddDate(0, 0, -30)},
+		current,
+	}}
+	sb := NewScoreboard(backend)
+	sb.currentResult = &current
+
+	// All time ranks last month's faster race first
+	sb.ShowLeaderboardOnly(&current)
+	if len(sb.leaderboard) != 2 || sb.leaderboard[1].PlayerName != "Skipper" || !sb.leaderboard[1].IsCurrentRace {
+		t.Fatalf("Expected both races with Skipper second and highlighted, got %+v", sb.leaderboard)
+	}
+
+	// Today and this week only have the current race, still highlighted
+	for _, period := range []LeaderboardPeriod{PeriodToday, PeriodThisWeek} {
+		sb.period = period
+		sb.loadLeaderboard()
+		if len(sb.leaderboard) != 1 || sb.leaderboard[0].PlayerName != "Skipper" || !sb.leaderboard[0].IsCurrentRace {
+			t.Errorf("%s: expected only Skipper's highlighted race, got %+v", period, sb.leaderboard)
+		}
+	}
+	if tabs := sb.periodTabs(); tabs != "<  All time  [This week]  Today  >" {
+		t.Errorf("Unexpected tabs %q", tabs)
+	}
+
+	// A time that is only top ten today still asks for a name on the Today tab
+	sb.period = PeriodToday
+	backend.results = append(backend.results, finishedRaces(10)...)
+	sb.ShowWithTopCheck(&RaceResult{RaceTimeSeconds: 150, MarkRounded: true})
+	if sb.state != StateEnterName {
+		t.Errorf("Expected name entry for a top ten time today, got state %d", sb.state)
+	}
+}
+
+func TestScoreboard_Scrolling(t *testing.T) {
+	results := finishedRaces(47)
+	current := results[14] // 15th fastest
+	sb := NewScoreboard(&fakeBackend{results: results})
+	sb.ShowLeaderboardOnly(&current)
+
+	if len(sb.leaderboard) != 47 {
+		t.Fatalf("Expected the whole field of 47, got %d", len(sb.leaderboard))
+	}
+	if got := sb.scrollFooter(); got != "Showing 1-10 of 47" {
+		t.Errorf("Unexpected footer %q", got)
+	}
+	if pinned := sb.pinnedEntry(); pinned == nil || pinned.Rank != 15 {
+		t.Fatalf("Expected the 15th place race pinned below the top ten, got %+v", pinned)
+	}
+
+	// Scrolled into view it is highlighted in place instead of pinned
+	sb.scrollBy(10)
+	if got := sb.scrollFooter(); got != "Showing 11-20 of 47" {
+		t.Errorf("Unexpected footer %q", got)
+	}
+	if sb.pinnedEntry() != nil {
+		t.Error("Expected no pinned entry with the current race in view")
+	}
+	if entry := sb.visibleEntries()[4]; entry.Rank != 15 || !entry.IsCurrentRace {
+		t.Errorf("Expected the highlighted 15th place fifth in view, got %+v", entry)
+	}
+
+	// Scrolling stops at either end
+	sb.scrollBy(100)
+	if got := sb.scrollFooter(); got != "Showing 38-47 of 47" {
+		t.Errorf("Unexpected footer at the bottom %q", got)
+	}
+	sb.scrollBy(-100)
+	if sb.scroll != 0 {
+		t.Errorf("Expected to stop at the top, got offset %d", sb.scroll)
+	}
+
+	// A field that fits needs no footer and doesn't scroll
+	small := NewScoreboard(&fakeBackend{results: finishedRaces(4)})
+	small.ShowLeaderboardOnly(nil)
+	small.scrollBy(1)
+	if small.scroll != 0 || small.scrollFooter() != "" {
+		t.Errorf("Expected a short field not to scroll, got offset %d and footer %q", small.scroll, small.scrollFooter())
+	}
+}
+
+func TestRestart_KeepsLeaderboardAndNameFilter(t *testing.T) {
+	g := NewGame(nil)
+	store := newMemoryStore()
+	g.SetStore(store)
+	backend := &fakeBackend{}
+	g.SetLeaderboard(backend)
+	filter, err := LoadNameFilter(strings.NewReader("skipper\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.SetNameFilter(filter)
+
+	g.restart()
+	if g.scoreboard.backend != backend {
+		t.Errorf("Expected the leaderboard to survive a restart, got %T", g.scoreboard.backend)
+	}
+	if g.store != store || g.scoreboard.store != store {
+		t.Error("Expected the store to survive a restart")
+	}
+	if g.scoreboard.nameFilter != filter || !g.scoreboard.nameFilter.Blocked("Big Skipper") {
+		t.Error("Expected the loaded name blocklist to survive a restart")
+	}
+}
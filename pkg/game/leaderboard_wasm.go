@@ -0,0 +1,8 @@
+//go:build js && wasm
+
+package game
+
+// newLeaderboardBackend uses the online Firebase leaderboard in the browser
+func newLeaderboardBackend(store KeyValueStore, course string) LeaderboardBackend {
+	return NewFirebaseClient()
+}
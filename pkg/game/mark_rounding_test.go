@@ -1,8 +1,10 @@
 package game
 
 import (
+	"math"
 	"testing"
 
+	"github.com/mpihlak/gosailing2/pkg/game/world"
 	"github.com/mpihlak/gosailing2/pkg/geometry"
 )
 
@@ -194,6 +196,81 @@ func TestMarkRounding_Phase2_ResetIfDriftEast(t *testing.T) {
 	}
 }
 
+func TestMarkRounding_Starboard(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	upwindMark := g.Arena.Marks[2]
+	upwindMark.Rounding = world.RoundToStarboard
+
+	// Crossing over the top east to west no longer counts
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X - 5, Y: upwindMark.Pos.Y - 5}
+	g.updateMarkRounding()
+	if !g.markRoundingPhase1 || g.markRoundingPhase2 {
+		t.Fatal("Expected only phase 1 after crossing to port of a starboard mark")
+	}
+
+	// West to east over the top, then back down
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X + 5, Y: upwindMark.Pos.Y - 5}
+	g.updateMarkRounding()
+	if !g.markRoundingPhase2 {
+		t.Fatal("Expected phase 2 after crossing to starboard of the mark")
+	}
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X + 5, Y: upwindMark.Pos.Y + 5}
+	g.updateMarkRounding()
+	if !g.markRounded {
+		t.Error("Expected the mark to be rounded to starboard")
+	}
+}
+
+func TestMarkRounding_Phase2_ResetBothDirections(t *testing.T) {
+	tests := []struct {
+		rounding world.Rounding
+		farSide  float64 // Sign of the X offset from the mark that completes phase 2
+	}{
+		{world.RoundToPort, -1},
+		{world.RoundToStarboard, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rounding.String(), func(t *testing.T) {
+			g := createTestGame()
+			g.raceStarted = true
+			g.hasCrossedLine = true
+			upwindMark := g.Arena.Marks[2]
+			upwindMark.Rounding = tt.rounding
+			at := func(dx, dy float64) {
+				g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X + dx*tt.farSide, Y: upwindMark.Pos.Y + dy}
+				g.updateMarkRounding()
+			}
+
+			// Up on the near side, then back south: phase 2 isn't reached
+			at(-10, -10)
+			at(-10, 10)
+			if !g.markRoundingPhase1 || g.markRoundingPhase2 {
+				t.Fatal("Expected only phase 1 after dropping back south on the near side")
+			}
+
+			// Over the top to the far side, then drifting back to the near side
+			at(10, -10)
+			if !g.markRoundingPhase2 {
+				t.Fatal("Expected phase 2 on the far side of the mark")
+			}
+			at(-5, -10)
+			if g.markRoundingPhase2 || !g.markRoundingPhase1 {
+				t.Error("Expected phase 2 to reset on drifting back to the near side")
+			}
+
+			// Crossing again and sailing down completes the rounding
+			at(10, -10)
+			at(10, 10)
+			if !g.markRounded {
+				t.Errorf("Expected the %s rounding to complete", tt.rounding)
+			}
+		})
+	}
+}
+
 func TestMarkRounding_NotActiveBeforeLineCrossing(t *testing.T) {
 	g := createTestGame()
 	g.raceStarted = true
@@ -226,3 +303,261 @@ func TestMarkRounding_WithInsufficientMarks(t *testing.T) {
 		t.Error("No rounding phases should be triggered without upwind mark")
 	}
 }
+
+func TestLaylineHint_KeepGoingBelowLayline(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.hasCrossedLine = true
+
+	// Upwind mark at (1000, 1800), 45° beat without polars
+	tests := []struct {
+		name    string
+		pos     geometry.Point
+		heading float64
+		want    string
+	}{
+		{"Below the layline", geometry.Point{X: 1100, Y: 2200}, 315, keepGoingHint},
+		{"On the layline", geometry.Point{X: 1400, Y: 2200}, 315, layingMarkHint},
+		{"Above the layline", geometry.Point{X: 1400, Y: 2000}, 315, layingMarkHint},
+		{"Past the other layline, should tack", geometry.Point{X: 1400, Y: 2200}, 45, ""},
+	}
+	for _, tt := range tests {
+		g.Boat.Pos, g.Boat.Heading = tt.pos, tt.heading
+		if got := g.laylineHint(); got != tt.want {
+			t.Errorf("%s: expected hint %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	g.hasCrossedLine = false
+	g.Boat.Pos, g.Boat.Heading = geometry.Point{X: 1100, Y: 2200}, 315
+	if got := g.laylineHint(); got != "" {
+		t.Errorf("Expected no layline hint before the start, got %q", got)
+	}
+}
+
+func TestCollision_MarkTouchedAfterStart(t *testing.T) {
+	g := createTestGame()
+	g.replay = NewReplayState(ScreenWidth, ScreenHeight)
+	upwindMark := g.Arena.Marks[2]
+	g.Boat.Pos = upwindMark.Pos
+
+	// Sailing through a mark before the gun isn't flagged
+	g.checkCollisions()
+	if g.penaltyCount != 0 || g.touchedMark != "" {
+		t.Fatalf("Expected no foul before the start, got %d penalties, banner %q", g.penaltyCount, g.touchedMark)
+	}
+
+	g.raceStarted = true
+	g.checkCollisions()
+	if g.penaltyCount != 1 || len(g.collisionHistory) != 1 {
+		t.Fatalf("Expected one foul after the start, got %d penalties", g.penaltyCount)
+	}
+	if g.touchedMark != "Upwind" {
+		t.Errorf("Expected the banner to name the Upwind mark, got %q", g.touchedMark)
+	}
+	if len(g.replay.Events) != 1 || g.replay.Events[0].Type != ReplayEventFoul {
+		t.Errorf("Expected a foul on the replay timeline, got %+v", g.replay.Events)
+	}
+
+	// Still touching a moment later is the same collision
+	g.checkCollisions()
+	if g.penaltyCount != 1 {
+		t.Errorf("Expected the touch to be counted once, got %d penalties", g.penaltyCount)
+	}
+}
+
+func TestCollision_NoneAfterFinish(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.raceFinished = true
+	g.Boat.Pos = g.Arena.Marks[1].Pos
+
+	g.checkCollisions()
+	if g.penaltyCount != 0 {
+		t.Errorf("Expected no foul after finishing, got %d penalties", g.penaltyCount)
+	}
+}
+
+// addTestGate puts an 80m leeward gate 150m above the middle of the test game's start line
+func addTestGate(g *GameState) {
+	left := &world.Mark{Pos: geometry.Point{X: 960, Y: 2250}, Name: "Gate Left"}
+	right := &world.Mark{Pos: geometry.Point{X: 1040, Y: 2250}, Name: "Gate Right"}
+	g.Arena.Marks = append(g.Arena.Marks, left, right)
+	g.Arena.Gate = &world.Gate{Left: left, Right: right}
+}
+
+func TestGatePassing_RequiredBeforeFinish(t *testing.T) {
+	g := createTestGame()
+	g.replay = createTestReplay()
+	addTestGate(g)
+	g.raceStarted, g.hasCrossedLine, g.markRounded = true, true, true
+	g.Boat.Heading = 180
+
+	// Move the boat between two positions within one frame of race checks
+	sail := func(from, to geometry.Point) {
+		g.Boat.Pos = from
+		g.prevBowPos = g.Boat.GetBowPosition()
+		g.Boat.Pos = to
+		g.updateRace(0)
+	}
+
+	// Running down outside the gate and across the finish line doesn't count
+	sail(geometry.Point{X: 1100, Y: 2230}, geometry.Point{X: 1100, Y: 2260})
+	sail(geometry.Point{X: 1100, Y: 2380}, geometry.Point{X: 1100, Y: 2410})
+	if g.gatePassed || g.raceFinished {
+		t.Fatalf("Expected no gate or finish outside the gate, got gate %v finish %v", g.gatePassed, g.raceFinished)
+	}
+
+	// Back up and through the gate, then across the line
+	sail(geometry.Point{X: 1000, Y: 2230}, geometry.Point{X: 1000, Y: 2260})
+	if !g.gatePassed {
+		t.Fatal("Expected the gate to be passed between the marks")
+	}
+	sail(geometry.Point{X: 1000, Y: 2380}, geometry.Point{X: 1000, Y: 2410})
+	if !g.raceFinished {
+		t.Error("Expected to finish after passing the gate")
+	}
+}
+
+func TestGatePassing_NotBeforeRounding(t *testing.T) {
+	g := createTestGame()
+	g.replay = createTestReplay()
+	addTestGate(g)
+	g.raceStarted, g.hasCrossedLine = true, true
+	g.Boat.Heading = 180
+
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2230}
+	g.prevBowPos = g.Boat.GetBowPosition()
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2260}
+	g.updateRace(0)
+	if g.gatePassed {
+		t.Error("Expected the gate not to count before rounding the upwind mark")
+	}
+}
+
+// sailPast moves the boat through the waypoints (offsets from the mark) in 1m steps,
+// checking the mark rounding at each step
+func sailPast(g *GameState, mark *world.Mark, waypoints ...geometry.Point) {
+	pos := g.Boat.Pos
+	for _, wp := range waypoints {
+		target := geometry.Point{X: mark.Pos.X + wp.X, Y: mark.Pos.Y + wp.Y}
+		steps := int(math.Ceil(distance(pos, target)))
+		for i := 1; i <= steps; i++ {
+			f := float64(i) / float64(steps)
+			g.Boat.Pos = geometry.Point{X: pos.X + (target.X-pos.X)*f, Y: pos.Y + (target.Y-pos.Y)*f}
+			g.updateMarkRounding()
+		}
+		pos = target
+	}
+}
+
+func TestMarkRounding_WrongWay(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	upwindMark := g.Arena.Marks[2]
+
+	// Up the west side and clockwise over the top: the mark is left to starboard
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X - 20, Y: upwindMark.Pos.Y + 30}
+	sailPast(g, upwindMark, geometry.Point{X: -20, Y: -20}, geometry.Point{X: 20, Y: -20}, geometry.Point{X: 20, Y: 30})
+	if g.markRounded {
+		t.Fatal("Expected a wrong-way rounding not to count")
+	}
+	if !g.wrongRounding {
+		t.Fatal("Expected the wrong-way rounding to be flagged")
+	}
+
+	// Rounding it properly counts and clears the warning
+	sailPast(g, upwindMark, geometry.Point{X: 20, Y: -20}, geometry.Point{X: -20, Y: -20}, geometry.Point{X: -20, Y: 30})
+	if !g.markRounded || g.wrongRounding {
+		t.Errorf("Expected a correct re-rounding to count, got rounded=%v wrong=%v", g.markRounded, g.wrongRounding)
+	}
+}
+
+func TestMarkRounding_WrongWayStarboardMark(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	upwindMark := g.Arena.Marks[2]
+	upwindMark.Rounding = world.RoundToStarboard
+
+	// Counter-clockwise, as for a port mark
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X + 20, Y: upwindMark.Pos.Y + 30}
+	sailPast(g, upwindMark, geometry.Point{X: 20, Y: -20}, geometry.Point{X: -20, Y: -20}, geometry.Point{X: -20, Y: 30})
+	if g.markRounded || !g.wrongRounding {
+		t.Errorf("Expected a wrong-way rounding of a starboard mark, got rounded=%v wrong=%v", g.markRounded, g.wrongRounding)
+	}
+}
+
+func TestMarkRounding_OvershootIsNotWrongWay(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	upwindMark := g.Arena.Marks[2]
+
+	// Over the top, then back the way the boat came and down the same side
+	g.Boat.Pos = geometry.Point{X: upwindMark.Pos.X + 20, Y: upwindMark.Pos.Y + 30}
+	sailPast(g, upwindMark, geometry.Point{X: 20, Y: -20}, geometry.Point{X: -20, Y: -20}, geometry.Point{X: 20, Y: -20}, geometry.Point{X: 20, Y: 30})
+	if g.markRounded || g.wrongRounding {
+		t.Errorf("Expected neither a rounding nor a wrong-way rounding, got rounded=%v wrong=%v", g.markRounded, g.wrongRounding)
+	}
+}
+
+func TestTackNow_LatchesAtLayline(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.settings = DefaultSettings()
+	g.hasCrossedLine = true
+
+	// Upwind mark at (1000, 1800), 45° beat without polars: port tack east of the mark
+	// reaches the starboard layline at X = 1000 + (2200-1800)
+	g.Boat.Heading = 45
+	g.Boat.Pos = geometry.Point{X: 1390, Y: 2200}
+	g.updateTackNow()
+	if got := g.laylineHint(); got == tackNowHint {
+		t.Fatal("Expected no TACK NOW below the layline")
+	}
+
+	g.Boat.Pos = geometry.Point{X: 1401, Y: 2200}
+	g.updateTackNow()
+	if got := g.laylineHint(); got != tackNowHint {
+		t.Fatalf("Expected TACK NOW on reaching the layline, got %q", got)
+	}
+
+	// A shift or a wobble just below the layline doesn't clear it...
+	g.Boat.Pos = geometry.Point{X: 1395, Y: 2200}
+	g.updateTackNow()
+	if !g.tackNow {
+		t.Error("Expected TACK NOW to hold just below the layline")
+	}
+	// ...dropping clearly below does
+	g.Boat.Pos = geometry.Point{X: 1300, Y: 2200}
+	g.updateTackNow()
+	if g.tackNow {
+		t.Error("Expected TACK NOW to clear well below the layline")
+	}
+
+	// Tacking onto the layline clears it: now laying the mark
+	g.Boat.Pos = geometry.Point{X: 1410, Y: 2200}
+	g.updateTackNow()
+	g.Boat.Heading = 315
+	g.updateTackNow()
+	if got := g.laylineHint(); got != layingMarkHint {
+		t.Errorf("Expected %q after tacking on the layline, got %q", layingMarkHint, got)
+	}
+}
+
+func TestTackNow_CanBeTurnedOff(t *testing.T) {
+	g := createTestGame()
+	g.Wind = &world.ConstantWind{Direction: 0, Speed: 10}
+	g.settings = DefaultSettings()
+	g.settings.ShowTackNow = false
+	g.hasCrossedLine = true
+
+	g.Boat.Heading = 45
+	g.Boat.Pos = geometry.Point{X: 1450, Y: 2200}
+	g.updateTackNow()
+	if got := g.laylineHint(); got != "" {
+		t.Errorf("Expected no hint with the TACK NOW cue off, got %q", got)
+	}
+}
@@ -20,16 +20,38 @@ type MobileControls struct {
 
 	// Additional UI button zones
 	restartButton TouchZone
+	layoutButton  TouchZone // Cycles the button layout, on the pause screen
+
+	// Button size and placement, and the screen they were laid out for
+	layout                    ControlLayout
+	screenWidth, screenHeight int
+	inputScale                int // Render pixels per logical pixel the buttons are laid out in (2 when supersampling)
+
+	// Swipe steering: a finger dragged sideways across the lower half of the screen
+	swipeTop    int            // Swipes start below this screen Y
+	swipeID     ebiten.TouchID // Finger doing the steering
+	swipeStartX int            // Where that finger first touched
+	swiping     bool
+	swipeTurn   float64 // Turn from the swipe this frame, -1 (full left) to 1 (full right)
 
 	// Button press states
 	leftPressed    bool
 	rightPressed   bool
 	pausePressed   bool
 	restartPressed bool
+	layoutPressed  bool
 
 	// State
 	lastTouchTime int
-	hasTouchInput bool // Track if we've ever seen touch input
+	hasTouchInput bool    // Track if we've ever seen touch input
+	pinchDistance float64 // Distance between two pinching fingers last frame, 0 when not pinching
+	pinchMidX     float64 // Midpoint between the two fingers last frame
+	pinchMidY     float64
+	pinchZoom     float64 // Pinch zoom factor this frame, 0 when not pinching
+	panX, panY    float64 // Two-finger pan this frame in screen pixels
+	multiTouch    bool    // More than one finger has been down since the screen was last clear
+	tapped        bool    // A single finger was lifted this frame without a two-finger gesture
+	tapX, tapY    int     // Where it was lifted
 
 	// Testing
 	showControlsOverride bool // Force show controls on desktop for testing
@@ -43,36 +65,8 @@ type TouchZone struct {
 
 // NewMobileControls creates a new mobile controls instance
 func NewMobileControls(screenWidth, screenHeight int) *MobileControls {
-	buttonSize := 80
-	margin := 20
-
-	mc := &MobileControls{
-		// Left arrow button in lower left corner
-		leftButton: TouchZone{
-			X: margin, Y: screenHeight - buttonSize - margin,
-			Width: buttonSize, Height: buttonSize,
-			Enabled: true,
-		},
-		// Right arrow button in lower right corner
-		rightButton: TouchZone{
-			X: screenWidth - buttonSize - margin, Y: screenHeight - buttonSize - margin,
-			Width: buttonSize, Height: buttonSize,
-			Enabled: true,
-		},
-		// Pause/play button in center bottom
-		pauseButton: TouchZone{
-			X: screenWidth/2 - buttonSize/2, Y: screenHeight - buttonSize - margin,
-			Width: buttonSize, Height: buttonSize,
-			Enabled: true,
-		},
-
-		// Restart button in top left corner
-		restartButton: TouchZone{
-			X: margin, Y: margin,
-			Width: buttonSize * 2 / 3, Height: buttonSize * 2 / 3, // Slightly larger than old menu button
-			Enabled: true,
-		},
-	}
+	mc := &MobileControls{}
+	mc.Layout(screenWidth, screenHeight)
 
 	// Determine touch capability at initialization
 	mc.detectTouchCapability()
@@ -80,6 +74,141 @@ func NewMobileControls(screenWidth, screenHeight int) *MobileControls {
 	return mc
 }
 
+// ButtonPlacement says where the steering buttons sit
+type ButtonPlacement int
+
+const (
+	PlacementSplit       ButtonPlacement = iota // Left button in the bottom-left corner, right in the bottom-right
+	PlacementRightHanded                        // Both steering buttons bottom-right, under the right thumb
+	PlacementLeftHanded                         // Both steering buttons bottom-left, under the left thumb
+)
+
+func (p ButtonPlacement) String() string {
+	switch p {
+	case PlacementRightHanded:
+		return "right-handed"
+	case PlacementLeftHanded:
+		return "left-handed"
+	default:
+		return "split"
+	}
+}
+
+// ControlLayout sizes and places the touch buttons
+type ControlLayout struct {
+	ButtonSize int // Width and height of the steering and pause buttons in pixels
+	Margin     int // Gap between the buttons and the screen edges
+	Placement  ButtonPlacement
+}
+
+// DefaultControlLayout returns the layout for a first launch: standard buttons split
+// between the bottom corners
+func DefaultControlLayout() ControlLayout {
+	return ControlLayout{ButtonSize: 80, Margin: 20, Placement: PlacementSplit}
+}
+
+// controlLayouts are the layouts the layout button cycles through: standard buttons for
+// phones and larger ones for tablets, in each placement
+var controlLayouts = []ControlLayout{
+	{ButtonSize: 80, Margin: 20, Placement: PlacementSplit},
+	{ButtonSize: 110, Margin: 30, Placement: PlacementSplit},
+	{ButtonSize: 80, Margin: 20, Placement: PlacementRightHanded},
+	{ButtonSize: 110, Margin: 30, Placement: PlacementRightHanded},
+	{ButtonSize: 80, Margin: 20, Placement: PlacementLeftHanded},
+	{ButtonSize: 110, Margin: 30, Placement: PlacementLeftHanded},
+}
+
+// Next returns the following layout in the cycle; a custom layout goes back to the first
+func (l ControlLayout) Next() ControlLayout {
+	for i, preset := range controlLayouts {
+		if preset == l {
+			return controlLayouts[(i+1)%len(controlLayouts)]
+		}
+	}
+	return controlLayouts[0]
+}
+
+// String describes the layout on the layout button
+func (l ControlLayout) String() string {
+	size := "standard"
+	if l.ButtonSize > DefaultControlLayout().ButtonSize {
+		size = "large"
+	}
+	return fmt.Sprintf("%s, %s", l.Placement, size)
+}
+
+// normalized returns the layout with unset sizes taken from the default
+func (l ControlLayout) normalized() ControlLayout {
+	if l.ButtonSize <= 0 {
+		l.ButtonSize = DefaultControlLayout().ButtonSize
+	}
+	if l.Margin <= 0 {
+		l.Margin = DefaultControlLayout().Margin
+	}
+	return l
+}
+
+// SetLayout changes the button size and placement, moving the buttons straight away
+func (mc *MobileControls) SetLayout(layout ControlLayout) {
+	mc.layout = layout
+	mc.Layout(mc.screenWidth, mc.screenHeight)
+}
+
+// Layout places the buttons for a screen of the given size
+func (mc *MobileControls) Layout(screenWidth, screenHeight int) {
+	mc.screenWidth, mc.screenHeight = screenWidth, screenHeight
+	layout := mc.layout.normalized()
+	buttonSize := layout.ButtonSize
+	margin := layout.Margin
+	mc.swipeTop = screenHeight / 2
+
+	// Steering buttons in the bottom corners, or side by side in one corner
+	leftX, rightX := margin, screenWidth-buttonSize-margin
+	switch layout.Placement {
+	case PlacementRightHanded:
+		leftX = rightX - buttonSize - margin
+	case PlacementLeftHanded:
+		rightX = leftX + buttonSize + margin
+	}
+	mc.leftButton = TouchZone{
+		X: leftX, Y: screenHeight - buttonSize - margin,
+		Width: buttonSize, Height: buttonSize,
+		Enabled: true,
+	}
+	mc.rightButton = TouchZone{
+		X: rightX, Y: screenHeight - buttonSize - margin,
+		Width: buttonSize, Height: buttonSize,
+		Enabled: true,
+	}
+	// Pause/play button in center bottom, lifted a row above the steering buttons when
+	// the screen is too narrow for all three side by side, as on a portrait phone
+	mc.pauseButton = TouchZone{
+		X: screenWidth/2 - buttonSize/2, Y: screenHeight - buttonSize - margin,
+		Width: buttonSize, Height: buttonSize,
+		Enabled: true,
+	}
+	if mc.pauseButton.Intersects(mc.leftButton) || mc.pauseButton.Intersects(mc.rightButton) {
+		mc.pauseButton.Y -= buttonSize + margin
+	}
+
+	// Restart button in top left corner
+	mc.restartButton = TouchZone{
+		X: margin, Y: margin,
+		Width: buttonSize * 2 / 3, Height: buttonSize * 2 / 3, // Slightly larger than old menu button
+		Enabled: true,
+	}
+
+	// Layout button in the top right corner, wide enough for its label
+	mc.layoutButton = TouchZone{
+		X: screenWidth - layoutButtonWidth - margin, Y: margin,
+		Width: layoutButtonWidth, Height: 40,
+		Enabled: true,
+	}
+}
+
+// Width of the layout button on the pause screen
+const layoutButtonWidth = 210
+
 // detectTouchCapability determines if the device supports touch input
 func (mc *MobileControls) detectTouchCapability() {
 	// Check if there are any active touch points
@@ -101,13 +230,39 @@ func (tz *TouchZone) Contains(x, y int) bool {
 		y >= tz.Y && y < tz.Y+tz.Height
 }
 
-// Update processes touch input for mobile controls
-func (mc *MobileControls) Update() {
+// Intersects checks if two touch zones overlap
+func (tz TouchZone) Intersects(other TouchZone) bool {
+	return tz.X < other.X+other.Width && other.X < tz.X+tz.Width &&
+		tz.Y < other.Y+other.Height && other.Y < tz.Y+tz.Height
+}
+
+// touchPosition returns where a finger is, in the logical pixels the buttons are laid out in
+func (mc *MobileControls) touchPosition(id ebiten.TouchID) (int, int) {
+	return mc.toLogical(ebiten.TouchPosition(id))
+}
+
+// toLogical converts a touch position in render pixels to logical pixels
+func (mc *MobileControls) toLogical(x, y int) (int, int) {
+	if mc.inputScale > 1 {
+		return x / mc.inputScale, y / mc.inputScale
+	}
+	return x, y
+}
+
+// Update processes touch input for mobile controls. inputScale is the number of render
+// pixels per logical pixel (2 when supersampling).
+func (mc *MobileControls) Update(inputScale int) {
+	mc.inputScale = inputScale
 	// Reset button press states
 	mc.leftPressed = false
 	mc.rightPressed = false
 	mc.pausePressed = false
 	mc.restartPressed = false
+	mc.layoutPressed = false
+	mc.pinchZoom = 0
+	mc.panX, mc.panY = 0, 0
+	mc.swipeTurn = 0
+	mc.tapped = false
 
 	// Dynamically detect touch input during runtime
 	// Check both current touches and just-pressed touches
@@ -127,7 +282,7 @@ func (mc *MobileControls) Update() {
 
 	// Check each button for current touches (held down)
 	for _, id := range currentTouchIDs {
-		x, y := ebiten.TouchPosition(id)
+		x, y := mc.touchPosition(id)
 
 		if mc.leftButton.Contains(x, y) {
 			mc.leftPressed = true
@@ -137,12 +292,16 @@ func (mc *MobileControls) Update() {
 		}
 	}
 
+	mc.updatePinch(currentTouchIDs)
+	mc.updateSwipe(currentTouchIDs)
+	mc.updateTap(currentTouchIDs)
+
 	// Get just pressed touches for one-time button interactions (pause, menu, etc.)
 	justPressedTouchIDs := inpututil.AppendJustPressedTouchIDs(nil)
 
 	// Check action buttons for just pressed touches
 	for _, id := range justPressedTouchIDs {
-		x, y := ebiten.TouchPosition(id)
+		x, y := mc.touchPosition(id)
 
 		if mc.pauseButton.Contains(x, y) {
 			mc.pausePressed = true
@@ -150,6 +309,9 @@ func (mc *MobileControls) Update() {
 		if mc.restartButton.Contains(x, y) {
 			mc.restartPressed = true
 		}
+		if mc.layoutButton.Contains(x, y) {
+			mc.layoutPressed = true
+		}
 	}
 }
 
@@ -158,9 +320,125 @@ func (mc *MobileControls) GetMobileInput() MobileInput {
 	return MobileInput{
 		TurnLeft:       mc.leftPressed,
 		TurnRight:      mc.rightPressed,
+		Turn:           mc.swipeTurn,
 		PausePressed:   mc.pausePressed,
 		RestartPressed: mc.restartPressed,
+		LayoutPressed:  mc.layoutPressed,
+		Zoom:           mc.pinchZoom,
+		PanX:           mc.panX,
+		PanY:           mc.panY,
+	}
+}
+
+// Tap returns where a single finger was lifted this frame. Fingers that were part of a
+// two-finger pinch or pan don't count, so surveying the course doesn't unpause the game.
+func (mc *MobileControls) Tap() (int, int, bool) {
+	return mc.tapX, mc.tapY, mc.tapped
+}
+
+// updateTap records a finger lifted this frame, unless more than one finger has been down
+// since the screen was last clear
+func (mc *MobileControls) updateTap(touchIDs []ebiten.TouchID) {
+	if len(touchIDs) > 1 {
+		mc.multiTouch = true
+	}
+	for _, id := range inpututil.AppendJustReleasedTouchIDs(nil) {
+		if !mc.multiTouch {
+			mc.tapX, mc.tapY = mc.toLogical(inpututil.TouchPositionInPreviousTick(id))
+			mc.tapped = true
+		}
+	}
+	if len(touchIDs) == 0 {
+		mc.multiTouch = false
+	}
+}
+
+// overButton reports whether a touch position is on one of the control buttons
+func (mc *MobileControls) overButton(x, y int) bool {
+	for _, zone := range []*TouchZone{&mc.leftButton, &mc.rightButton, &mc.pauseButton, &mc.restartButton, &mc.layoutButton} {
+		if zone.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePinch tracks two fingers on the course, away from the buttons, and turns the
+// change in the distance between them into a zoom factor for the frame, and the movement
+// of their midpoint into a pan
+func (mc *MobileControls) updatePinch(touchIDs []ebiten.TouchID) {
+	var fingers [][2]int
+	for _, id := range touchIDs {
+		if x, y := mc.touchPosition(id); !mc.overButton(x, y) {
+			fingers = append(fingers, [2]int{x, y})
+		}
+	}
+	if len(fingers) != 2 {
+		mc.pinchDistance = 0
+		return
+	}
+
+	dist := math.Hypot(float64(fingers[0][0]-fingers[1][0]), float64(fingers[0][1]-fingers[1][1]))
+	midX, midY := float64(fingers[0][0]+fingers[1][0])/2, float64(fingers[0][1]+fingers[1][1])/2
+	if mc.pinchDistance > 0 {
+		mc.panX, mc.panY = midX-mc.pinchMidX, midY-mc.pinchMidY
 	}
+	mc.pinchZoom = pinchZoom(mc.pinchDistance, dist)
+	mc.pinchDistance, mc.pinchMidX, mc.pinchMidY = dist, midX, midY
+}
+
+// Swipe steering: this many pixels sideways from where the finger went down is full helm,
+// and the first few pixels are ignored so a resting finger doesn't steer
+const (
+	swipeFullTurn = 80
+	swipeDeadZone = 8
+)
+
+// updateSwipe starts swipe steering when a finger goes down on the lower half of the screen
+// away from the buttons, and turns by how far it has been dragged sideways since. Two
+// fingers on the course are a pinch, not a swipe.
+func (mc *MobileControls) updateSwipe(touchIDs []ebiten.TouchID) {
+	if mc.pinchDistance > 0 {
+		mc.swiping = false
+		return
+	}
+
+	if mc.swiping {
+		held := false
+		for _, id := range touchIDs {
+			if id == mc.swipeID {
+				held = true
+				x, _ := mc.touchPosition(id)
+				mc.swipeTurn = swipeTurn(x - mc.swipeStartX)
+			}
+		}
+		mc.swiping = held
+		return
+	}
+
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		if x, y := mc.touchPosition(id); y >= mc.swipeTop && !mc.overButton(x, y) {
+			mc.swipeID, mc.swipeStartX, mc.swiping = id, x, true
+			return
+		}
+	}
+}
+
+// swipeTurn maps a sideways drag in pixels to a proportional turn (-1..1)
+func swipeTurn(dx int) float64 {
+	if dx > -swipeDeadZone && dx < swipeDeadZone {
+		return 0
+	}
+	return math.Max(-1, math.Min(1, float64(dx)/swipeFullTurn))
+}
+
+// pinchZoom returns the zoom factor for fingers moving from prev to dist apart, 0 when the
+// pinch is just starting
+func pinchZoom(prev, dist float64) float64 {
+	if prev <= 0 || dist <= 0 {
+		return 0
+	}
+	return dist / prev
 }
 
 // ToggleControlsOverride toggles the display of mobile controls on desktop for testing
@@ -172,8 +450,12 @@ func (mc *MobileControls) ToggleControlsOverride() {
 type MobileInput struct {
 	TurnLeft       bool
 	TurnRight      bool
+	Turn           float64 // Proportional turn from a swipe, -1 (full left) to 1 (full right)
 	PausePressed   bool
 	RestartPressed bool
+	LayoutPressed  bool    // The layout button was tapped (only shown while paused)
+	Zoom           float64 // Pinch zoom factor this frame, 0 when not pinching
+	PanX, PanY     float64 // Two-finger pan this frame in screen pixels
 }
 
 // Draw renders the mobile control elements on screen
@@ -221,11 +503,18 @@ func (mc *MobileControls) Draw(screen *ebiten.Image, isPaused bool) {
 	}
 	mc.drawRestartArrow(screen, mc.restartButton, restartColor)
 
+	// Draw the layout button on the pause screen, labelled with the current layout
+	if isPaused {
+		zone := mc.layoutButton
+		vector.DrawFilledRect(screen, float32(zone.X), float32(zone.Y), float32(zone.Width), float32(zone.Height), color.RGBA{80, 80, 80, 200}, false)
+		ebitenutil.DebugPrintAt(screen, "Buttons: "+mc.layout.normalized().String(), zone.X+8, zone.Y+zone.Height/2-8)
+	}
+
 	// Debug: Show button positions and current touches
 	touchIDs := ebiten.AppendTouchIDs(nil)
 	if len(touchIDs) > 0 {
 		for i, touchID := range touchIDs {
-			x, y := ebiten.TouchPosition(touchID)
+			x, y := mc.touchPosition(touchID)
 			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Touch %d: %d,%d", i, x, y), 10, 50+i*15)
 		}
 	}
@@ -236,7 +525,7 @@ func (mc *MobileControls) Draw(screen *ebiten.Image, isPaused bool) {
 
 	// Debug: Show screen vs logical size
 	windowW, windowH := ebiten.WindowSize()
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Window: %dx%d Screen: %dx%d", windowW, windowH, ScreenWidth, ScreenHeight), 10, 140)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Window: %dx%d Screen: %dx%d", windowW, windowH, screen.Bounds().Dx(), screen.Bounds().Dy()), 10, 140)
 
 	// Debug: Show button press states and touch zones
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Pressed: L:%t R:%t P:%t Override:%t",
@@ -245,7 +534,7 @@ func (mc *MobileControls) Draw(screen *ebiten.Image, isPaused bool) {
 	// Debug: Show if any touches are in button areas
 	if len(touchIDs) > 0 {
 		touchID := touchIDs[0]
-		x, y := ebiten.TouchPosition(touchID)
+		x, y := mc.touchPosition(touchID)
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Touch in L:%t R:%t P:%t",
 			mc.leftButton.Contains(x, y), mc.rightButton.Contains(x, y), mc.pauseButton.Contains(x, y)), 10, 180)
 	}
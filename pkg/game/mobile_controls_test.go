@@ -0,0 +1,136 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSwipeTurn(t *testing.T) {
+	tests := []struct {
+		name     string
+		dx       int
+		expected float64
+	}{
+		{"Finger resting", 0, 0},
+		{"Inside dead zone", swipeDeadZone - 1, 0},
+		{"Half right", swipeFullTurn / 2, 0.5},
+		{"Half left", -swipeFullTurn / 2, -0.5},
+		{"Full right", swipeFullTurn, 1},
+		{"Past full left clamped", -3 * swipeFullTurn, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := swipeTurn(tt.dx); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("swipeTurn(%d) = %.3f, expected %.3f", tt.dx, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCombineInput_SwipeSteersProportionally(t *testing.T) {
+	if turn := combineInput(false, false, MobileInput{Turn: 0.3}, GamepadInput{}).Turn; math.Abs(turn-0.3) > 1e-9 {
+		t.Errorf("Expected a gentle swipe to give a gentle turn, got %.2f", turn)
+	}
+	// The buttons still work alongside, and the total is clamped
+	if turn := combineInput(false, false, MobileInput{Turn: 0.6, TurnRight: true}, GamepadInput{}).Turn; turn != 1 {
+		t.Errorf("Expected swipe and button together clamped to full helm, got %.2f", turn)
+	}
+}
+
+func TestControlLayout_PlacesButtons(t *testing.T) {
+	mc := NewMobileControls(ScreenWidth, ScreenHeight)
+	if mc.leftButton.X != 20 || mc.rightButton.X != ScreenWidth-100 {
+		t.Errorf("Expected the default buttons in the bottom corners, left at %d right at %d", mc.leftButton.X, mc.rightButton.X)
+	}
+
+	// Large buttons for a tablet, both under the right thumb
+	mc.SetLayout(ControlLayout{ButtonSize: 110, Margin: 30, Placement: PlacementRightHanded})
+	if mc.rightButton.X != ScreenWidth-140 || mc.leftButton.X != ScreenWidth-280 {
+		t.Errorf("Expected both buttons bottom-right, left at %d right at %d", mc.leftButton.X, mc.rightButton.X)
+	}
+	if mc.leftButton.Width != 110 || mc.leftButton.Y != ScreenHeight-140 {
+		t.Errorf("Expected 110px buttons 30px from the bottom, got %d wide at y=%d", mc.leftButton.Width, mc.leftButton.Y)
+	}
+
+	// Left-handed keeps them bottom-left, and survives a resize
+	mc.SetLayout(ControlLayout{ButtonSize: 80, Margin: 20, Placement: PlacementLeftHanded})
+	mc.Layout(375, 812)
+	if mc.leftButton.X != 20 || mc.rightButton.X != 120 || mc.rightButton.Y != 812-100 {
+		t.Errorf("Expected both buttons bottom-left, left at %d right at %d,%d", mc.leftButton.X, mc.rightButton.X, mc.rightButton.Y)
+	}
+}
+
+func TestControlLayout_NoButtonsOverlap(t *testing.T) {
+	screens := []struct{ width, height int }{
+		{375, 812}, // Portrait phone
+		{812, 375}, // The same phone in landscape
+		{ScreenWidth, ScreenHeight},
+	}
+	for _, screen := range screens {
+		for _, layout := range controlLayouts {
+			mc := NewMobileControls(screen.width, screen.height)
+			mc.SetLayout(layout)
+			zones := map[string]TouchZone{
+				"left":    mc.leftButton,
+				"right":   mc.rightButton,
+				"pause":   mc.pauseButton,
+				"restart": mc.restartButton,
+				"layout":  mc.layoutButton,
+			}
+			for name, zone := range zones {
+				for otherName, other := range zones {
+					if name < otherName && zone.Intersects(other) {
+						t.Errorf("%dx%d %s: %s button %+v overlaps %s button %+v",
+							screen.width, screen.height, layout, name, zone, otherName, other)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMobileControls_TouchesInLogicalPixels(t *testing.T) {
+	mc := NewMobileControls(ScreenWidth, ScreenHeight)
+	right := mc.rightButton
+
+	// Supersampled, a touch on the middle of the right button arrives at twice the coordinates
+	mc.inputScale = 2
+	x, y := mc.toLogical(2*(right.X+right.Width/2), 2*(right.Y+right.Height/2))
+	if !mc.rightButton.Contains(x, y) {
+		t.Errorf("Expected a supersampled touch at the right button's center to hit it, got %d,%d", x, y)
+	}
+
+	mc.inputScale = 1
+	if x, y := mc.toLogical(right.X, right.Y); x != right.X || y != right.Y {
+		t.Errorf("Expected touches unchanged at native resolution, got %d,%d", x, y)
+	}
+}
+
+func TestControlLayout_CyclesAndPersists(t *testing.T) {
+	seen := map[ControlLayout]bool{}
+	layout := DefaultControlLayout()
+	for i := 0; i < len(controlLayouts); i++ {
+		seen[layout] = true
+		layout = layout.Next()
+	}
+	if len(seen) != len(controlLayouts) || layout != DefaultControlLayout() {
+		t.Errorf("Expected to cycle through all %d layouts back to the default, saw %d", len(controlLayouts), len(seen))
+	}
+
+	g := createTestGame()
+	g.mobileControls = NewMobileControls(ScreenWidth, ScreenHeight)
+	g.settings = DefaultSettings()
+	g.store = newMemoryStore()
+	g.cycleControlLayout()
+
+	if g.settings.Controls != controlLayouts[1] {
+		t.Errorf("Expected the next layout, got %+v", g.settings.Controls)
+	}
+	if g.mobileControls.leftButton.Width != controlLayouts[1].ButtonSize {
+		t.Errorf("Expected the buttons resized to %d, got %d", controlLayouts[1].ButtonSize, g.mobileControls.leftButton.Width)
+	}
+	if saved := LoadControlLayout(g.store); saved != controlLayouts[1] {
+		t.Errorf("Expected the layout saved for the next session, got %+v", saved)
+	}
+}
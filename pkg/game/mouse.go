@@ -0,0 +1,37 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Mouse steering: full helm toward the cursor until the bow is within mouseSteerBand degrees
+// of it, then easing off so the boat settles on the bearing instead of swinging past it
+const (
+	mouseSteerBand     = 15.0
+	mouseSteerDeadZone = 1.0  // Degrees off the bearing treated as on it
+	mouseMinDistance   = 20.0 // Cursor this close to the boat (pixels) gives no bearing
+)
+
+// mouseSteer returns the helm (-1 full left to 1 full right) that turns a boat at the given
+// screen position and heading toward the cursor
+func mouseSteer(heading float64, boat, cursor geometry.Point) float64 {
+	if distance(boat, cursor) < mouseMinDistance {
+		return 0
+	}
+	off := geometry.AngleDiff(geometry.Bearing(boat, cursor), heading)
+	if math.Abs(off) < mouseSteerDeadZone {
+		return 0
+	}
+	return math.Max(-1, math.Min(1, off/mouseSteerBand))
+}
+
+// mouseTurn returns the helm toward the mouse cursor, which is in the same render pixels as
+// the world view
+func (g *GameState) mouseTurn() float64 {
+	x, y := g.worldView().ToScreen(g.Boat.Pos.X, g.Boat.Pos.Y)
+	cx, cy := ebiten.CursorPosition()
+	return mouseSteer(g.Boat.Heading, geometry.Point{X: x, Y: y}, geometry.Point{X: float64(cx), Y: float64(cy)})
+}
@@ -0,0 +1,33 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestMouseSteer_TurnsTowardCursor(t *testing.T) {
+	boat := geometry.Point{X: 640, Y: 360}
+
+	tests := []struct {
+		name    string
+		heading float64
+		cursor  geometry.Point
+		want    float64
+	}{
+		{"Cursor dead ahead", 0, geometry.Point{X: 640, Y: 100}, 0},
+		{"Cursor to starboard", 0, geometry.Point{X: 900, Y: 360}, 1},
+		{"Cursor to port", 0, geometry.Point{X: 380, Y: 360}, -1},
+		{"Cursor astern to port turns the short way", 90, geometry.Point{X: 640, Y: 100}, -1},
+		{"Nearly on the bearing eases the helm", 0, geometry.Point{X: 640 + 100*0.1317, Y: 260}, 0.5},
+		{"Cursor on the boat", 0, geometry.Point{X: 645, Y: 355}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mouseSteer(tt.heading, boat, tt.cursor)
+			if d := got - tt.want; d < -0.01 || d > 0.01 {
+				t.Errorf("mouseSteer() = %.2f, expected %.2f", got, tt.want)
+			}
+		})
+	}
+}
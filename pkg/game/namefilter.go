@@ -0,0 +1,62 @@
+package game
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// NameFilter rejects player names containing a blocked word, so names that go onto a
+// shared leaderboard stay printable. Matching is case-insensitive and by substring.
+type NameFilter struct {
+	words []string
+}
+
+// Built-in blocklist, used unless one is loaded with -name-blocklist
+const defaultBlocklist = `# One word per line; names containing any of them are rejected
+fuck
+shit
+cunt
+bitch
+asshole
+wanker
+nazi
+`
+
+// LoadNameFilter reads a blocklist with one word per line. Blank lines and lines
+// starting with # are skipped.
+func LoadNameFilter(r io.Reader) (*NameFilter, error) {
+	filter := &NameFilter{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		filter.words = append(filter.words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// defaultNameFilter returns the filter for the built-in blocklist
+func defaultNameFilter() *NameFilter {
+	filter, _ := LoadNameFilter(strings.NewReader(defaultBlocklist))
+	return filter
+}
+
+// Blocked reports whether the name contains a blocked word. A nil filter blocks nothing.
+func (f *NameFilter) Blocked(name string) bool {
+	if f == nil {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, word := range f.words {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNameFilter_Blocked(t *testing.T) {
+	filter, err := LoadNameFilter(strings.NewReader("# sample list\nbadword\n\n  Rude  \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		blocked bool
+	}{
+		{"Skipper", false},
+		{"badword", true},
+		{"The BadWord Sailor", true}, // Case-insensitive
+		{"xxrudexx", true},           // Substring
+		{"sample list", false},       // Comments aren't words
+		{"Ru de", false},
+	}
+	for _, tt := range tests {
+		if got := filter.Blocked(tt.name); got != tt.blocked {
+			t.Errorf("Blocked(%q) = %v, want %v", tt.name, got, tt.blocked)
+		}
+	}
+
+	var none *NameFilter
+	if none.Blocked("badword") {
+		t.Error("Expected a nil filter to allow any name")
+	}
+}
+
+func TestNameFilter_RejectsSubmission(t *testing.T) {
+	filter, _ := LoadNameFilter(strings.NewReader("badword\n"))
+	backend := &fakeBackend{}
+	sb := NewScoreboard(backend)
+	sb.nameFilter = filter
+
+	sb.Show(&RaceResult{RaceTimeSeconds: 100, MarkRounded: true})
+	sb.playerName = "  BadWord99 "
+	sb.submitScore()
+	if len(backend.results) != 0 || sb.submitError == "" || sb.state != StateEnterName {
+		t.Fatalf("Expected a blocked name to be rejected on name entry, got %d results and %q",
+			len(backend.results), sb.submitError)
+	}
+
+	// A different name goes through
+	sb.playerName = "Skipper"
+	sb.submitScore()
+	if len(backend.results) != 1 || sb.submitError != "" {
+		t.Errorf("Expected an allowed name to be submitted, got %d results and %q", len(backend.results), sb.submitError)
+	}
+}
+
+func TestNameFilter_DefaultList(t *testing.T) {
+	filter := defaultNameFilter()
+	if len(filter.words) == 0 {
+		t.Fatal("Expected a built-in blocklist")
+	}
+	if filter.Blocked("Skipper") {
+		t.Error("Expected an ordinary name to pass the built-in list")
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"bytes"
+	"image/color"
+	"log"
+	"sync"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Player names can be typed in any script, but the debug font only has Latin-1 glyphs.
+// Names with other letters are drawn with M+ 1p, which covers Greek, Cyrillic and
+// Japanese kana and kanji, at the debug font's 16px line height.
+const nameFontSize = 12
+
+var (
+	nameFaceOnce   sync.Once
+	nameFaceSource *text.GoTextFaceSource // nil if the font failed to load
+)
+
+// nameFace returns the face player names outside Latin-1 are drawn with
+func nameFace() *text.GoTextFace {
+	nameFaceOnce.Do(func() {
+		source, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+		if err != nil {
+			log.Printf("Failed to load the name font: %v", err)
+			return
+		}
+		nameFaceSource = source
+	})
+	if nameFaceSource == nil {
+		return nil
+	}
+	return &text.GoTextFace{Source: nameFaceSource, Size: nameFontSize}
+}
+
+// debugFontCanDraw reports whether every character of s has a glyph in the debug font
+func debugFontCanDraw(s string) bool {
+	for _, ch := range s {
+		if ch > unicode.MaxLatin1 {
+			return false
+		}
+	}
+	return true
+}
+
+// drawName draws a player name at x, y like DebugPrintAt, switching to the name font
+// when the debug font can't show all of it
+func drawName(screen *ebiten.Image, name string, x, y int) {
+	face := nameFace()
+	if debugFontCanDraw(name) || face == nil {
+		ebitenutil.DebugPrintAt(screen, name, x, y)
+		return
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(color.White)
+	text.Draw(screen, name, face, op)
+}
@@ -1,12 +1,14 @@
 package objects
 
 import (
+	"image"
 	"image/color"
 	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/mpihlak/gosailing2/pkg/game/world"
 	"github.com/mpihlak/gosailing2/pkg/geometry"
 	"github.com/mpihlak/gosailing2/pkg/polars"
@@ -15,12 +17,49 @@ import (
 const (
 	maxHistoryPoints = 50
 	historyInterval  = 200 * time.Millisecond
-	boatHeight       = 15.0       // Triangle height
-	boatWidth        = 7.5        // Triangle width
 	speedScale       = 30.0 / 6.0 // Pixels per second per knot (10 pixels/sec at 6 knots)
-	boatMass         = 4000.0     // Boat mass in kg
-	dragCoefficient  = 0.02       // Water resistance coefficient (reduced for more gradual deceleration)
 	BoatRadius       = 5.0        // Collision radius in meters
+	// Heel and broaching
+	heelFactor           = 0.12  // Heel degrees per knot² of wind on a beam reach
+	maxHeel              = 45.0  // Heel angle cap in degrees
+	BroachHeelThreshold  = 25.0  // Heel angle above which a broach can occur
+	broachMinTWA         = 100.0 // Broaching only happens at broad angles
+	broachMaxTWA         = 160.0
+	broachDuration       = 90   // Frames out of control (1.5 s at 60 FPS)
+	broachCooldownFrames = 180  // Frames after recovery before another broach can occur (3 s)
+	broachRoundUpRate    = 0.5  // Degrees per frame the boat rounds up toward the wind
+	broachSpeedLoss      = 0.97 // Velocity multiplier per frame while broaching
+	// In irons: stalled head to wind until the boat bears away
+	noGoAngle      = 30.0 // TWA in degrees inside which the sails can't draw
+	inIronsSpeed   = 1.0  // Speed in knots below which a boat inside the no-go angle stalls
+	ironsSpeedLoss = 0.98 // Velocity multiplier per frame while in irons
+	// Wake
+	wakeWidthPerKnot  = 4.0  // Wake spread in meters per knot of boat speed
+	maxWakeWidth      = 40.0 // Wake spread cap in meters
+	wakeLengthPerKnot = 5.0  // Length of each wake arm in meters per knot of boat speed
+	maxWakeLength     = 60.0 // Wake arm length cap in meters
+	maxWakeAlpha      = 90   // Wake opacity at full width (kept subtle)
+	wakeSegments      = 4    // Pieces per wake arm, each fainter than the one before
+	// Leeway: sideways slip when close-hauled, larger at low speed (scaled by BoatClass.Leeway)
+	maxLeeway      = 10.0 // Leeway cap in degrees (stalled boats)
+	minLeewaySpeed = 1.0  // Speeds below this (knots) are treated as this when computing leeway
+	// OCS hull flash period
+	ocsFlashInterval = 250 * time.Millisecond
+	// How much wider the leeward side of the hull is drawn at full heel, to suggest the deck
+	// tilting toward the viewer
+	heelWidening = 0.8
+	// Cap on how quickly very light boats accelerate toward the polar speed
+	maxAccelerationFactor = 0.05
+	// Simulation steps per second of game time
+	stepsPerSecond = 60
+	// Helm: seconds for the rate of turn to build to full helm, and to ease off once released
+	helmRampTime    = 0.25
+	helmReleaseTime = 0.15
+	// Bouncing off solid objects
+	bounceRestitution = 0.3 // Fraction of the speed into the obstacle that comes back out
+	bounceSpeedLoss   = 0.5 // Fraction of the remaining speed lost in a head-on hit
+	// Velocity multiplier per frame at the very edge of the shallows, easing to none in open water
+	shallowsSpeedLoss = 0.96
 )
 
 type Boat struct {
@@ -29,15 +68,112 @@ type Boat struct {
 	Speed       float64        // in knots (current polar speed)
 	VelX, VelY  float64        // Actual velocity in pixels/frame
 	History     []geometry.Point
-	lastHistory time.Time
-	Polars      polars.Polars // Polar performance data
-	Wind        world.Wind    // Wind interface to get wind conditions
+	historyStep int
+	Class       *BoatClass      // Physical handling: mass, drag, size, turn rate (nil = Keelboat)
+	Polars      polars.Polars   // Polar performance data
+	Wind        world.Wind      // Wind interface to get wind conditions
+	Current     world.Current   // Tidal current that carries the boat (nil = slack water)
+	Boundary    *world.Boundary // Edge of the sailing area (nil = open water without limits)
+	SOG         float64         // Speed over ground in knots (Speed is through the water)
+	Leeway      float64         // Sideways slip in degrees between heading and course through the water
+	COG         float64         // Course over ground in degrees, including leeway and current
+	// Heel and broaching
+	Heel           float64     // Heel angle in degrees from wind pressure on the sails
+	heelSide       float64     // Side the boat heels to: 1 = starboard, -1 = port
+	BroachEnabled  bool        // Whether overpowering at broad angles causes a broach (dinghies)
+	broachFrames   int         // Frames remaining in the current broach (0 = in control)
+	broachCooldown int         // Frames before another broach can occur
+	inIrons        bool        // Stalled head to wind; no drive until TWA opens past noGoAngle
+	DrawWake       bool        // Whether to draw the V-shaped wake behind the boat
+	OCS            bool        // On course side before the start; the hull flashes red
+	SpinnakerUp    bool        // Flying the spinnaker: faster deep downwind, slower when too high or too low
+	Color          color.Color // Hull color (nil = white)
+	// Steering
+	RateOfTurn float64 // Degrees per second the boat is turning (positive = clockwise)
+
+	// Game time between trail points (0 = 200ms). Counted in simulation steps (historyStep)
+	// rather than wall clock, so the trail stays evenly spaced in slow motion or at a low frame rate.
+	HistoryInterval time.Duration
+}
+
+// Knots converts a speed in meters per second of game time to knots, the way the boat's
+// physics does
+func Knots(metersPerSecond float64) float64 {
+	return metersPerSecond / speedScale
+}
+
+// MetersPerSecond converts a speed in knots to meters per second of game time, the way
+// the boat's physics does
+func MetersPerSecond(knots float64) float64 {
+	return knots * speedScale
+}
+
+// class returns the boat's class, defaulting to a keelboat
+func (b *Boat) class() *BoatClass {
+	if b.Class == nil {
+		return &Keelboat
+	}
+	return b.Class
+}
+
+// HullLength returns the length of the boat's hull in meters
+func (b *Boat) HullLength() float64 {
+	return b.class().Length
+}
+
+// TurnRate returns how many degrees per frame the boat turns at full helm
+func (b *Boat) TurnRate() float64 {
+	return b.class().TurnRate
+}
+
+// Steer moves the helm toward turn (-1 = full port, 1 = full starboard, 0 = centered) for one
+// simulation step and turns the boat at the resulting rate. The rate of turn builds up while
+// the helm is held over and eases off when released, never exceeding the class's full-helm rate.
+func (b *Boat) Steer(turn float64) {
+	dt := 1.0 / stepsPerSecond
+	maxRate := b.TurnRate() * stepsPerSecond
+	target := math.Max(-1, math.Min(1, turn)) * maxRate
+
+	ramp := maxRate / helmRampTime * dt
+	if target == 0 {
+		ramp = maxRate / helmReleaseTime * dt
+	}
+	b.RateOfTurn += math.Max(-ramp, math.Min(ramp, target-b.RateOfTurn))
+
+	b.Heading = math.Mod(b.Heading+b.RateOfTurn*dt+360, 360)
+}
+
+// trueWindAngle returns the signed TWA at the boat (positive = wind over the port side)
+func (b *Boat) trueWindAngle() float64 {
+	windDir, _ := b.Wind.GetWind(b.Pos)
+	return math.Mod(b.Heading-windDir+540, 360) - 180
+}
+
+// ToggleSpinnaker hoists the spinnaker when sailing at least SpinnakerMinTWA off the wind,
+// or drops it. Returns whether the kite is up afterwards.
+func (b *Boat) ToggleSpinnaker() bool {
+	if b.SpinnakerUp {
+		b.SpinnakerUp = false
+	} else if math.Abs(b.trueWindAngle()) >= polars.SpinnakerMinTWA {
+		b.SpinnakerUp = true
+	}
+	return b.SpinnakerUp
+}
+
+// PolarSpeed returns the polar target speed (knots) for a TWA and TWS with the sails the
+// boat is flying
+func (b *Boat) PolarSpeed(twa, tws float64) float64 {
+	speed := b.Polars.GetBoatSpeed(twa, tws)
+	if b.SpinnakerUp {
+		speed *= polars.SpinnakerFactor(twa)
+	}
+	return speed
 }
 
 // GetBowPosition returns the position of the boat's bow (front tip)
 func (b *Boat) GetBowPosition() geometry.Point {
 	headingRad := b.Heading * math.Pi / 180
-	bowDistance := boatHeight / 2
+	bowDistance := b.class().Length / 2
 
 	return geometry.Point{
 		X: b.Pos.X + bowDistance*math.Sin(headingRad),
@@ -45,6 +181,71 @@ func (b *Boat) GetBowPosition() geometry.Point {
 	}
 }
 
+// IsBroaching returns whether the boat is currently rounding up out of control
+func (b *Boat) IsBroaching() bool {
+	return b.broachFrames > 0
+}
+
+// updateHeel estimates the heel angle from wind pressure, strongest on a beam reach
+func (b *Boat) updateHeel(twa, windSpeed float64) {
+	twaRad := twa * math.Pi / 180
+	b.Heel = math.Min(maxHeel, heelFactor*windSpeed*windSpeed*math.Abs(math.Sin(twaRad)))
+	b.heelSide = 1 // Wind on the port side heels the boat to starboard
+	if twa < 0 {
+		b.heelSide = -1
+	}
+}
+
+// IsInIrons returns whether the boat is stalled head to wind and must bear away to sail again
+func (b *Boat) IsInIrons() bool {
+	return b.inIrons
+}
+
+// updateIrons stalls a slow boat pointing inside the no-go angle, and frees it once it
+// bears away past it
+func (b *Boat) updateIrons(twa float64) {
+	if math.Abs(twa) >= noGoAngle {
+		b.inIrons = false
+	} else if b.Speed < inIronsSpeed {
+		b.inIrons = true
+	}
+}
+
+// updateBroach starts a broach when overpowered at broad angles and rounds the boat up
+// toward the wind while it lasts. Returns true while the boat is out of control.
+func (b *Boat) updateBroach(twa float64) bool {
+	if b.broachCooldown > 0 {
+		b.broachCooldown--
+	}
+
+	if b.broachFrames == 0 {
+		absTWA := math.Abs(twa)
+		overpowered := b.Heel > BroachHeelThreshold && absTWA >= broachMinTWA && absTWA <= broachMaxTWA
+		if !b.BroachEnabled || !overpowered || b.broachCooldown > 0 {
+			return false
+		}
+		b.broachFrames = broachDuration
+	}
+
+	// Round up toward the wind
+	if twa > 0 {
+		b.Heading -= broachRoundUpRate
+	} else {
+		b.Heading += broachRoundUpRate
+	}
+	if b.Heading < 0 {
+		b.Heading += 360
+	} else if b.Heading >= 360 {
+		b.Heading -= 360
+	}
+
+	b.broachFrames--
+	if b.broachFrames == 0 {
+		b.broachCooldown = broachCooldownFrames
+	}
+	return true
+}
+
 func (b *Boat) Update() {
 	// Get wind conditions at boat position
 	windDir, windSpeed := b.Wind.GetWind(b.Pos)
@@ -65,43 +266,62 @@ func (b *Boat) Update() {
 		twa -= 360
 	}
 
+	// Heel from wind pressure, and loss of control when overpowered
+	b.updateHeel(twa, windSpeed)
+	broaching := b.updateBroach(twa)
+	b.updateIrons(twa)
+
 	// Get target speed from polars
-	targetSpeed := b.Polars.GetBoatSpeed(twa, windSpeed)
+	targetSpeed := b.PolarSpeed(twa, windSpeed)
 	// Validate target speed
 	if math.IsNaN(targetSpeed) || math.IsInf(targetSpeed, 0) || targetSpeed < 0 {
 		targetSpeed = 0.0
 	}
+	// In irons the sails just flog: no drive at all until the boat bears away
+	if b.inIrons {
+		targetSpeed = 0.0
+	}
 
-	// Convert target speed to target velocity in heading direction
-	headingRad := b.Heading * math.Pi / 180
+	// The boat slips to leeward, so it moves along its course rather than its heading
+	b.Leeway = leewayAngle(twa, b.Speed, b.class().Leeway)
+	course := b.Heading
+	if twa > 0 {
+		course += b.Leeway // Wind on the port side pushes the boat to starboard
+	} else {
+		course -= b.Leeway
+	}
+
+	// Convert target speed to target velocity in the course direction
+	courseRad := course * math.Pi / 180
 	targetPixelSpeed := targetSpeed * speedScale / 60.0
-	targetVelX := targetPixelSpeed * math.Sin(headingRad)
-	targetVelY := -targetPixelSpeed * math.Cos(headingRad) // Y inverted
+	targetVelX := targetPixelSpeed * math.Sin(courseRad)
+	targetVelY := -targetPixelSpeed * math.Cos(courseRad) // Y inverted
 
 	// Calculate current velocity magnitude
 	currentSpeed := math.Sqrt(b.VelX*b.VelX + b.VelY*b.VelY)
 
-	// Project current velocity onto the heading direction to maintain forward momentum
+	// Project current velocity onto the course direction to maintain forward momentum
 	if currentSpeed > 0.01 {
-		// Calculate the component of current velocity in the heading direction
-		currentHeadingVelX := math.Sin(headingRad)
-		currentHeadingVelY := -math.Cos(headingRad)
+		// Calculate the component of current velocity in the course direction
+		currentHeadingVelX := math.Sin(courseRad)
+		currentHeadingVelY := -math.Cos(courseRad)
 
-		// Dot product to get the magnitude of velocity in heading direction
+		// Dot product to get the magnitude of velocity in course direction
 		forwardSpeed := b.VelX*currentHeadingVelX + b.VelY*currentHeadingVelY
 
-		// Keep the forward momentum but gradually align with heading
-		alignmentFactor := 0.05 // How quickly the boat aligns velocity with heading
+		// Keep the forward momentum but gradually align with the course
+		alignmentFactor := 0.05 // How quickly the boat aligns velocity with the course
 		b.VelX = b.VelX*(1-alignmentFactor) + forwardSpeed*currentHeadingVelX*alignmentFactor
 		b.VelY = b.VelY*(1-alignmentFactor) + forwardSpeed*currentHeadingVelY*alignmentFactor
 	}
 
 	// Apply drag force (proportional to velocity squared)
 	currentSpeed = math.Sqrt(b.VelX*b.VelX + b.VelY*b.VelY)
-	dragForce := dragCoefficient * currentSpeed * currentSpeed
+	class := b.class()
+	dragForce := class.DragCoefficient * currentSpeed * currentSpeed
 
 	// Calculate drag acceleration (F = ma, so a = F/m)
-	dragAccel := dragForce / boatMass * 10 // Reduced scale factor for slower deceleration (was 20)
+	dragAccel := dragForce / class.Mass * 10 // Reduced scale factor for slower deceleration (was 20)
 
 	// Apply drag in opposite direction of movement
 	if currentSpeed > 0.01 { // Avoid division by zero
@@ -114,9 +334,21 @@ func (b *Boat) Update() {
 	// Apply force towards target velocity (wind power)
 	// This simulates the boat's ability to accelerate towards the polar speed
 	accelerationFactor := 0.01 // Reduced for slower acceleration (was 0.02)
+	// Lighter boats get up to speed sooner than the keelboat
+	accelerationFactor = math.Min(maxAccelerationFactor, accelerationFactor*math.Sqrt(Keelboat.Mass/class.Mass))
 	b.VelX += (targetVelX - b.VelX) * accelerationFactor
 	b.VelY += (targetVelY - b.VelY) * accelerationFactor
 
+	// A broaching boat stalls and loses speed
+	if broaching {
+		b.VelX *= broachSpeedLoss
+		b.VelY *= broachSpeedLoss
+	}
+	if b.inIrons {
+		b.VelX *= ironsSpeedLoss
+		b.VelY *= ironsSpeedLoss
+	}
+
 	// Move boat using actual velocity
 	b.Pos.X += b.VelX
 	b.Pos.Y += b.VelY
@@ -131,10 +363,26 @@ func (b *Boat) Update() {
 	}
 	b.Speed = currentPixelSpeed * 60.0 / speedScale // Convert back to knots
 
-	// Add to history
-	if time.Since(b.lastHistory) >= historyInterval {
+	// The current carries the boat regardless of heading, even when it's stopped
+	driftX, driftY := b.currentDrift()
+	b.Pos.X += driftX
+	b.Pos.Y += driftY
+	b.keepInBounds()
+	b.SOG = math.Hypot(b.VelX+driftX, b.VelY+driftY) * 60.0 / speedScale
+	b.COG = b.Heading
+	if b.SOG > 0.01 {
+		b.COG = math.Mod(math.Atan2(b.VelX+driftX, -(b.VelY+driftY))*180/math.Pi+360, 360)
+	}
+
+	// Add to history every interval of game time
+	interval := b.HistoryInterval
+	if interval <= 0 {
+		interval = historyInterval
+	}
+	b.historyStep++
+	if b.historyStep >= max(1, int(math.Round(interval.Seconds()*stepsPerSecond))) {
 		b.History = append(b.History, b.Pos)
-		b.lastHistory = time.Now()
+		b.historyStep = 0
 
 		// Cap history at maxHistoryPoints
 		if len(b.History) > maxHistoryPoints {
@@ -143,7 +391,100 @@ func (b *Boat) Update() {
 	}
 }
 
-func (b *Boat) Draw(screen *ebiten.Image) {
+// leewayAngle returns the leeway in degrees for a true wind angle and boat speed (knots),
+// given the class's leeway at 1 knot head to wind. It is largest close-hauled and at low
+// speed, and fades to zero on a beam reach and downwind.
+func leewayAngle(twa, speed, coefficient float64) float64 {
+	upwind := math.Cos(twa * math.Pi / 180)
+	if upwind <= 0 {
+		return 0
+	}
+	return math.Min(maxLeeway, coefficient*upwind/math.Max(speed, minLeewaySpeed))
+}
+
+// Bounce pushes the boat out of a solid round obstacle of the given radius and reflects
+// its velocity off the contact normal, losing more speed the more head-on the hit.
+// Returns whether the boat was touching the obstacle.
+func (b *Boat) Bounce(center geometry.Point, radius float64) bool {
+	dx, dy := b.Pos.X-center.X, b.Pos.Y-center.Y
+	distance := math.Hypot(dx, dy)
+	contact := BoatRadius + radius
+	if distance >= contact {
+		return false
+	}
+
+	// Contact normal points from the obstacle to the boat (straight back if dead center)
+	nx, ny := dx/distance, dy/distance
+	if distance == 0 {
+		headingRad := b.Heading * math.Pi / 180
+		nx, ny = -math.Sin(headingRad), math.Cos(headingRad)
+	}
+	b.Pos = geometry.Point{X: center.X + nx*contact, Y: center.Y + ny*contact}
+
+	// Reflect the part of the velocity heading into the obstacle
+	speed := math.Hypot(b.VelX, b.VelY)
+	into := b.VelX*nx + b.VelY*ny
+	if into >= 0 || speed == 0 {
+		return true
+	}
+	b.VelX -= (1 + bounceRestitution) * into * nx
+	b.VelY -= (1 + bounceRestitution) * into * ny
+
+	// The harder the impact, the more way the boat loses
+	impact := -into / speed
+	b.VelX *= 1 - bounceSpeedLoss*impact
+	b.VelY *= 1 - bounceSpeedLoss*impact
+	b.Speed = math.Hypot(b.VelX, b.VelY) * 60.0 / speedScale
+	return true
+}
+
+// keepInBounds slows the boat in the shallows and stops it at the edge of the sailing area:
+// a wall bounces it back, the shallows run it aground. Only the motion out of the area is
+// stopped, so the boat can still sail along the edge.
+func (b *Boat) keepInBounds() {
+	if b.Boundary == nil {
+		return
+	}
+	if depth := b.Boundary.ShallowsDepth(b.Pos); depth > 0 {
+		loss := 1 - (1-shallowsSpeedLoss)*depth
+		b.VelX *= loss
+		b.VelY *= loss
+	}
+
+	pos, nx, ny := b.Boundary.Clamp(b.Pos)
+	if nx == 0 && ny == 0 {
+		return
+	}
+	b.Pos = pos
+	restitution := 0.0
+	if b.Boundary.Mode == world.BoundaryWall {
+		restitution = bounceRestitution
+	}
+	if b.VelX*nx > 0 {
+		b.VelX = -b.VelX * restitution
+	}
+	if b.VelY*ny > 0 {
+		b.VelY = -b.VelY * restitution
+	}
+}
+
+// currentDrift returns the current's set and drift at the boat as a velocity in pixels/frame
+func (b *Boat) currentDrift() (float64, float64) {
+	if b.Current == nil {
+		return 0, 0
+	}
+	direction, speed := b.Current.GetCurrent(b.Pos)
+	if math.IsNaN(direction) || math.IsNaN(speed) || math.IsInf(speed, 0) {
+		return 0, 0
+	}
+
+	dirRad := direction * math.Pi / 180
+	pixelSpeed := speed * speedScale / 60.0
+	return pixelSpeed * math.Sin(dirRad), -pixelSpeed * math.Cos(dirRad) // Y inverted
+}
+
+// Draw renders the boat and its trail, mapping world coordinates through view
+func (b *Boat) Draw(screen *ebiten.Image, view world.View) {
 	// Draw boat history (skip the last 2 points to avoid overlap with boat)
 	historyToShow := len(b.History) - 1
 	if historyToShow < 0 {
@@ -151,39 +492,148 @@ func (b *Boat) Draw(screen *ebiten.Image) {
 	}
 
 	for i := 0; i < historyToShow; i++ {
-		p := b.History[i]
-		ebitenutil.DrawCircle(screen, p.X, p.Y, 2, color.RGBA{173, 216, 230, 150})
+		x, y := view.ToScreen(b.History[i].X, b.History[i].Y)
+		ebitenutil.DrawCircle(screen, x, y, view.Length(2), color.RGBA{173, 216, 230, 150})
 	}
 
-	// Draw boat as triangle pointing towards heading
-	headingRad := b.Heading * math.Pi / 180
+	if b.DrawWake {
+		b.drawWake(screen, view)
+	}
 
-	// Triangle dimensions
-	height := boatHeight
-	width := boatWidth
+	// Draw boat as a filled triangle pointing towards heading
+	var path vector.Path
+	for i, p := range b.hullPolygon() {
+		x, y := view.ToScreen(p.X, p.Y)
+		if i == 0 {
+			path.MoveTo(float32(x), float32(y))
+		} else {
+			path.LineTo(float32(x), float32(y))
+		}
+	}
+	path.Close()
+
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	r, g, bl, a := b.hullColor(time.Now()).RGBA()
+	for i := range vertices {
+		vertices[i].SrcX, vertices[i].SrcY = 1, 1
+		vertices[i].ColorR = float32(r) / 0xffff
+		vertices[i].ColorG = float32(g) / 0xffff
+		vertices[i].ColorB = float32(bl) / 0xffff
+		vertices[i].ColorA = float32(a) / 0xffff
+	}
+	screen.DrawTriangles(vertices, indices, hullFillImage, &ebiten.DrawTrianglesOptions{AntiAlias: true})
+}
+
+// Solid source image for filling the hull; the vertex colors give it the hull color
+var hullFillImage = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// hullPolygon returns the world positions of the hull triangle: the bow, then the port and
+// starboard corners of the stern. The leeward side is drawn wider the more the boat heels,
+// so the hull looks tipped over on a reach.
+func (b *Boat) hullPolygon() [3]geometry.Point {
+	headingRad := b.Heading * math.Pi / 180
+	length, beam := b.class().Length, b.class().Beam
+	sin, cos := math.Sin(headingRad), math.Cos(headingRad)
 
-	// Calculate triangle vertices relative to boat center position
 	// Bow (tip) is forward from center, stern (base) is behind center
-	bowDistance := height / 2
-	sternDistance := height / 2
+	bow := geometry.Point{X: b.Pos.X + length/2*sin, Y: b.Pos.Y - length/2*cos}
+	stern := geometry.Point{X: b.Pos.X - length/2*sin, Y: b.Pos.Y + length/2*cos}
+
+	// Half the beam each side, with the leeward half widened by the heel
+	port, starboard := beam/2, beam/2
+	widen := 1 + heelWidening*b.Heel/maxHeel
+	if b.heelSide < 0 {
+		port *= widen
+	} else if b.heelSide > 0 {
+		starboard *= widen
+	}
 
-	// Bow position (front tip)
-	bowX := b.Pos.X + bowDistance*math.Sin(headingRad)
-	bowY := b.Pos.Y - bowDistance*math.Cos(headingRad)
+	return [3]geometry.Point{
+		bow,
+		{X: stern.X - port*cos, Y: stern.Y - port*sin},
+		{X: stern.X + starboard*cos, Y: stern.Y + starboard*sin},
+	}
+}
 
-	// Stern center position (back center)
-	sternX := b.Pos.X - sternDistance*math.Sin(headingRad)
-	sternY := b.Pos.Y + sternDistance*math.Cos(headingRad)
+// hullColor returns the hull color at time now: the boat's color normally, flashing between
+// bright and light red while the boat is OCS
+func (b *Boat) hullColor(now time.Time) color.Color {
+	if !b.OCS {
+		if b.Color != nil {
+			return b.Color
+		}
+		return color.White
+	}
+	if (now.UnixMilli()/ocsFlashInterval.Milliseconds())%2 == 0 {
+		return color.RGBA{255, 0, 0, 255}
+	}
+	return color.RGBA{255, 140, 140, 255}
+}
 
-	// Left and right stern points
-	leftX := sternX - (width/2)*math.Cos(headingRad)
-	leftY := sternY - (width/2)*math.Sin(headingRad)
+// WakeWidth returns the spread in meters between the ends of the wake arms at the given speed in knots
+func WakeWidth(speed float64) float64 {
+	if speed <= 0 {
+		return 0
+	}
+	return math.Min(speed*wakeWidthPerKnot, maxWakeWidth)
+}
+
+// WakeLength returns the length in meters of each wake arm at the given speed in knots
+func WakeLength(speed float64) float64 {
+	if speed <= 0 {
+		return 0
+	}
+	return math.Min(speed*wakeLengthPerKnot, maxWakeLength)
+}
 
-	rightX := sternX + (width/2)*math.Cos(headingRad)
-	rightY := sternY + (width/2)*math.Sin(headingRad)
+// wakeEnds returns the world positions of the ends of the two wake arms trailing from the stern
+func wakeEnds(stern geometry.Point, heading, speed float64) (left, right geometry.Point) {
+	headingRad := heading * math.Pi / 180
+	halfWidth := WakeWidth(speed) / 2
+	length := WakeLength(speed)
+
+	// Center of the wake's open end, directly astern
+	backX := stern.X - length*math.Sin(headingRad)
+	backY := stern.Y + length*math.Cos(headingRad)
+
+	// Spread perpendicular to the heading
+	left = geometry.Point{X: backX - halfWidth*math.Cos(headingRad), Y: backY - halfWidth*math.Sin(headingRad)}
+	right = geometry.Point{X: backX + halfWidth*math.Cos(headingRad), Y: backY + halfWidth*math.Sin(headingRad)}
+	return left, right
+}
 
-	// Draw triangle using lines
-	ebitenutil.DrawLine(screen, bowX, bowY, leftX, leftY, color.White)
-	ebitenutil.DrawLine(screen, leftX, leftY, rightX, rightY, color.White)
-	ebitenutil.DrawLine(screen, rightX, rightY, bowX, bowY, color.White)
+// drawWake draws a V-shaped wake from the stern whose length, width and opacity grow with
+// speed, fading out toward the open end
+func (b *Boat) drawWake(screen *ebiten.Image, view world.View) {
+	width := WakeWidth(b.Speed)
+	if width <= 0 {
+		return
+	}
+
+	headingRad := b.Heading * math.Pi / 180
+	stern := geometry.Point{
+		X: b.Pos.X - (b.class().Length/2)*math.Sin(headingRad),
+		Y: b.Pos.Y + (b.class().Length/2)*math.Cos(headingRad),
+	}
+	left, right := wakeEnds(stern, b.Heading, b.Speed)
+	sternX, sternY := view.ToScreen(stern.X, stern.Y)
+	leftX, leftY := view.ToScreen(left.X, left.Y)
+	rightX, rightY := view.ToScreen(right.X, right.Y)
+
+	strokeWidth := float32(view.Length(1.5))
+
+	for i := 0; i < wakeSegments; i++ {
+		from, to := float64(i)/wakeSegments, float64(i+1)/wakeSegments
+		alpha := uint8(maxWakeAlpha * width / maxWakeWidth * (1 - from))
+		wakeColor := color.RGBA{alpha, alpha, alpha, alpha} // Premultiplied white
+		for _, end := range [][2]float64{{leftX, leftY}, {rightX, rightY}} {
+			x0, y0 := sternX+(end[0]-sternX)*from, sternY+(end[1]-sternY)*from
+			x1, y1 := sternX+(end[0]-sternX)*to, sternY+(end[1]-sternY)*to
+			vector.StrokeLine(screen, float32(x0), float32(y0), float32(x1), float32(y1), strokeWidth, wakeColor, true)
+		}
+	}
 }
@@ -0,0 +1,600 @@
+package objects
+
+import (
+	"go/parser"
+	"go/token"
+	"image/color"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// createTestBoat creates a boat sailing at the given heading in constant wind from the north
+func createTestBoat(windSpeed, heading float64) *Boat {
+	return &Boat{
+		Pos:     geometry.Point{X: 1000, Y: 1000},
+		Heading: heading,
+		Polars:  &polars.RealisticPolar{},
+		Wind:    &world.ConstantWind{Direction: 0, Speed: windSpeed},
+	}
+}
+
+func TestBroach_TriggersAboveThreshold(t *testing.T) {
+	boat := createTestBoat(24, 135)
+	boat.BroachEnabled = true
+
+	boat.Update()
+
+	if boat.Heel <= BroachHeelThreshold {
+		t.Fatalf("Expected heel above %.1f° in 24 kts on a broad reach, got %.1f°", BroachHeelThreshold, boat.Heel)
+	}
+	if !boat.IsBroaching() {
+		t.Fatal("Expected boat to broach when overpowered on a broad reach")
+	}
+
+	// Boat should round up toward the wind (port tack, heading decreases)
+	if boat.Heading >= 135 {
+		t.Errorf("Expected boat to round up from 135°, heading is %.1f°", boat.Heading)
+	}
+}
+
+func TestBroach_NotTriggered(t *testing.T) {
+	tests := []struct {
+		name          string
+		windSpeed     float64
+		heading       float64
+		broachEnabled bool
+	}{
+		{"Keelboat in strong wind", 24, 135, false},
+		{"Dinghy in light wind", 8, 135, true},
+		{"Dinghy close-hauled in strong wind", 24, 45, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boat := createTestBoat(tt.windSpeed, tt.heading)
+			boat.BroachEnabled = tt.broachEnabled
+
+			boat.Update()
+
+			if boat.IsBroaching() {
+				t.Errorf("Expected no broach (heel %.1f°)", boat.Heel)
+			}
+		})
+	}
+}
+
+func TestBroach_RecoversAfterCooldown(t *testing.T) {
+	boat := createTestBoat(24, 135)
+	boat.BroachEnabled = true
+
+	boat.Update()
+	if !boat.IsBroaching() {
+		t.Fatal("Expected boat to broach")
+	}
+
+	// Broach lasts a fixed number of frames
+	for i := 1; i < broachDuration; i++ {
+		boat.Update()
+	}
+	if boat.IsBroaching() {
+		t.Fatalf("Expected boat to regain control after %d frames", broachDuration)
+	}
+
+	// Player bears away again - no new broach during the cooldown
+	for i := 0; i < broachCooldownFrames-1; i++ {
+		boat.Heading = 135
+		boat.Update()
+		if boat.IsBroaching() {
+			t.Fatalf("Expected no broach during cooldown, broached at frame %d", i)
+		}
+	}
+
+	// Still overpowered after the cooldown - broaches again
+	boat.Heading = 135
+	boat.Update()
+	if !boat.IsBroaching() {
+		t.Error("Expected boat to broach again once the cooldown has passed")
+	}
+}
+
+func TestWakeWidth_ScalesWithSpeed(t *testing.T) {
+	if w := WakeWidth(0); w != 0 {
+		t.Errorf("Expected no wake when stopped, got %.1f", w)
+	}
+
+	prev := 0.0
+	for _, speed := range []float64{1, 3, 5, 8} {
+		w := WakeWidth(speed)
+		if w <= prev {
+			t.Errorf("Expected wake to widen with speed: %.0f kts gave %.1f, previous %.1f", speed, w, prev)
+		}
+		prev = w
+	}
+
+	if w := WakeWidth(50); w != maxWakeWidth {
+		t.Errorf("Expected wake width capped at %.1f, got %.1f", maxWakeWidth, w)
+	}
+}
+
+func TestWakeLength_ScalesWithSpeed(t *testing.T) {
+	if l := WakeLength(0); l != 0 {
+		t.Errorf("Expected no wake when stopped, got %.1f", l)
+	}
+	if l := WakeLength(6); l != 30 {
+		t.Errorf("Expected a 30m wake at 6 kts, got %.1f", l)
+	}
+	if WakeLength(4) >= WakeLength(8) {
+		t.Errorf("Expected a longer wake at 8 kts than 4, got %.1f and %.1f", WakeLength(8), WakeLength(4))
+	}
+	if l := WakeLength(50); l != maxWakeLength {
+		t.Errorf("Expected wake length capped at %.1f, got %.1f", maxWakeLength, l)
+	}
+}
+
+func TestWakeEnds_SymmetricBehindStern(t *testing.T) {
+	stern := geometry.Point{X: 100, Y: 100}
+
+	// Heading north: wake trails south and spreads east-west
+	left, right := wakeEnds(stern, 0, 6)
+	if math.Abs(left.Y-(stern.Y+WakeLength(6))) > 1e-9 || math.Abs(right.Y-(stern.Y+WakeLength(6))) > 1e-9 {
+		t.Errorf("Expected wake ends %.0fm astern, got left %v right %v", WakeLength(6), left, right)
+	}
+	if math.Abs((left.X+right.X)/2-stern.X) > 1e-9 {
+		t.Errorf("Expected wake centered on the stern, got left %v right %v", left, right)
+	}
+
+	// Spread matches WakeWidth at any heading
+	for _, heading := range []float64{0, 45, 135, 270} {
+		left, right := wakeEnds(stern, heading, 6)
+		if spread := math.Hypot(right.X-left.X, right.Y-left.Y); math.Abs(spread-WakeWidth(6)) > 1e-9 {
+			t.Errorf("Heading %.0f: expected spread %.1f, got %.1f", heading, WakeWidth(6), spread)
+		}
+	}
+}
+
+func TestHullColor_ReflectsOCS(t *testing.T) {
+	boat := createTestBoat(10, 45)
+	now := time.UnixMilli(0)
+	flashed := now.Add(ocsFlashInterval)
+
+	if c := boat.hullColor(now); c != color.White {
+		t.Errorf("Expected white hull color when not OCS, got %v", c)
+	}
+
+	boat.OCS = true
+	first := color.RGBAModel.Convert(boat.hullColor(now)).(color.RGBA)
+	second := color.RGBAModel.Convert(boat.hullColor(flashed)).(color.RGBA)
+	for _, c := range []color.RGBA{first, second} {
+		if c.R != 255 || c.G == 255 || c.B == 255 {
+			t.Errorf("Expected a red hull color while OCS, got %v", c)
+		}
+	}
+	if first == second {
+		t.Errorf("Expected the OCS hull color to flash, got %v in both phases", first)
+	}
+
+	boat.OCS = false
+	if c := boat.hullColor(flashed); c != color.White {
+		t.Errorf("Expected hull color back to white once OCS is cleared, got %v", c)
+	}
+}
+
+func TestHullPolygon_HeelsToLeeward(t *testing.T) {
+	// Upright, the hull is symmetric with the bow along the heading
+	boat := createTestBoat(0, 90)
+	hull := boat.hullPolygon()
+	if bow := hull[0]; math.Abs(bow.X-(boat.Pos.X+boat.HullLength()/2)) > 1e-9 || math.Abs(bow.Y-boat.Pos.Y) > 1e-9 {
+		t.Errorf("Expected the bow ahead of the boat heading east, got %+v", bow)
+	}
+	port, starboard := math.Abs(hull[1].Y-boat.Pos.Y), math.Abs(hull[2].Y-boat.Pos.Y)
+	if math.Abs(port-starboard) > 1e-9 {
+		t.Errorf("Expected an upright hull to be symmetric, got %.2f and %.2f", port, starboard)
+	}
+
+	// Beam reach in a northerly with the wind on the port side: heels to starboard
+	boat = createTestBoat(12, 90)
+	boat.Update()
+	hull = boat.hullPolygon()
+	port, starboard = math.Abs(hull[1].Y-boat.Pos.Y), math.Abs(hull[2].Y-boat.Pos.Y)
+	if boat.Heel <= 0 || starboard <= port {
+		t.Errorf("Expected the starboard side wider when heeled %.0f°, got port %.2f starboard %.2f", boat.Heel, port, starboard)
+	}
+
+	// On the other tack it heels the other way
+	boat = createTestBoat(12, 270)
+	boat.Update()
+	hull = boat.hullPolygon()
+	port, starboard = math.Abs(hull[1].Y-boat.Pos.Y), math.Abs(hull[2].Y-boat.Pos.Y)
+	if port <= starboard {
+		t.Errorf("Expected the port side wider on starboard tack, got port %.2f starboard %.2f", port, starboard)
+	}
+}
+
+func TestCurrent_DriftsStoppedBoat(t *testing.T) {
+	// Head to wind with no way on: the boat only moves with the current
+	boat := createTestBoat(10, 0)
+	boat.Current = &world.ConstantCurrent{Direction: 90, Speed: 2}
+
+	for i := 0; i < 60; i++ {
+		boat.Update()
+	}
+
+	// 2 knots for one second at 5 m/s per knot
+	if dx := boat.Pos.X - 1000; math.Abs(dx-10) > 0.01 {
+		t.Errorf("Expected the boat to drift 10m east in one second, drifted %.2fm", dx)
+	}
+	if dy := boat.Pos.Y - 1000; math.Abs(dy) > 0.01 {
+		t.Errorf("Expected no north-south drift, got %.2fm", dy)
+	}
+	if boat.Speed > 0.01 {
+		t.Errorf("Expected no speed through the water in irons, got %.2f kts", boat.Speed)
+	}
+	if math.Abs(boat.SOG-2) > 0.01 {
+		t.Errorf("Expected 2 kts over ground, got %.2f kts", boat.SOG)
+	}
+}
+
+func TestCurrent_FoulTideSlowsSOG(t *testing.T) {
+	boat := createTestBoat(10, 90)
+	boat.Current = &world.ConstantCurrent{Direction: 270, Speed: 1}
+
+	for i := 0; i < 600; i++ {
+		boat.Update()
+	}
+
+	if math.Abs(boat.Speed-boat.SOG-1) > 0.05 {
+		t.Errorf("Expected SOG 1 kt below STW against a 1 kt current, got SOG %.2f, STW %.2f", boat.SOG, boat.Speed)
+	}
+}
+
+func TestLeewayAngle(t *testing.T) {
+	closeHauledFast := leewayAngle(45, 6, Keelboat.Leeway)
+	closeHauledSlow := leewayAngle(45, 2, Keelboat.Leeway)
+
+	if closeHauledFast <= 0 {
+		t.Errorf("Expected leeway close-hauled, got %.2f°", closeHauledFast)
+	}
+	if closeHauledSlow <= closeHauledFast {
+		t.Errorf("Expected more leeway at low speed: %.2f° at 2 kts vs %.2f° at 6 kts", closeHauledSlow, closeHauledFast)
+	}
+	if tight, wide := leewayAngle(35, 6, Keelboat.Leeway), leewayAngle(60, 6, Keelboat.Leeway); tight <= wide {
+		t.Errorf("Expected more leeway at tighter angles: %.2f° at 35° vs %.2f° at 60°", tight, wide)
+	}
+	if stalled := leewayAngle(0, 0, Keelboat.Leeway); stalled != maxLeeway {
+		t.Errorf("Expected leeway capped at %.0f° when stalled, got %.2f°", maxLeeway, stalled)
+	}
+	for _, twa := range []float64{90, -90, 135, 180} {
+		if l := leewayAngle(twa, 6, Keelboat.Leeway); math.Abs(l) > 1e-9 {
+			t.Errorf("Expected no leeway at TWA %.0f°, got %.2f°", twa, l)
+		}
+	}
+}
+
+func TestLeeway_DinghySlipsMoreThanKeelboat(t *testing.T) {
+	for _, twa := range []float64{35, 45, 60} {
+		keelboat := leewayAngle(twa, 4, (&Boat{}).class().Leeway)
+		dinghy := leewayAngle(twa, 4, (&Boat{Class: &Dinghy}).class().Leeway)
+		if dinghy <= keelboat {
+			t.Errorf("Expected a dinghy to make more leeway than a keelboat at TWA %.0f° and 4 kts: %.2f° vs %.2f°", twa, dinghy, keelboat)
+		}
+	}
+}
+
+func TestLeeway_CourseSlipsToLeeward(t *testing.T) {
+	tests := []struct {
+		name    string
+		heading float64
+		sign    float64 // Direction the course is offset from the heading
+	}{
+		{"Port tack slips to starboard", 45, 1},
+		{"Starboard tack slips to port", 315, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boat := createTestBoat(10, tt.heading)
+			for i := 0; i < 600; i++ {
+				boat.Update()
+			}
+
+			offset := math.Mod(boat.COG-tt.heading+540, 360) - 180
+			if offset*tt.sign <= 0 {
+				t.Errorf("Expected course offset to leeward of heading %.0f°, COG is %.1f°", tt.heading, boat.COG)
+			}
+			if math.Abs(math.Abs(offset)-boat.Leeway) > 0.5 {
+				t.Errorf("Expected COG offset to match leeway %.1f°, got %.1f°", boat.Leeway, offset)
+			}
+		})
+	}
+}
+
+func TestHistory_SpacedByGameTime(t *testing.T) {
+	// Stopped head to wind and carried by a steady 2 kt current: 10 m/s of game time
+	boat := createTestBoat(10, 0)
+	boat.Current = &world.ConstantCurrent{Direction: 90, Speed: 2}
+	boat.HistoryInterval = 100 * time.Millisecond
+
+	// Two seconds of game time, however fast the frames are simulated
+	for i := 0; i < 120; i++ {
+		boat.Update()
+	}
+
+	if len(boat.History) != 20 {
+		t.Fatalf("Expected 20 trail points in 2s at 100ms spacing, got %d", len(boat.History))
+	}
+	for i := 1; i < len(boat.History); i++ {
+		prev, cur := boat.History[i-1], boat.History[i]
+		if spacing := math.Hypot(cur.X-prev.X, cur.Y-prev.Y); math.Abs(spacing-1) > 1e-6 {
+			t.Errorf("Expected trail points 1m apart (100ms at 10 m/s), got %.3fm", spacing)
+		}
+	}
+}
+
+func TestHistory_DefaultInterval(t *testing.T) {
+	boat := createTestBoat(10, 90)
+
+	for i := 0; i < 60; i++ {
+		boat.Update()
+	}
+
+	if want := int(time.Second / historyInterval); len(boat.History) != want {
+		t.Errorf("Expected %d trail points after 1s at the default spacing, got %d", want, len(boat.History))
+	}
+}
+
+func TestBounce_HeadOnReflectsAndSlows(t *testing.T) {
+	// Sailing east straight into an obstacle 9m ahead (inside the 10m contact distance)
+	boat := createTestBoat(12, 90)
+	boat.VelX = 1
+	center := geometry.Point{X: boat.Pos.X + 9, Y: boat.Pos.Y}
+
+	if !boat.Bounce(center, 5) {
+		t.Fatal("Expected the boat to hit the obstacle")
+	}
+	if dist := math.Hypot(boat.Pos.X-center.X, boat.Pos.Y-center.Y); math.Abs(dist-10) > 1e-9 {
+		t.Errorf("Expected the boat pushed out to the 10m contact distance, got %.2fm", dist)
+	}
+
+	// Thrown back west at the restitution speed, less the head-on speed loss
+	want := -bounceRestitution * (1 - bounceSpeedLoss)
+	if math.Abs(boat.VelX-want) > 1e-9 || math.Abs(boat.VelY) > 1e-9 {
+		t.Errorf("Expected velocity (%.3f, 0), got (%.3f, %.3f)", want, boat.VelX, boat.VelY)
+	}
+	if math.Abs(boat.Speed-math.Abs(want)*60/speedScale) > 1e-9 {
+		t.Errorf("Expected speed to match the bounced velocity, got %.2f kts", boat.Speed)
+	}
+}
+
+func TestBounce_GlancingHitKeepsMostSpeed(t *testing.T) {
+	// Sailing north past an obstacle just off to starboard
+	boat := createTestBoat(12, 0)
+	boat.VelY = -1
+	center := geometry.Point{X: boat.Pos.X + 8, Y: boat.Pos.Y - 4}
+
+	boat.Bounce(center, 5)
+	if boat.VelX >= 0 {
+		t.Errorf("Expected the boat deflected away to the west, got VelX %.3f", boat.VelX)
+	}
+	if boat.VelY >= 0 {
+		t.Errorf("Expected the boat to keep moving north after a glancing hit, got VelY %.3f", boat.VelY)
+	}
+	if speed := math.Hypot(boat.VelX, boat.VelY); speed < 0.5 || speed >= 1 {
+		t.Errorf("Expected a glancing hit to keep most of the speed, got %.2f of 1", speed)
+	}
+}
+
+func TestBounce_ClearOfObstacle(t *testing.T) {
+	boat := createTestBoat(12, 90)
+	boat.VelX = 1
+
+	if boat.Bounce(geometry.Point{X: boat.Pos.X + 10, Y: boat.Pos.Y}, 5) {
+		t.Error("Expected no contact at exactly the contact distance")
+	}
+	if boat.VelX != 1 {
+		t.Errorf("Expected velocity unchanged, got %.2f", boat.VelX)
+	}
+}
+
+func TestSteer_RateOfTurnBuildsAndEases(t *testing.T) {
+	boat := createTestBoat(12, 90)
+	maxRate := boat.TurnRate() * stepsPerSecond
+
+	// The first step at full helm barely turns the boat
+	boat.Steer(1)
+	if boat.RateOfTurn <= 0 || boat.RateOfTurn >= maxRate/2 {
+		t.Fatalf("Expected the rate of turn to start building, got %.1f°/s", boat.RateOfTurn)
+	}
+
+	// Holding the helm over reaches, but never passes, the full-helm rate
+	for i := 0; i < stepsPerSecond; i++ {
+		boat.Steer(1)
+		if boat.RateOfTurn > maxRate+1e-9 {
+			t.Fatalf("Rate of turn %.1f°/s exceeds the full-helm rate %.1f°/s", boat.RateOfTurn, maxRate)
+		}
+	}
+	if math.Abs(boat.RateOfTurn-maxRate) > 1e-9 {
+		t.Errorf("Expected full-helm rate %.1f°/s after a second, got %.1f", maxRate, boat.RateOfTurn)
+	}
+
+	// Releasing the helm: the boat keeps turning briefly, then goes straight
+	heading := boat.Heading
+	boat.Steer(0)
+	if boat.Heading <= heading {
+		t.Error("Expected the boat to carry on turning just after the helm is released")
+	}
+	for i := 0; i < stepsPerSecond*helmReleaseTime; i++ {
+		boat.Steer(0)
+	}
+	heading = boat.Heading
+	boat.Steer(0)
+	if boat.RateOfTurn != 0 || boat.Heading != heading {
+		t.Errorf("Expected the boat to stop turning, rate %.2f°/s", boat.RateOfTurn)
+	}
+}
+
+func TestSteer_TurnsToPortThroughNorth(t *testing.T) {
+	boat := createTestBoat(12, 2)
+	for i := 0; i < stepsPerSecond; i++ {
+		boat.Steer(-1)
+	}
+	if boat.Heading < 300 || boat.Heading >= 360 {
+		t.Errorf("Expected the heading to wrap to the 300s turning to port from 2°, got %.1f°", boat.Heading)
+	}
+}
+
+func TestUpdate_DoesNotSteer(t *testing.T) {
+	// Steering is the game's job; physics alone keeps the heading on every point of sail
+	for _, heading := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		boat := createTestBoat(12, heading)
+		for i := 0; i < stepsPerSecond; i++ {
+			boat.Update()
+		}
+		if boat.Heading != heading {
+			t.Errorf("Expected Update to keep heading %.0f°, got %.2f°", heading, boat.Heading)
+		}
+	}
+}
+
+func TestImports_UseModulePath(t *testing.T) {
+	// Imports from this repository must go through the gosailing2 module, or a clean checkout won't build
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", file, err)
+		}
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if strings.HasPrefix(path, "github.com/mpihlak/") && !strings.HasPrefix(path, "github.com/mpihlak/gosailing2/") {
+				t.Errorf("%s imports %q outside the github.com/mpihlak/gosailing2 module", file, path)
+			}
+		}
+	}
+}
+
+func TestToggleSpinnaker_OnlyHoistsDeep(t *testing.T) {
+	boat := createTestBoat(12, 45)
+	if boat.ToggleSpinnaker() {
+		t.Fatal("Expected the spinnaker to stay down close-hauled")
+	}
+
+	boat.Heading = 150
+	plain := boat.PolarSpeed(150, 12)
+	if !boat.ToggleSpinnaker() {
+		t.Fatal("Expected the spinnaker to go up on a broad reach")
+	}
+	if kite := boat.PolarSpeed(150, 12); kite <= plain {
+		t.Errorf("Expected the spinnaker to add speed at 150°: %.2f with it, %.2f without", kite, plain)
+	}
+
+	// Luffing up with the kite still flying costs speed, until it comes down
+	boat.Heading = 70
+	if high := boat.PolarSpeed(70, 12); high >= boat.Polars.GetBoatSpeed(70, 12) {
+		t.Errorf("Expected flying the spinnaker at 70° to cost speed, got %.2f", high)
+	}
+	if boat.ToggleSpinnaker() {
+		t.Error("Expected the spinnaker to drop at any angle")
+	}
+}
+
+func TestInIrons_HeadToWindStallsUntilBearingAway(t *testing.T) {
+	boat := createTestBoat(12, 0)
+	boat.VelY = -6 * speedScale / 60 // 6 knots straight into the wind
+	boat.Speed = 6
+
+	prev := boat.Speed
+	for i := 0; i < 30*stepsPerSecond; i++ {
+		boat.Update()
+		if boat.Speed > prev+1e-9 {
+			t.Fatalf("Expected the boat to keep slowing head to wind, %.3f kts after %.3f", boat.Speed, prev)
+		}
+		prev = boat.Speed
+	}
+	if !boat.IsInIrons() {
+		t.Fatal("Expected the boat to be in irons after stalling head to wind")
+	}
+	if boat.Speed > 0.05 {
+		t.Errorf("Expected the boat to come almost to a stop in irons, still at %.2f kts", boat.Speed)
+	}
+
+	// Bearing away onto a close reach frees the sails and the boat picks up speed
+	boat.Heading = 60
+	for i := 0; i < 5*stepsPerSecond; i++ {
+		boat.Update()
+	}
+	if boat.IsInIrons() {
+		t.Error("Expected bearing away past the no-go angle to get out of irons")
+	}
+	if boat.Speed < 1 {
+		t.Errorf("Expected the boat to pick up speed after bearing away, got %.2f kts", boat.Speed)
+	}
+}
+
+func TestBoundary_KeepsBoatInsideTheWorld(t *testing.T) {
+	for _, mode := range []world.BoundaryMode{world.BoundaryWall, world.BoundaryShallows} {
+		t.Run(mode.String(), func(t *testing.T) {
+			bounds := &world.Boundary{Width: 2000, Height: 3000, Mode: mode}
+			// Broad reaching flat out toward the bottom right corner
+			boat := createTestBoat(20, 135)
+			boat.Pos = geometry.Point{X: 1900, Y: 2900}
+			boat.Boundary = bounds
+			boat.Current = &world.ConstantCurrent{Direction: 135, Speed: 3}
+
+			for i := 0; i < 60*stepsPerSecond; i++ {
+				boat.Update()
+				if boat.Pos.X < 0 || boat.Pos.X > bounds.Width || boat.Pos.Y < 0 || boat.Pos.Y > bounds.Height {
+					t.Fatalf("Expected the boat to stay inside the world, at %+v after %d steps", boat.Pos, i+1)
+				}
+			}
+			if bounds.DistanceToEdge(boat.Pos) > 5 {
+				t.Errorf("Expected the boat to end up against the edge, at %+v", boat.Pos)
+			}
+		})
+	}
+}
+
+func TestBoundary_WallBouncesShallowsStop(t *testing.T) {
+	// Heading straight at the east edge at 6 knots
+	wall := createTestBoat(12, 90)
+	wall.Boundary = &world.Boundary{Width: 2000, Height: 3000, Mode: world.BoundaryWall}
+	wall.Pos = geometry.Point{X: 2001, Y: 1500}
+	wall.VelX = 6 * speedScale / 60
+	wall.keepInBounds()
+	if wall.VelX >= 0 {
+		t.Errorf("Expected the wall to bounce the boat back, VelX %.3f", wall.VelX)
+	}
+
+	shallows := createTestBoat(12, 90)
+	shallows.Boundary = &world.Boundary{Width: 2000, Height: 3000, Mode: world.BoundaryShallows}
+	shallows.Pos = geometry.Point{X: 2001, Y: 1500}
+	shallows.VelX, shallows.VelY = 6*speedScale/60, 0.1
+	shallows.keepInBounds()
+	if shallows.VelX != 0 || shallows.Pos.X != 2000 {
+		t.Errorf("Expected the boat to run aground at the edge, VelX %.3f at X %.1f", shallows.VelX, shallows.Pos.X)
+	}
+	if shallows.VelY == 0 {
+		t.Error("Expected the boat to keep sliding along the edge")
+	}
+}
+
+func TestSpeedConversions(t *testing.T) {
+	// 6 knots is 30 m/s of game time
+	if got := MetersPerSecond(6); got != 30 {
+		t.Errorf("Expected 6 kts to be 30 m/s, got %.2f", got)
+	}
+	if got := Knots(MetersPerSecond(7.5)); math.Abs(got-7.5) > 1e-9 {
+		t.Errorf("Expected the conversions to round trip, got %.3f kts", got)
+	}
+}
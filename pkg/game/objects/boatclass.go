@@ -0,0 +1,56 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mpihlak/gosailing2/pkg/polars"
+)
+
+// BoatClass bundles a boat's performance (polars) with its physical handling
+type BoatClass struct {
+	Name            string
+	Polars          polars.Polars
+	Mass            float64 // Mass in kg; lighter boats accelerate sooner
+	DragCoefficient float64 // Water resistance coefficient
+	Length          float64 // Hull length in meters (drawn triangle height)
+	Beam            float64 // Hull width in meters (drawn triangle width)
+	TurnRate        float64 // Degrees per frame at full helm
+	Leeway          float64 // Leeway in degrees at 1 knot head to wind (how far the hull slips sideways)
+	Broaches        bool    // Whether overpowering at broad angles causes a broach
+}
+
+// Built-in boat classes
+var (
+	Keelboat = BoatClass{
+		Name:            "keelboat",
+		Polars:          &polars.RealisticPolar{},
+		Mass:            4000.0,
+		DragCoefficient: 0.02, // Reduced for more gradual deceleration
+		Length:          15.0,
+		Beam:            7.5,
+		TurnRate:        1.0,
+		Leeway:          25.0,
+	}
+	Dinghy = BoatClass{
+		Name:            "dinghy",
+		Polars:          &polars.DinghyPolar{},
+		Mass:            180.0,
+		DragCoefficient: 0.02,
+		Length:          10.0,
+		Beam:            5.0,
+		TurnRate:        2.0,  // Tacks on a dime
+		Leeway:          35.0, // Slips more than a keel with the board half up
+		Broaches:        true,
+	}
+)
+
+// ParseBoatClass returns the built-in class with the given name (case-insensitive)
+func ParseBoatClass(name string) (*BoatClass, error) {
+	for _, c := range []*BoatClass{&Keelboat, &Dinghy} {
+		if strings.EqualFold(name, c.Name) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown boat class %q (want keelboat or dinghy)", name)
+}
@@ -0,0 +1,63 @@
+package objects
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestParseBoatClass(t *testing.T) {
+	for _, want := range []*BoatClass{&Keelboat, &Dinghy} {
+		got, err := ParseBoatClass(want.Name)
+		if err != nil || got != want {
+			t.Errorf("ParseBoatClass(%q) = %v, %v", want.Name, got, err)
+		}
+	}
+	if got, err := ParseBoatClass("Dinghy"); err != nil || got != &Dinghy {
+		t.Errorf("Expected class names to be case-insensitive, got %v, %v", got, err)
+	}
+	if _, err := ParseBoatClass("catamaran"); err == nil {
+		t.Error("Expected an error for an unknown boat class")
+	}
+}
+
+func TestBoatClass_DinghyAcceleratesFaster(t *testing.T) {
+	// Seconds for a boat of the class to reach 80% of its target speed on a beam reach from rest
+	timeToSpeed := func(class *BoatClass) float64 {
+		boat := &Boat{
+			Pos:     geometry.Point{X: 1000, Y: 1000},
+			Heading: 90,
+			Class:   class,
+			Polars:  class.Polars,
+			Wind:    &world.ConstantWind{Direction: 0, Speed: 10},
+		}
+		target := class.Polars.GetBoatSpeed(90, 10)
+		for frame := 0; frame < 60*60; frame++ {
+			boat.Update()
+			if boat.Speed >= 0.8*target {
+				return float64(frame) / 60
+			}
+		}
+		t.Fatalf("%s never reached 80%% of target speed", class.Name)
+		return 0
+	}
+
+	keelboat := timeToSpeed(&Keelboat)
+	dinghy := timeToSpeed(&Dinghy)
+	if dinghy >= keelboat {
+		t.Errorf("Expected the dinghy to get up to speed sooner: dinghy %.1fs, keelboat %.1fs", dinghy, keelboat)
+	}
+}
+
+func TestBoatClass_HandlingDefaultsToKeelboat(t *testing.T) {
+	boat := createTestBoat(10, 90)
+	if boat.TurnRate() != Keelboat.TurnRate {
+		t.Errorf("Expected a boat without a class to turn like a keelboat, got %.1f°/frame", boat.TurnRate())
+	}
+
+	boat.Class = &Dinghy
+	if boat.TurnRate() <= Keelboat.TurnRate {
+		t.Errorf("Expected the dinghy to turn faster than a keelboat, got %.1f°/frame", boat.TurnRate())
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"math"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Two full turns in the same direction exonerate a mark touch
+const penaltyTurnDegrees = 720.0
+
+// takePenalty requires penalty turns before the boat can finish, starting the count afresh
+func (g *GameState) takePenalty() {
+	g.penaltyPending = true
+	g.penaltyTurned = 0
+}
+
+// trackPenaltyTurns adds the heading change since prevHeading to the penalty turns owed,
+// clearing the penalty once two full turns have been completed. Turning back the other
+// way unwinds the count.
+func (g *GameState) trackPenaltyTurns(prevHeading float64) {
+	if !g.penaltyPending {
+		return
+	}
+	g.penaltyTurned += geometry.AngleDiff(g.Boat.Heading, prevHeading)
+	if math.Abs(g.penaltyTurned) >= penaltyTurnDegrees {
+		g.penaltyPending = false
+		g.penaltyTurned = 0
+	}
+}
+
+// penaltyProgress returns the degrees turned so far toward the penalty and the degrees
+// required (0 when no penalty is owed)
+func (g *GameState) penaltyProgress() (float64, float64) {
+	if !g.penaltyPending {
+		return 0, 0
+	}
+	return math.Abs(g.penaltyTurned), penaltyTurnDegrees
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// turnBoat turns the player's boat by degrees in 5° steps, tracking penalty turns
+func turnBoat(g *GameState, degrees float64) {
+	step := 5.0
+	if degrees < 0 {
+		step = -5
+	}
+	for turned := 0.0; turned*step < degrees*step; turned += step {
+		prev := g.Boat.Heading
+		g.Boat.Heading = math.Mod(g.Boat.Heading+step+360, 360)
+		g.trackPenaltyTurns(prev)
+	}
+}
+
+func TestPenalty_TwoFullTurnsClearIt(t *testing.T) {
+	g := createTestGame()
+	g.takePenalty()
+
+	turnBoat(g, 360)
+	if turned, required := g.penaltyProgress(); !g.penaltyPending || turned != 360 || required != penaltyTurnDegrees {
+		t.Fatalf("Expected one turn of two done, got %.0f of %.0f (pending %v)", turned, required, g.penaltyPending)
+	}
+
+	turnBoat(g, 360)
+	if g.penaltyPending {
+		t.Error("Expected the penalty to be cleared after two full turns")
+	}
+	if _, required := g.penaltyProgress(); required != 0 {
+		t.Errorf("Expected nothing owed after the turns, got %.0f", required)
+	}
+}
+
+func TestPenalty_TurningBackUnwinds(t *testing.T) {
+	g := createTestGame()
+	g.takePenalty()
+
+	// Tacking back and forth isn't a penalty turn
+	for i := 0; i < 10; i++ {
+		turnBoat(g, 90)
+		turnBoat(g, -90)
+	}
+	if turned, _ := g.penaltyProgress(); !g.penaltyPending || turned != 0 {
+		t.Errorf("Expected no progress from tacking back and forth, got %.0f°", turned)
+	}
+
+	// Turns to port count just as well as turns to starboard
+	turnBoat(g, -720)
+	if g.penaltyPending {
+		t.Error("Expected two turns to port to clear the penalty")
+	}
+}
+
+func TestPenalty_BlocksFinish(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	g.markRounded = true
+	g.takePenalty()
+
+	// Bow crossing the line from the course side
+	g.Boat.Heading = 180
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2400}
+	g.prevBowPos = geometry.Point{X: 1000, Y: 2390}
+	g.checkFinishLineCrossing()
+	if g.raceFinished {
+		t.Fatal("Expected no finish while the penalty is owed")
+	}
+}
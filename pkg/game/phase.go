@@ -0,0 +1,87 @@
+package game
+
+// RacePhase is the part of the race the player's boat is in
+type RacePhase int
+
+const (
+	RacePhasePreStart RacePhase = iota // Before the gun, or not yet across the start line
+	RacePhaseBeat                      // Sailing upwind to the mark
+	RacePhaseRounding                  // Above the mark, rounding it
+	RacePhaseRun                       // Running back down to the gate or the line
+	RacePhaseFinished                  // Across the finish line
+)
+
+func (p RacePhase) String() string {
+	switch p {
+	case RacePhaseBeat:
+		return "Beat"
+	case RacePhaseRounding:
+		return "Rounding"
+	case RacePhaseRun:
+		return "Run"
+	case RacePhaseFinished:
+		return "Finished"
+	default:
+		return "Pre-start"
+	}
+}
+
+// Each lap is a beat to the upwind mark and a run back to the line
+const legsPerLap = 2
+
+// racePhase derives the race phase from the start, rounding and finish flags
+func (g *GameState) racePhase() RacePhase {
+	switch {
+	case g.raceFinished:
+		return RacePhaseFinished
+	case !g.raceStarted || !g.hasCrossedLine:
+		return RacePhasePreStart
+	case g.markRounded:
+		return RacePhaseRun
+	case g.markRoundingPhase1:
+		return RacePhaseRounding
+	default:
+		return RacePhaseBeat
+	}
+}
+
+// raceLeg returns the leg being sailed (0 before the start, the last leg once finished)
+// and the number of legs in the race
+func (g *GameState) raceLeg() (int, int) {
+	legs := g.totalLaps() * legsPerLap
+	switch g.racePhase() {
+	case RacePhasePreStart:
+		return 0, legs
+	case RacePhaseFinished:
+		return legs, legs
+	case RacePhaseRun:
+		return g.lapsCompleted*legsPerLap + 2, legs
+	default:
+		return g.lapsCompleted*legsPerLap + 1, legs
+	}
+}
+
+// raceStatus describes what the boat has to do next in its race phase, for the dashboard
+func (g *GameState) raceStatus() string {
+	switch g.racePhase() {
+	case RacePhaseFinished:
+		return "FINISHED!"
+	case RacePhasePreStart:
+		return "Must cross start line"
+	case RacePhaseRun:
+		if g.Arena.Gate == nil {
+			return "Mark rounded"
+		}
+		if !g.gatePassed {
+			return "Running to gate"
+		}
+		return "Gate passed"
+	case RacePhaseRounding:
+		return "Rounding mark"
+	default:
+		if g.wrongRounding {
+			return "Wrong way round!"
+		}
+		return "Racing to mark"
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+)
+
+func TestRacePhase_FromFlags(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		started, crossed, above, rounded, done bool
+		want                                   RacePhase
+	}{
+		{"Before the gun", false, false, false, false, false, RacePhasePreStart},
+		{"Gun gone, not across the line", true, false, false, false, false, RacePhasePreStart},
+		{"Beating to the mark", true, true, false, false, false, RacePhaseBeat},
+		{"Above the mark", true, true, true, false, false, RacePhaseRounding},
+		{"Rounded", true, true, true, true, false, RacePhaseRun},
+		{"Finished", true, true, true, true, true, RacePhaseFinished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createTestGame()
+			g.raceStarted = tt.started
+			g.hasCrossedLine = tt.crossed
+			g.markRoundingPhase1 = tt.above
+			g.markRounded = tt.rounded
+			g.raceFinished = tt.done
+			if got := g.racePhase(); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRaceLeg_CountsAcrossLaps(t *testing.T) {
+	g := createTestGame()
+	g.course.Laps = 2
+
+	if leg, legs := g.raceLeg(); leg != 0 || legs != 4 {
+		t.Errorf("Expected leg 0 of 4 before the start, got %d of %d", leg, legs)
+	}
+
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	if leg, _ := g.raceLeg(); leg != 1 {
+		t.Errorf("Expected the first beat to be leg 1, got %d", leg)
+	}
+
+	g.markRounded = true
+	if leg, _ := g.raceLeg(); leg != 2 {
+		t.Errorf("Expected the first run to be leg 2, got %d", leg)
+	}
+
+	g.startNextLap()
+	if leg, _ := g.raceLeg(); leg != 3 {
+		t.Errorf("Expected the second beat to be leg 3, got %d", leg)
+	}
+
+	g.raceFinished = true
+	if leg, legs := g.raceLeg(); leg != 4 || legs != 4 {
+		t.Errorf("Expected leg 4 of 4 once finished, got %d of %d", leg, legs)
+	}
+}
+
+func TestRaceStatus_FollowsPhase(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	if got := g.raceStatus(); got != "Must cross start line" {
+		t.Errorf("Expected to be told to cross the line, got %q", got)
+	}
+
+	g.hasCrossedLine = true
+	g.wrongRounding = true
+	if got := g.raceStatus(); got != "Wrong way round!" {
+		t.Errorf("Expected the wrong rounding warning on the beat, got %q", got)
+	}
+
+	g.markRounded = true
+	if got := g.raceStatus(); got != "Mark rounded" {
+		t.Errorf("Expected the mark rounded without a gate, got %q", got)
+	}
+	g.Arena.Gate = &world.Gate{Left: &world.Mark{}, Right: &world.Mark{}}
+	if got := g.raceStatus(); got != "Running to gate" {
+		t.Errorf("Expected to be running to the gate, got %q", got)
+	}
+	g.gatePassed = true
+	if got := g.raceStatus(); got != "Gate passed" {
+		t.Errorf("Expected the gate passed, got %q", got)
+	}
+
+	g.raceFinished = true
+	if got := g.raceStatus(); got != "FINISHED!" {
+		t.Errorf("Expected finished, got %q", got)
+	}
+}
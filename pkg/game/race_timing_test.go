@@ -1,6 +1,7 @@
 package game
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 
 // Helper function to create a minimal game state for testing
 func createTestGame() *GameState {
-	wind := world.NewOscillatingWind(10, 10, WorldWidth)
+	wind := world.NewOscillatingWind(10, 10, WorldWidth, 0)
 
 	pinX := float64(WorldWidth/2 - 200)
 	committeeX := float64(WorldWidth/2 + 200)
@@ -30,16 +31,17 @@ func createTestGame() *GameState {
 	arena := &world.Arena{
 		Marks: []*world.Mark{
 			{Pos: geometry.Point{X: pinX, Y: lineY}, Name: "Pin"},
-			{Pos: geometry.Point{X: committeeX, Y: lineY}, Name: "Committee"},
+			{Pos: geometry.Point{X: committeeX, Y: lineY}, Name: "Committee", Solid: true},
 			{Pos: geometry.Point{X: 1000, Y: 1800}, Name: "Upwind"},
 		},
 	}
 
+	arena.Line = &world.StartLine{Pin: arena.Marks[0], Committee: arena.Marks[1]}
+
 	dash := &dashboard.Dashboard{
 		Boat:       boat,
 		Wind:       wind,
-		LineStart:  geometry.Point{X: pinX, Y: lineY},
-		LineEnd:    geometry.Point{X: committeeX, Y: lineY},
+		Line:       arena.Line,
 		UpwindMark: geometry.Point{X: 1000, Y: 1800},
 	}
 
@@ -264,3 +266,98 @@ func TestIsWithinLineBounds(t *testing.T) {
 		})
 	}
 }
+
+func TestDistanceSailed_CountsFromLineToFinish(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.isPaused = false
+	g.Boat.VelX, g.Boat.VelY = 0.3, -0.4 // 0.5 m per step
+
+	// Nothing counts before crossing the line
+	g.trackDistanceSailed()
+	if g.distanceSailed != 0 {
+		t.Fatalf("Expected no distance before crossing the line, got %.2fm", g.distanceSailed)
+	}
+
+	g.hasCrossedLine = true
+	for i := 0; i < 60; i++ {
+		g.trackDistanceSailed()
+	}
+	if math.Abs(g.distanceSailed-30) > 1e-9 {
+		t.Errorf("Expected 30m after a second at 0.5 m per step, got %.2fm", g.distanceSailed)
+	}
+
+	// Paused with the leaderboard open the distance holds
+	g.isPaused = true
+	g.trackDistanceSailed()
+	g.isPaused = false
+
+	// And stops at the finish
+	g.raceFinished = true
+	g.trackDistanceSailed()
+	if math.Abs(g.distanceSailed-30) > 1e-9 {
+		t.Errorf("Expected the distance to hold while paused and after the finish, got %.2fm", g.distanceSailed)
+	}
+	if got := g.raceResult().DistanceSailed; math.Abs(got-30) > 1e-9 {
+		t.Errorf("Expected the race result to carry 30m, got %.2fm", got)
+	}
+}
+
+func TestAverageSpeedKnots(t *testing.T) {
+	// 1500m in 100s is 15 m/s, which is 3 kts at 5 m/s per knot
+	if got := averageSpeedKnots(1500, 100*time.Second); math.Abs(got-3) > 1e-9 {
+		t.Errorf("Expected 3.0 kts, got %.3f", got)
+	}
+	if got := averageSpeedKnots(1500, 0); got != 0 {
+		t.Errorf("Expected 0 kts for a zero race time, got %.3f", got)
+	}
+}
+
+func TestAverageSpeed_SetAtFinish(t *testing.T) {
+	g := createTestGame()
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	g.markRounded = true
+	g.raceTimer = 200 * time.Second
+	g.distanceSailed = 5000
+	g.scoreboard = NewScoreboard(&localLeaderboard{store: newMemoryStore()})
+
+	// Bow crossing the finish line from the course side
+	g.Boat.Heading = 180
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 2400}
+	g.prevBowPos = geometry.Point{X: 1000, Y: 2390}
+	g.checkFinishLineCrossing()
+	if !g.raceFinished {
+		t.Fatal("Expected the boat to finish")
+	}
+	if got := g.raceResult().AverageSpeed; math.Abs(got-5) > 1e-9 {
+		t.Errorf("Expected 5.0 kts average over 5000m in 200s, got %.3f", got)
+	}
+}
+
+func TestFinish_ScoreboardOfferedOnceAfterDelay(t *testing.T) {
+	g := createTestGame()
+	g.scoreboard = NewScoreboard(&localLeaderboard{store: newMemoryStore()})
+	g.mobileControls = &MobileControls{}
+	g.raceFinished = true
+	g.finishBannerTime = time.Now()
+
+	// The finish banner shows first
+	g.updateFinishScoreboard()
+	if g.scoreboard.IsVisible() {
+		t.Fatal("Expected the scoreboard to wait for the finish banner")
+	}
+
+	g.finishBannerTime = time.Now().Add(-scoreboardDelay)
+	g.updateFinishScoreboard()
+	if !g.scoreboard.IsVisible() {
+		t.Fatal("Expected the scoreboard after the delay")
+	}
+
+	// Closing it doesn't bring it back
+	g.scoreboard.isVisible = false
+	g.updateFinishScoreboard()
+	if g.scoreboard.IsVisible() {
+		t.Error("Expected the scoreboard to be offered only once per finish")
+	}
+}
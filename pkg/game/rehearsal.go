@@ -0,0 +1,96 @@
+package game
+
+import (
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Start rehearsal loops from this long before the gun to this long after it
+const (
+	rehearsalPreGun  = 60 * time.Second
+	rehearsalPostGun = 10 * time.Second
+)
+
+// rehearsalSnapshot is the boat state captured at the start of the rehearsal loop
+type rehearsalSnapshot struct {
+	boat objects.Boat
+}
+
+// rehearsalLoopStart returns the elapsed time the rehearsal loops back to (one minute
+// before the gun, or the beginning of the sequence if it is shorter)
+func (g *GameState) rehearsalLoopStart() time.Duration {
+	start := g.timerDuration - rehearsalPreGun
+	if start < 0 {
+		start = 0
+	}
+	return start
+}
+
+// toggleRehearsal turns the start rehearsal loop on or off (only before the gun)
+func (g *GameState) toggleRehearsal() {
+	if g.raceStarted {
+		return
+	}
+	g.rehearsalMode = !g.rehearsalMode
+	g.rehearsalSnapshot = nil
+}
+
+// updateRehearsal captures the boat when the loop point is reached and rewinds to it
+// a few seconds after the gun, so timed approaches can be practised back-to-back
+func (g *GameState) updateRehearsal() {
+	if !g.rehearsalMode {
+		return
+	}
+
+	if g.rehearsalSnapshot == nil && g.elapsedTime >= g.rehearsalLoopStart() {
+		boat := *g.Boat
+		boat.History = append([]geometry.Point(nil), g.Boat.History...)
+		g.rehearsalSnapshot = &rehearsalSnapshot{boat: boat}
+	}
+
+	if g.rehearsalSnapshot != nil && g.elapsedTime >= g.timerDuration+rehearsalPostGun {
+		g.restartRehearsal()
+	}
+}
+
+// restartRehearsal rewinds the clock to the loop point and restores the captured boat
+func (g *GameState) restartRehearsal() {
+	boat := g.rehearsalSnapshot.boat
+	boat.History = append([]geometry.Point(nil), boat.History...)
+	*g.Boat = boat
+
+	g.elapsedTime = g.rehearsalLoopStart()
+	g.prevBowPos = g.Boat.GetBowPosition()
+
+	// Clear race progress from the previous attempt
+	g.raceStarted = false
+	g.raceTimer = 0
+	g.isOCS = false
+	g.hasCrossedLine = false
+	g.lineCrossingTime = 0
+	g.secondsLate = 0
+	g.vmgAtCrossing = 0
+	g.speedPercentage = 0
+	g.markRoundingPhase1 = false
+	g.markRoundingPhase2 = false
+	g.markRoundingPhase3 = false
+	g.markRounded = false
+	g.tackNow = false
+	g.markCrossings = 0
+	g.markAcross = 0
+	g.wrongRounding = false
+	g.gatePassed = false
+	g.lapsCompleted = 0
+	g.distanceSailed = 0
+	g.penaltyCount = 0
+	g.collisionHistory = nil
+	g.touchedMark = ""
+	g.penaltyPending = false
+	g.penaltyTurned = 0
+	g.prevTWA = 0
+	g.tacks = nil
+	g.replay = NewReplayState(g.screenSize())
+	g.setupFleet()
+}
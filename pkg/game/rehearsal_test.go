@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestRehearsal_LoopsBackAfterGun(t *testing.T) {
+	g := createTestGame()
+	g.timerDuration = 2 * time.Minute
+	g.toggleRehearsal()
+
+	// Reach the loop point one minute before the gun
+	g.elapsedTime = time.Minute
+	g.updateRehearsal()
+	startPos := g.Boat.Pos
+	startHeading := g.Boat.Heading
+
+	// Sail through the start
+	g.Boat.Pos = geometry.Point{X: 1050, Y: 2300}
+	g.Boat.Heading = 45
+	g.elapsedTime = g.timerDuration + 5*time.Second
+	g.raceStarted = true
+	g.hasCrossedLine = true
+	g.updateRehearsal()
+
+	if g.elapsedTime != g.timerDuration+5*time.Second {
+		t.Fatalf("Expected no loop 5s after the gun, elapsed time reset to %v", g.elapsedTime)
+	}
+
+	// A few seconds later the sequence rewinds
+	g.elapsedTime = g.timerDuration + 10*time.Second
+	g.updateRehearsal()
+
+	if g.elapsedTime != time.Minute {
+		t.Errorf("Expected rehearsal to loop back to -60s (elapsed 1m0s), got %v", g.elapsedTime)
+	}
+	if g.raceStarted || g.hasCrossedLine {
+		t.Error("Expected race progress to be cleared after looping")
+	}
+	if g.Boat.Pos != startPos || g.Boat.Heading != startHeading {
+		t.Errorf("Expected boat restored to %v heading %.0f, got %v heading %.0f", startPos, startHeading, g.Boat.Pos, g.Boat.Heading)
+	}
+
+	// Loops again on the next attempt
+	g.elapsedTime = g.timerDuration + 10*time.Second
+	g.updateRehearsal()
+	if g.elapsedTime != time.Minute {
+		t.Errorf("Expected rehearsal to loop repeatedly, got elapsed %v", g.elapsedTime)
+	}
+}
+
+func TestRehearsal_ShortSequenceLoopsToBeginning(t *testing.T) {
+	g := createTestGame() // 30 second start sequence
+	g.toggleRehearsal()
+
+	g.updateRehearsal()
+	g.elapsedTime = g.timerDuration + rehearsalPostGun
+	g.updateRehearsal()
+
+	if g.elapsedTime != 0 {
+		t.Errorf("Expected loop back to the beginning of a 30s sequence, got %v", g.elapsedTime)
+	}
+}
+
+func TestRehearsal_OffByDefault(t *testing.T) {
+	g := createTestGame()
+	g.elapsedTime = g.timerDuration + time.Minute
+	g.updateRehearsal()
+
+	if g.elapsedTime != g.timerDuration+time.Minute {
+		t.Errorf("Expected no looping outside rehearsal mode, got %v", g.elapsedTime)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSupersampling_RenderDimensionsScale(t *testing.T) {
+	tests := []struct {
+		name           string
+		factor         int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{"Native resolution", 1, ScreenWidth, ScreenHeight},
+		{"2x supersampling", 2, ScreenWidth * 2, ScreenHeight * 2},
+		{"Invalid factor falls back to native", 0, ScreenWidth, ScreenHeight},
+		{"Factor above 2x is capped", 4, ScreenWidth * 2, ScreenHeight * 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createTestGame()
+			g.SetSupersampling(tt.factor)
+
+			width, height := g.Layout(ScreenWidth, ScreenHeight)
+			if width != tt.expectedWidth || height != tt.expectedHeight {
+				t.Errorf("Layout() = %dx%d, expected %dx%d", width, height, tt.expectedWidth, tt.expectedHeight)
+			}
+
+			// World is drawn at the internal resolution, offset by the camera
+			g.CameraX, g.CameraY = 600, 2000
+			x, y := g.worldView().ToScreen(700, 2050)
+			scale := float64(tt.expectedWidth) / ScreenWidth
+			if x != 100*scale || y != 50*scale {
+				t.Errorf("World point rendered at (%.0f, %.0f), expected (%.0f, %.0f)", x, y, 100*scale, 50*scale)
+			}
+		})
+	}
+}
+
+func TestLayout_FollowsPhoneScreen(t *testing.T) {
+	sim, err := NewSimulator(DefaultCourseConfig(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := sim.Game()
+	g.SetSupersampling(2)
+
+	// A portrait phone: the logical screen matches it instead of letterboxing 1280x720
+	width, height := g.Layout(375, 812)
+	if width != 750 || height != 1624 {
+		t.Errorf("Layout() = %dx%d, expected the 375x812 screen at 2x", width, height)
+	}
+
+	// The touch buttons sit 20px in from the phone's corners and bottom middle
+	mc := g.mobileControls
+	tests := []struct {
+		name string
+		zone TouchZone
+		x, y int
+	}{
+		{"left", mc.leftButton, 20, 712},
+		{"right", mc.rightButton, 275, 712},
+		{"pause", mc.pauseButton, 147, 712},
+		{"restart", mc.restartButton, 20, 20},
+	}
+	for _, tt := range tests {
+		if tt.zone.X != tt.x || tt.zone.Y != tt.y {
+			t.Errorf("%s button at %d,%d, expected %d,%d", tt.name, tt.zone.X, tt.zone.Y, tt.x, tt.y)
+		}
+		if !tt.zone.Contains(tt.x+tt.zone.Width/2, tt.y+tt.zone.Height/2) {
+			t.Errorf("%s button doesn't contain its own middle", tt.name)
+		}
+	}
+
+	// The telltales and replay timeline follow the new width
+	if g.telltales.BaseX != 375/2-50 {
+		t.Errorf("Telltales at %.0f, expected centered for 375px", g.telltales.BaseX)
+	}
+	if s := g.replay.Scrubber; s.Y != 812-50 || s.Width != 375-240 {
+		t.Errorf("Replay scrubber at y=%.0f width %.0f, expected along the bottom of the phone", s.Y, s.Width)
+	}
+
+	// Rotating to landscape moves everything again
+	g.Layout(812, 375)
+	if mc.rightButton.X != 812-100 || mc.rightButton.Y != 375-100 {
+		t.Errorf("Right button at %d,%d after rotating, expected 712,275", mc.rightButton.X, mc.rightButton.Y)
+	}
+}
+
+func TestFollowCamera_SmallScreenMargins(t *testing.T) {
+	g := createTestGame()
+	g.screenWidth, g.screenHeight = 375, 812
+
+	// On a narrow screen the margins shrink so the camera settles instead of flipping sides
+	g.CameraX, g.CameraY = 800, 2000
+	g.Boat.Pos.X, g.Boat.Pos.Y = 1000, 2400
+	g.snapCamera()
+	first := g.CameraX
+	g.snapCamera()
+	if g.CameraX != first {
+		t.Errorf("Expected the camera to settle, moved from %.0f to %.0f", first, g.CameraX)
+	}
+	if screenX := g.Boat.Pos.X - g.CameraX; screenX < 0 || screenX > 375 {
+		t.Errorf("Expected the boat on the 375px screen, at x=%.0f", screenX)
+	}
+}
+
+func TestPauseOverlay_HelpCanBeTurnedOff(t *testing.T) {
+	g := createTestGame()
+	g.mobileControls = NewMobileControls(ScreenWidth, ScreenHeight)
+	g.settings = DefaultSettings()
+
+	if text := g.pauseOverlayText(); !strings.Contains(text, "Controls:") {
+		t.Errorf("Expected the full help on pause by default, got %q", text)
+	}
+
+	g.settings.ShowHelpOnPause = false
+	text := g.pauseOverlayText()
+	if strings.Contains(text, "Controls:") || strings.Contains(text, "How to Play") {
+		t.Errorf("Expected no help text with help on pause turned off, got %q", text)
+	}
+	if !strings.Contains(text, "PAUSED") {
+		t.Errorf("Expected a pause indicator, got %q", text)
+	}
+}
@@ -0,0 +1,399 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// ReplayEventType identifies a key race moment shown on the replay timeline
+type ReplayEventType int
+
+const (
+	ReplayEventStart        ReplayEventType = iota // Start line crossing
+	ReplayEventMarkRounding                        // Upwind mark rounded
+	ReplayEventFinish                              // Finish line crossing
+	ReplayEventFoul                                // Mark collision penalty
+	ReplayEventLap                                 // Line crossed to start another lap
+)
+
+// ReplayEvent is a timestamped race event (time since game start)
+type ReplayEvent struct {
+	Type ReplayEventType
+	Time time.Duration
+}
+
+// ReplayFrame is a recorded boat state at a point in time (time since game start)
+type ReplayFrame struct {
+	Time    time.Duration
+	Pos     geometry.Point
+	Heading float64
+	Speed   float64
+}
+
+// Scrubber is the replay timeline bar that maps screen positions to race times
+type Scrubber struct {
+	X, Y, Width, Height float64
+}
+
+// TimeAt maps a horizontal screen position on the bar to a time within duration
+func (s Scrubber) TimeAt(x float64, duration time.Duration) time.Duration {
+	if s.Width <= 0 {
+		return 0
+	}
+	fraction := math.Max(0, math.Min(1, (x-s.X)/s.Width))
+	return time.Duration(fraction * float64(duration))
+}
+
+// XAt maps a time within duration to a horizontal screen position on the bar
+func (s Scrubber) XAt(t, duration time.Duration) float64 {
+	if duration <= 0 {
+		return s.X
+	}
+	fraction := math.Max(0, math.Min(1, float64(t)/float64(duration)))
+	return s.X + fraction*s.Width
+}
+
+// Contains reports whether a screen point is on the bar (with some vertical slack for touch)
+func (s Scrubber) Contains(x, y float64) bool {
+	const slack = 10.0
+	return x >= s.X && x <= s.X+s.Width && y >= s.Y-slack && y <= s.Y+s.Height+slack
+}
+
+// ReplayState records the race and plays it back with a seekable timeline
+type ReplayState struct {
+	Frames   []ReplayFrame
+	Events   []ReplayEvent
+	Active   bool          // Whether replay mode is showing
+	Playing  bool          // Whether playback is advancing
+	Cursor   time.Duration // Current playback time
+	Scrubber Scrubber
+	GunTime  time.Duration // Time of the starting gun in this recording
+	Compare  *ReplayTrack  // Another run shown alongside, in sync by race time (nil = none)
+	dragging bool          // Whether the user is dragging the playhead
+}
+
+// ReplayTrack is a recorded run that can be overlaid on the replay of another
+type ReplayTrack struct {
+	Frames  []ReplayFrame
+	GunTime time.Duration // Time of the starting gun, to line runs up by race time
+	Events  []ReplayEvent // Race moments, to tell which leg the run was sailing
+}
+
+// The race is recorded at 10 Hz whatever the frame rate; playback interpolates between frames
+const replayRecordInterval = 100 * time.Millisecond
+
+// Recorded runs are thinned to one frame per interval when kept as a comparison track
+const replayTrackInterval = 250 * time.Millisecond
+
+// NewReplayState creates an empty replay with the scrubber along the bottom of the screen
+func NewReplayState(screenWidth, screenHeight int) *ReplayState {
+	return &ReplayState{Scrubber: newScrubber(screenWidth, screenHeight)}
+}
+
+// newScrubber places the timeline along the bottom of a screen of the given size
+func newScrubber(screenWidth, screenHeight int) Scrubber {
+	return Scrubber{
+		X:      120,
+		Y:      float64(screenHeight) - 50,
+		Width:  float64(screenWidth) - 240,
+		Height: 12,
+	}
+}
+
+// Record adds a boat state to the recording, keeping the first frame in each
+// replayRecordInterval. The newest state always ends the recording, replacing the one
+// before it within the same interval, so the replay runs right up to the last moment.
+func (r *ReplayState) Record(frame ReplayFrame) {
+	n := len(r.Frames)
+	if n >= 2 && r.Frames[n-1].Time/replayRecordInterval == r.Frames[n-2].Time/replayRecordInterval {
+		r.Frames[n-1] = frame
+		return
+	}
+	r.Frames = append(r.Frames, frame)
+}
+
+// AddEvent marks a key race moment on the timeline
+func (r *ReplayState) AddEvent(eventType ReplayEventType, t time.Duration) {
+	r.Events = append(r.Events, ReplayEvent{Type: eventType, Time: t})
+}
+
+// Duration returns the length of the recording
+func (r *ReplayState) Duration() time.Duration {
+	if len(r.Frames) == 0 {
+		return 0
+	}
+	return r.Frames[len(r.Frames)-1].Time
+}
+
+// Seek moves playback to the given time, clamped to the recording
+func (r *ReplayState) Seek(t time.Duration) {
+	if t < 0 {
+		t = 0
+	}
+	if d := r.Duration(); t > d {
+		t = d
+	}
+	r.Cursor = t
+}
+
+// Start enters replay mode from the beginning of the recording
+func (r *ReplayState) Start() {
+	r.Active = true
+	r.Playing = true
+	r.Seek(0)
+}
+
+// Stop leaves replay mode
+func (r *ReplayState) Stop() {
+	r.Active = false
+	r.Playing = false
+	r.dragging = false
+}
+
+// CurrentFrame returns the boat state at the playback cursor, interpolated between recorded frames
+func (r *ReplayState) CurrentFrame() ReplayFrame {
+	return r.FrameAt(r.Cursor)
+}
+
+// FrameAt returns the boat state at time t, interpolated between recorded frames
+func (r *ReplayState) FrameAt(t time.Duration) ReplayFrame {
+	return interpolateFrame(r.Frames, t)
+}
+
+// FrameAt returns the track's boat state at time t, interpolated between recorded frames
+func (rt *ReplayTrack) FrameAt(t time.Duration) ReplayFrame {
+	return interpolateFrame(rt.Frames, t)
+}
+
+// Track returns the recording as a comparison track, thinned to one frame per
+// replayTrackInterval (playback interpolates between them)
+func (r *ReplayState) Track() *ReplayTrack {
+	track := &ReplayTrack{GunTime: r.GunTime, Events: append([]ReplayEvent(nil), r.Events...)}
+	for i, frame := range r.Frames {
+		last := i == len(r.Frames)-1
+		if n := len(track.Frames); n == 0 || last || frame.Time-track.Frames[n-1].Time >= replayTrackInterval {
+			track.Frames = append(track.Frames, frame)
+		}
+	}
+	return track
+}
+
+// LegAt returns the leg and lap the run was sailing at time t, in the opponents' terms.
+// False for runs recorded without their race moments.
+func (rt *ReplayTrack) LegAt(t time.Duration) (AILeg, int, bool) {
+	if len(rt.Events) == 0 {
+		return AILegPreStart, 0, false
+	}
+	started := false
+	roundings, laps := 0, 0
+	for _, event := range rt.Events {
+		if event.Time > t {
+			continue
+		}
+		switch event.Type {
+		case ReplayEventStart:
+			started = true
+		case ReplayEventMarkRounding:
+			roundings++
+		case ReplayEventLap:
+			laps++
+		case ReplayEventFinish:
+			return AILegFinished, laps, true
+		}
+	}
+	switch {
+	case !started:
+		return AILegPreStart, 0, true
+	case roundings > laps:
+		return AILegRun, laps, true
+	default:
+		return AILegBeat, laps, true
+	}
+}
+
+// CompareFrameAt returns the comparison run's boat state at the same race time as time t
+// in this recording, lining the two runs up by their starting guns
+func (r *ReplayState) CompareFrameAt(t time.Duration) (ReplayFrame, bool) {
+	if r.Compare == nil || len(r.Compare.Frames) == 0 {
+		return ReplayFrame{}, false
+	}
+	raceTime := t - r.GunTime
+	return r.Compare.FrameAt(r.Compare.GunTime + raceTime), true
+}
+
+// CompareGap returns how far this run is ahead of the comparison run (negative = behind)
+// at time t, measured along the course toward target
+func (r *ReplayState) CompareGap(t time.Duration, target geometry.Point) (float64, bool) {
+	other, ok := r.CompareFrameAt(t)
+	if !ok {
+		return 0, false
+	}
+	frame := r.FrameAt(t)
+	return alongCourseGap(frame.Pos, other.Pos, geometry.Bearing(frame.Pos, target)), true
+}
+
+// EventTime returns when the first event of the given type happened, if it did
+func (r *ReplayState) EventTime(eventType ReplayEventType) (time.Duration, bool) {
+	for _, event := range r.Events {
+		if event.Type == eventType {
+			return event.Time, true
+		}
+	}
+	return 0, false
+}
+
+// interpolateFrame returns the boat state at time t, interpolated between recorded frames
+func interpolateFrame(frames []ReplayFrame, t time.Duration) ReplayFrame {
+	if len(frames) == 0 {
+		return ReplayFrame{}
+	}
+	if t <= frames[0].Time {
+		return frames[0]
+	}
+
+	for i := 1; i < len(frames); i++ {
+		next := frames[i]
+		if t > next.Time {
+			continue
+		}
+		prev := frames[i-1]
+		span := next.Time - prev.Time
+		if span <= 0 {
+			return next
+		}
+		f := float64(t-prev.Time) / float64(span)
+
+		// Interpolate heading along the shortest arc
+		dh := next.Heading - prev.Heading
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+		heading := math.Mod(prev.Heading+dh*f+360, 360)
+
+		return ReplayFrame{
+			Time: t,
+			Pos: geometry.Point{
+				X: prev.Pos.X + (next.Pos.X-prev.Pos.X)*f,
+				Y: prev.Pos.Y + (next.Pos.Y-prev.Pos.Y)*f,
+			},
+			Heading: heading,
+			Speed:   prev.Speed + (next.Speed-prev.Speed)*f,
+		}
+	}
+
+	return frames[len(frames)-1]
+}
+
+// Update advances playback and handles seeking by clicking or dragging on the scrubber.
+// inputScale is the number of render pixels per logical pixel (2 when supersampling).
+func (r *ReplayState) Update(deltaTime time.Duration, inputScale float64) {
+	if !r.Active {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		r.Playing = !r.Playing
+		// Restart from the beginning when playing again at the end
+		if r.Playing && r.Cursor >= r.Duration() {
+			r.Seek(0)
+		}
+	}
+
+	// Mouse click or drag on the timeline
+	cx, cy := ebiten.CursorPosition()
+	mx, my := float64(cx)/inputScale, float64(cy)/inputScale
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && r.Scrubber.Contains(mx, my) {
+		r.dragging = true
+	}
+	if r.dragging {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			r.Seek(r.Scrubber.TimeAt(mx, r.Duration()))
+		} else {
+			r.dragging = false
+		}
+	}
+
+	// Touch seeking
+	for _, touchID := range ebiten.AppendTouchIDs(nil) {
+		tx, ty := ebiten.TouchPosition(touchID)
+		x, y := float64(tx)/inputScale, float64(ty)/inputScale
+		if r.Scrubber.Contains(x, y) {
+			r.Seek(r.Scrubber.TimeAt(x, r.Duration()))
+		}
+	}
+
+	if r.Playing && !r.dragging {
+		r.Seek(r.Cursor + deltaTime)
+		if r.Cursor >= r.Duration() {
+			r.Playing = false
+		}
+	}
+}
+
+// eventColor returns the timeline marker color for an event type
+func eventColor(eventType ReplayEventType) color.RGBA {
+	switch eventType {
+	case ReplayEventStart:
+		return color.RGBA{0, 255, 0, 255} // Green
+	case ReplayEventMarkRounding:
+		return color.RGBA{255, 165, 0, 255} // Orange (like the upwind mark)
+	case ReplayEventFinish:
+		return color.RGBA{255, 255, 255, 255} // White
+	case ReplayEventLap:
+		return color.RGBA{0, 200, 255, 255} // Cyan
+	default:
+		return color.RGBA{255, 0, 0, 255} // Red for fouls
+	}
+}
+
+// Draw renders the scrubber bar with event markers and the playhead
+func (r *ReplayState) Draw(screen *ebiten.Image) {
+	if !r.Active {
+		return
+	}
+
+	s := r.Scrubber
+	duration := r.Duration()
+
+	// Background panel and bar
+	vector.DrawFilledRect(screen, float32(s.X-10), float32(s.Y-25), float32(s.Width+20), float32(s.Height+35), color.RGBA{0, 0, 0, 150}, false)
+	vector.DrawFilledRect(screen, float32(s.X), float32(s.Y), float32(s.Width), float32(s.Height), color.RGBA{80, 80, 80, 255}, false)
+
+	// Played portion
+	playheadX := s.XAt(r.Cursor, duration)
+	vector.DrawFilledRect(screen, float32(s.X), float32(s.Y), float32(playheadX-s.X), float32(s.Height), color.RGBA{100, 160, 220, 255}, false)
+
+	// Event markers
+	for _, event := range r.Events {
+		x := s.XAt(event.Time, duration)
+		vector.StrokeLine(screen, float32(x), float32(s.Y-4), float32(x), float32(s.Y+s.Height+4), 3, eventColor(event.Type), false)
+	}
+
+	// Playhead
+	vector.StrokeLine(screen, float32(playheadX), float32(s.Y-6), float32(playheadX), float32(s.Y+s.Height+6), 2, color.RGBA{255, 255, 0, 255}, false)
+
+	// Labels
+	state := "PLAYING"
+	if !r.Playing {
+		state = "PAUSED"
+	}
+	label := fmt.Sprintf("REPLAY %s  %s / %s   (Space: play/pause, V: exit)", state, formatReplayTime(r.Cursor), formatReplayTime(duration))
+	ebitenutil.DebugPrintAt(screen, label, int(s.X), int(s.Y)-20)
+}
+
+// formatReplayTime formats a timeline position as MM:SS
+func formatReplayTime(t time.Duration) string {
+	minutes := int(t.Minutes())
+	seconds := int(t.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
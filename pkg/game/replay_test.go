@@ -0,0 +1,182 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createTestReplay records a boat sailing north at 1 m per 100ms for 10 seconds
+func createTestReplay() *ReplayState {
+	r := NewReplayState(ScreenWidth, ScreenHeight)
+	for i := 0; i <= 100; i++ {
+		r.Record(ReplayFrame{
+			Time:    time.Duration(i) * 100 * time.Millisecond,
+			Pos:     geometry.Point{X: 1000, Y: 2500 - float64(i)},
+			Heading: 0,
+			Speed:   6,
+		})
+	}
+	r.AddEvent(ReplayEventStart, 2*time.Second)
+	r.AddEvent(ReplayEventFoul, 5*time.Second)
+	return r
+}
+
+func TestScrubber_TimeAt(t *testing.T) {
+	s := Scrubber{X: 100, Y: 600, Width: 1000, Height: 12}
+	duration := 10 * time.Second
+
+	tests := []struct {
+		name     string
+		x        float64
+		expected time.Duration
+	}{
+		{"Left edge", 100, 0},
+		{"Quarter", 350, 2500 * time.Millisecond},
+		{"Middle", 600, 5 * time.Second},
+		{"Right edge", 1100, 10 * time.Second},
+		{"Left of bar clamps to start", 20, 0},
+		{"Right of bar clamps to end", 1250, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.TimeAt(tt.x, duration)
+			if got != tt.expected {
+				t.Errorf("TimeAt(%.0f) = %v, expected %v", tt.x, got, tt.expected)
+			}
+			// Round trip back to screen position (within the bar)
+			if tt.x >= s.X && tt.x <= s.X+s.Width {
+				if x := s.XAt(got, duration); math.Abs(x-tt.x) > 0.001 {
+					t.Errorf("XAt(%v) = %.3f, expected %.0f", got, x, tt.x)
+				}
+			}
+		})
+	}
+}
+
+func TestReplay_SeekToScrubberClick(t *testing.T) {
+	r := createTestReplay()
+	r.Start()
+
+	// Click 75% along the timeline
+	clickX := r.Scrubber.X + r.Scrubber.Width*0.75
+	clickY := r.Scrubber.Y + r.Scrubber.Height/2
+	if !r.Scrubber.Contains(clickX, clickY) {
+		t.Fatal("Expected click to be on the scrubber")
+	}
+	r.Seek(r.Scrubber.TimeAt(clickX, r.Duration()))
+
+	if r.Cursor != 7500*time.Millisecond {
+		t.Fatalf("Expected cursor at 7.5s, got %v", r.Cursor)
+	}
+
+	// Boat position is interpolated between recorded frames
+	frame := r.CurrentFrame()
+	if math.Abs(frame.Pos.Y-2425) > 0.001 {
+		t.Errorf("Expected boat at Y=2425 at 7.5s, got %.3f", frame.Pos.Y)
+	}
+}
+
+func TestReplay_SeekClampsAndEventMarkers(t *testing.T) {
+	r := createTestReplay()
+
+	r.Seek(-time.Second)
+	if r.Cursor != 0 {
+		t.Errorf("Expected seek before start to clamp to 0, got %v", r.Cursor)
+	}
+
+	r.Seek(time.Minute)
+	if r.Cursor != r.Duration() {
+		t.Errorf("Expected seek past end to clamp to %v, got %v", r.Duration(), r.Cursor)
+	}
+
+	// Event markers land at their proportional positions
+	foulX := r.Scrubber.XAt(r.Events[1].Time, r.Duration())
+	expectedX := r.Scrubber.X + r.Scrubber.Width*0.5
+	if math.Abs(foulX-expectedX) > 0.001 {
+		t.Errorf("Expected foul marker at X=%.1f, got %.1f", expectedX, foulX)
+	}
+}
+
+func TestReplay_CompareLinesUpRunsAtTheGun(t *testing.T) {
+	r := createTestReplay()
+	r.GunTime = 2 * time.Second
+
+	// The comparison run's gun went 5s into its recording, and it sailed north at twice the speed
+	other := &ReplayTrack{GunTime: 5 * time.Second}
+	for i := 0; i <= 200; i++ {
+		other.Frames = append(other.Frames, ReplayFrame{
+			Time: time.Duration(i) * 100 * time.Millisecond,
+			Pos:  geometry.Point{X: 1010, Y: 2500 - 2*float64(i)},
+		})
+	}
+
+	if _, ok := r.CompareGap(4*time.Second, geometry.Point{X: 1000, Y: 0}); ok {
+		t.Fatal("Expected no gap without a comparison run")
+	}
+	r.Compare = other
+
+	// 2s after the gun: this run is at 2500-40, the other at its 7s mark, 2500-140
+	frame, ok := r.CompareFrameAt(4 * time.Second)
+	if !ok || math.Abs(frame.Pos.Y-2360) > 1e-9 {
+		t.Fatalf("Expected comparison boat at Y=2360, got %+v (ok=%v)", frame.Pos, ok)
+	}
+	gap, ok := r.CompareGap(4*time.Second, geometry.Point{X: 1000, Y: 0})
+	if !ok || math.Abs(gap-(-100)) > 0.5 {
+		t.Errorf("Expected to be about 100m behind toward the mark, got %.1f", gap)
+	}
+
+	// Toward a mark downwind the same positions put this run ahead
+	gap, _ = r.CompareGap(4*time.Second, geometry.Point{X: 1000, Y: 5000})
+	if gap < 99 {
+		t.Errorf("Expected to be about 100m ahead toward a mark behind, got %.1f", gap)
+	}
+}
+
+func TestReplay_RecordsAtFixedInterval(t *testing.T) {
+	r := NewReplayState(ScreenWidth, ScreenHeight)
+	frame := time.Second / 60
+	for i := 0; i <= 600; i++ {
+		r.Record(ReplayFrame{Time: time.Duration(i) * frame, Pos: geometry.Point{X: 1000, Y: 2500 - float64(i)}})
+	}
+
+	// Ten seconds at 60 fps keeps about ten frames a second, not 600
+	if n := len(r.Frames); n < 95 || n > 105 {
+		t.Errorf("Expected about 100 frames for 10s, got %d", n)
+	}
+	for i := 1; i < len(r.Frames)-1; i++ {
+		if r.Frames[i].Time/replayRecordInterval == r.Frames[i-1].Time/replayRecordInterval {
+			t.Fatalf("Expected one frame per %v, got %v and %v", replayRecordInterval, r.Frames[i-1].Time, r.Frames[i].Time)
+		}
+	}
+	// The last state is kept, and playback interpolates between the kept frames
+	if r.Duration() != 600*frame {
+		t.Errorf("Expected the recording to end at %v, got %v", 600*frame, r.Duration())
+	}
+	if got := r.FrameAt(150 * frame).Pos.Y; math.Abs(got-2350) > 1e-6 {
+		t.Errorf("Expected Y=2350 at frame 150, got %.3f", got)
+	}
+}
+
+func TestReplay_TrackIsThinned(t *testing.T) {
+	r := createTestReplay()
+	r.GunTime = 2 * time.Second
+	track := r.Track()
+
+	// Every third 100ms frame is at least 250ms on from the last kept one, plus the final frame
+	if len(track.Frames) != 35 {
+		t.Fatalf("Expected 35 frames in the track, got %d", len(track.Frames))
+	}
+	if track.GunTime != r.GunTime {
+		t.Errorf("Expected gun time %v, got %v", r.GunTime, track.GunTime)
+	}
+	if last := track.Frames[len(track.Frames)-1]; last.Time != r.Duration() {
+		t.Errorf("Expected the track to end at %v, got %v", r.Duration(), last.Time)
+	}
+	if got := track.FrameAt(1050 * time.Millisecond).Pos.Y; math.Abs(got-2489.5) > 1e-9 {
+		t.Errorf("Expected interpolated Y=2489.5, got %.2f", got)
+	}
+}
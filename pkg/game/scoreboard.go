@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -22,6 +24,8 @@ type RaceResult struct {
 	MarkRounded     bool      `json:"mark_rounded"`
 	DistanceSailed  float64   `json:"distance_sailed"`  // Total distance in meters
 	AverageSpeed    float64   `json:"average_speed"`    // Average speed in knots
+	Laps            int       `json:"laps,omitempty"`   // Times round the course
+	Course          string    `json:"course,omitempty"` // Course and boat class signature ("" = unknown)
 	Timestamp       time.Time `json:"timestamp"`
 }
 
@@ -48,17 +52,28 @@ type Scoreboard struct {
 
 	// Leaderboard data
 	leaderboard      []LeaderboardEntry
-	currentRaceEntry *LeaderboardEntry // Current race entry (may be outside top 10)
+	currentRaceEntry *LeaderboardEntry // Current race entry, pinned below the list when scrolled out of view
 	currentResult    *RaceResult
+	results          []RaceResult       // Every result the leaderboard was built from, for export
+	playerBests      map[string]float64 // Fastest time per player, looked up as the name is typed
+	period           LeaderboardPeriod  // Leaderboard tab: which races are ranked
+	scroll           int                // Index of the first leaderboard entry in view
 
 	// UI state
 	cursorBlink bool
 	lastBlink   time.Time
 	submitError string
 	isLoading   bool
+	exportNote  string // Outcome of the last CSV export, shown on the leaderboard
 
-	// Firebase integration (WASM only)
-	firebase *FirebaseClient
+	// Where results are submitted and the leaderboard comes from
+	backend LeaderboardBackend
+
+	// Names that aren't allowed on the leaderboard (nil allows any)
+	nameFilter *NameFilter
+
+	// Autosaved result storage (cleared once the result has been handled)
+	store KeyValueStore
 }
 
 type ScoreboardState int
@@ -69,20 +84,15 @@ const (
 	StateError
 )
 
-// NewScoreboard creates a new scoreboard instance
-func NewScoreboard() *Scoreboard {
-	var firebase *FirebaseClient
-	if IsWASM() {
-		firebase = NewFirebaseClient()
-	}
-
+// NewScoreboard creates a new scoreboard instance using the given leaderboard backend
+func NewScoreboard(backend LeaderboardBackend) *Scoreboard {
 	return &Scoreboard{
 		isVisible:        false,
 		state:            StateEnterName,
 		playerName:       "",
 		leaderboard:      make([]LeaderboardEntry, 0),
 		currentRaceEntry: nil,
-		firebase:         firebase,
+		backend:          backend,
 		lastBlink:        time.Now(),
 	}
 }
@@ -92,6 +102,7 @@ func (s *Scoreboard) Show(result *RaceResult) {
 	s.isVisible = true
 	s.state = StateEnterName
 	s.currentResult = result
+	s.loadPlayerBests()
 	s.playerName = ""
 	s.nameSubmitted = false
 	s.submitError = ""
@@ -106,52 +117,48 @@ func (s *Scoreboard) ShowLeaderboardOnly(result *RaceResult) {
 	s.nameSubmitted = false
 	s.submitError = ""
 	s.isLoading = false
-	// Load leaderboard directly
+	// Load leaderboard directly, showing it (empty while loading) rather than name entry
+	s.state = StateDisplayLeaderboard
 	s.loadLeaderboard()
 }
 
 // ShowWithTopCheck checks if the result is top 10, then shows name entry or leaderboard
 func (s *Scoreboard) ShowWithTopCheck(result *RaceResult) {
 	s.currentResult = result
+	s.loadPlayerBests()
 	s.playerName = ""
 	s.nameSubmitted = false
 	s.submitError = ""
 	s.isLoading = false
 
+	// Don't show scoreboard yet - wait until we know if it's top 10
+	s.isVisible = false
+	s.isLoading = true
+
 	// Load leaderboard to check ranking
-	if IsWASM() && s.firebase != nil {
-		// Don't show scoreboard yet - wait until we know if it's top 10
-		s.isVisible = false
-		s.isLoading = true
-
-		s.firebase.GetLeaderboard(func(results []RaceResult, err string) {
-			s.isLoading = false
-			if err != "" {
-				// On error, show name entry
-				s.isVisible = true
-				s.state = StateEnterName
-				return
-			}
-
-			// Check if result is top 10
-			isTop10 := s.checkIfTop10(result, results)
-
-			// Now show the scoreboard with appropriate state
+	s.backend.GetLeaderboard(s.period.Since(time.Now()), func(results []RaceResult, err string) {
+		s.isLoading = false
+		if err != "" {
+			// On error, show name entry
 			s.isVisible = true
-			if isTop10 {
-				// Show name entry for top 10
-				s.state = StateEnterName
-			} else {
-				// Skip name entry, just show leaderboard
-				s.createLeaderboard(results)
-				s.state = StateDisplayLeaderboard
-			}
-		})
-	} else {
-		// Standalone mode - always show name entry
+			s.state = StateEnterName
+			return
+		}
+
+		// Check if result is top 10
+		isTop10 := s.checkIfTop10(result, results)
+
+		// Now show the scoreboard with appropriate state
 		s.isVisible = true
-		s.state = StateEnterName
-	}
+		if isTop10 {
+			// Show name entry for top 10
+			s.state = StateEnterName
+		} else {
+			// Skip name entry, just show leaderboard
+			s.createLeaderboard(results)
+			s.state = StateDisplayLeaderboard
+		}
+	})
 }
 
 // checkIfTop10 determines if a race result would be in the top 10
@@ -160,13 +167,7 @@ func (s *Scoreboard) checkIfTop10(result *RaceResult, allResults []RaceResult) b
 		return false
 	}
 
-	// Filter completed races only
-	completed := make([]RaceResult, 0)
-	for _, r := range allResults {
-		if r.MarkRounded {
-			completed = append(completed, r)
-		}
-	}
+	completed := s.completedInPeriod(allResults)
 
 	// Add current result to the list
 	completed = append(completed, *result)
@@ -187,14 +188,28 @@ func (s *Scoreboard) checkIfTop10(result *RaceResult, allResults []RaceResult) b
 	return false
 }
 
+// pollBackend runs the callbacks of a leaderboard that answers in the background
+func (s *Scoreboard) pollBackend() {
+	if poller, ok := s.backend.(interface{ Poll() }); ok {
+		poller.Poll()
+	}
+}
+
+// savedOnline reports whether results go to an online leaderboard rather than this device
+func (s *Scoreboard) savedOnline() bool {
+	return s.backend.Online()
+}
+
 // Hide closes the scoreboard
 func (s *Scoreboard) Hide() {
+	s.clearPendingResult()
 	s.isVisible = false
 	s.state = StateEnterName
 	s.playerName = ""
 	s.nameSubmitted = false
 	s.leaderboard = make([]LeaderboardEntry, 0)
 	s.currentRaceEntry = nil
+	s.exportNote = ""
 }
 
 // IsVisible returns whether the scoreboard is currently displayed
@@ -209,6 +224,9 @@ func (s *Scoreboard) IsCapturingInput() bool {
 
 // Update handles input and state updates
 func (s *Scoreboard) Update() {
+	// Answers from a background leaderboard can arrive while the scoreboard is hidden
+	// (ShowWithTopCheck waits for one before showing it)
+	s.pollBackend()
 	if !s.isVisible {
 		return
 	}
@@ -230,32 +248,21 @@ func (s *Scoreboard) Update() {
 // updateNameInput handles player name entry
 func (s *Scoreboard) updateNameInput() {
 	// Handle text input
-	inputChars := ebiten.AppendInputChars(nil)
-	for _, ch := range inputChars {
-		if len(s.playerName) < 20 && isValidNameChar(ch) {
-			s.playerName += string(ch)
-		}
-	}
+	s.playerName = appendNameChars(s.playerName, ebiten.AppendInputChars(nil))
 
 	// Handle backspace
-	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.playerName) > 0 {
-		s.playerName = s.playerName[:len(s.playerName)-1]
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		s.playerName = deleteLastRune(s.playerName)
 	}
 
-	// Handle enter key to submit name
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(strings.TrimSpace(s.playerName)) > 0 {
+	// Handle enter key to submit name (once: not again while it is being submitted)
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(strings.TrimSpace(s.playerName)) > 0 && !s.isLoading {
 		s.submitScore()
 	}
 
-	// Handle escape to skip submission (standalone mode)
+	// Handle escape to show leaderboard without submitting
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		if IsWASM() {
-			// In WASM, show leaderboard without submitting
-			s.loadLeaderboard()
-		} else {
-			// In standalone, just close
-			s.Hide()
-		}
+		s.loadLeaderboard()
 	}
 }
 
@@ -265,212 +272,220 @@ func (s *Scoreboard) updateLeaderboardDisplay() {
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
 		s.Hide()
 	}
+
+	// Handle up/down to scroll through the field
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		s.scrollBy(-1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		s.scrollBy(1)
+	}
+
+	// Handle left/right to switch between the period tabs
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		s.period = (s.period + leaderboardPeriods - 1) % leaderboardPeriods
+		s.loadLeaderboard()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		s.period = (s.period + 1) % leaderboardPeriods
+		s.loadLeaderboard()
+	}
+
+	// Handle X to export the results as CSV
+	if inpututil.IsKeyJustPressed(ebiten.KeyX) {
+		s.exportLeaderboard()
+	}
 }
 
-// submitScore submits the current race result to Firebase (WASM only)
+// submitScore submits the current race result to the leaderboard backend
 func (s *Scoreboard) submitScore() {
 	name := strings.TrimSpace(s.playerName)
 	if len(name) == 0 {
 		return
 	}
+	if s.nameFilter.Blocked(name) {
+		s.submitError = "Please choose a different name"
+		return
+	}
 
 	s.currentResult.PlayerName = name
 	s.currentResult.Timestamp = time.Now()
 
-	if IsWASM() && s.firebase != nil {
-		s.isLoading = true
-		s.submitError = ""
-
-		// Submit to Firebase
-		s.firebase.SubmitScore(s.currentResult, func(success bool, err string) {
-			s.isLoading = false
-			if success {
-				s.nameSubmitted = true
-				s.loadLeaderboard()
-			} else {
-				s.submitError = err
-			}
-		})
-	} else {
-		// Standalone mode - just show local leaderboard
-		s.nameSubmitted = true
-		s.createLocalLeaderboard()
-		s.state = StateDisplayLeaderboard
-	}
+	s.isLoading = true
+	s.submitError = ""
+
+	s.backend.SubmitScore(s.currentResult, func(success bool, err string) {
+		s.isLoading = false
+		if success {
+			s.nameSubmitted = true
+			s.clearPendingResult()
+			s.recordPlayerBest()
+			s.loadLeaderboard()
+		} else {
+			s.submitError = err
+		}
+	})
 }
 
-// loadLeaderboard loads the leaderboard from Firebase (WASM only)
-func (s *Scoreboard) loadLeaderboard() {
-	if IsWASM() && s.firebase != nil {
-		s.isLoading = true
-		s.firebase.GetLeaderboard(func(results []RaceResult, err string) {
-			s.isLoading = false
-			if err != "" {
-				s.submitError = err
-				s.state = StateError
-			} else {
-				s.createLeaderboard(results)
-				s.state = StateDisplayLeaderboard
-			}
-		})
-	} else {
-		s.createLocalLeaderboard()
-		s.state = StateDisplayLeaderboard
+// loadPlayerBests reads the stored personal bests on the result's course for the name entry screen
+func (s *Scoreboard) loadPlayerBests() {
+	s.playerBests = nil
+	if s.store != nil {
+		s.playerBests = LoadPlayerBests(s.store, s.currentResult.Course)
 	}
 }
 
-// createLeaderboard creates leaderboard entries from race results
-func (s *Scoreboard) createLeaderboard(results []RaceResult) {
-	// Filter completed races only
-	completed := make([]RaceResult, 0)
-	for _, result := range results {
-		if result.MarkRounded {
-			completed = append(completed, result)
-		}
+// recordPlayerBest keeps the submitted time if it is the player's fastest completed race
+func (s *Scoreboard) recordPlayerBest() {
+	if s.store == nil || !s.currentResult.MarkRounded {
+		return
 	}
+	UpdatePlayerBest(s.store, s.currentResult.Course, s.currentResult.PlayerName, s.currentResult.RaceTimeSeconds)
+}
 
-	// Sort by race time (ascending)
-	sort.Slice(completed, func(i, j int) bool {
-		return completed[i].RaceTimeSeconds < completed[j].RaceTimeSeconds
-	})
-
-	// Find current race in the completed results
-	var currentRaceResult *RaceResult
-	var currentRaceRank int
-	if s.currentResult != nil && s.currentResult.MarkRounded {
-		for i, result := range completed {
-			// Match by player name and exact race time (to identify the specific race)
-			if result.PlayerName == s.currentResult.PlayerName &&
-				fmt.Sprintf("%.2f", result.RaceTimeSeconds) == fmt.Sprintf("%.2f", s.currentResult.RaceTimeSeconds) {
-				currentRaceResult = &result
-				currentRaceRank = i + 1
-				break
-			}
-		}
+// playerBestReadout compares the new time with the typed name's personal best
+// ("Personal best: 01:38 (-3.2s)"), or is empty with no name or no completed race
+func (s *Scoreboard) playerBestReadout() string {
+	if s.currentResult == nil || !s.currentResult.MarkRounded || strings.TrimSpace(s.playerName) == "" {
+		return ""
 	}
-
-	// Create display entries (top 10)
-	s.leaderboard = make([]LeaderboardEntry, 0)
-	maxEntries := 10
-	if len(completed) < maxEntries {
-		maxEntries = len(completed)
+	best, ok := s.playerBests[playerKey(s.playerName)]
+	if !ok {
+		return "First race for this name - a new personal best!"
 	}
+	minutes := int(best) / 60
+	seconds := int(best) % 60
+	return fmt.Sprintf("Personal best: %02d:%02d (%+.1fs)", minutes, seconds, s.currentResult.RaceTimeSeconds-best)
+}
 
-	for i := 0; i < maxEntries; i++ {
-		result := completed[i]
-
-		// Format race time
-		minutes := int(result.RaceTimeSeconds) / 60
-		seconds := int(result.RaceTimeSeconds) % 60
-		centiseconds := int((result.RaceTimeSeconds - float64(int(result.RaceTimeSeconds))) * 100)
-		raceTimeStr := fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, centiseconds)
-
-		// Format seconds late
-		lateStr := fmt.Sprintf("%.1f", result.SecondsLate)
-		if result.SecondsLate < 0 {
-			lateStr = "Early"
-		}
-
-		// Check if this is the current race
-		isCurrentRace := currentRaceResult != nil &&
-			result.PlayerName == currentRaceResult.PlayerName &&
-			fmt.Sprintf("%.2f", result.RaceTimeSeconds) == fmt.Sprintf("%.2f", currentRaceResult.RaceTimeSeconds)
+// clearPendingResult drops the autosaved result once it has been submitted or dismissed
+func (s *Scoreboard) clearPendingResult() {
+	if s.store != nil {
+		ClearPendingResult(s.store)
+	}
+}
 
-		// Format distance and average speed (handle old records without distance)
-		distanceStr := "-"
-		avgSpeedStr := "-"
-		if result.DistanceSailed > 0 {
-			distanceStr = fmt.Sprintf("%.0fm", result.DistanceSailed)
-		}
-		if result.AverageSpeed > 0 {
-			avgSpeedStr = fmt.Sprintf("%.1fkt", result.AverageSpeed)
+// loadLeaderboard loads the leaderboard from the backend
+func (s *Scoreboard) loadLeaderboard() {
+	s.isLoading = true
+	s.backend.GetLeaderboard(s.period.Since(time.Now()), func(results []RaceResult, err string) {
+		s.isLoading = false
+		if err != "" {
+			s.submitError = err
+			s.state = StateError
+		} else {
+			s.createLeaderboard(results)
+			s.state = StateDisplayLeaderboard
 		}
+	})
+}
 
-		entry := LeaderboardEntry{
-			Rank:          i + 1,
-			PlayerName:    result.PlayerName,
-			RaceTime:      raceTimeStr,
-			SecondsLate:   lateStr,
-			Distance:      distanceStr,
-			AvgSpeed:      avgSpeedStr,
-			IsCurrentRace: isCurrentRace,
+// completedInPeriod filters the results to completed races sailed in the selected tab's period
+func (s *Scoreboard) completedInPeriod(results []RaceResult) []RaceResult {
+	since := s.period.Since(time.Now())
+	completed := make([]RaceResult, 0)
+	for _, result := range results {
+		if result.MarkRounded && !result.Timestamp.Before(since) {
+			completed = append(completed, result)
 		}
-
-		s.leaderboard = append(s.leaderboard, entry)
 	}
+	return completed
+}
 
-	// Create separate current race entry if it's outside top 10
-	s.currentRaceEntry = nil
-	if currentRaceResult != nil && currentRaceRank > 10 {
-		minutes := int(currentRaceResult.RaceTimeSeconds) / 60
-		seconds := int(currentRaceResult.RaceTimeSeconds) % 60
-		centiseconds := int((currentRaceResult.RaceTimeSeconds - float64(int(currentRaceResult.RaceTimeSeconds))) * 100)
-		raceTimeStr := fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, centiseconds)
-
-		lateStr := fmt.Sprintf("%.1f", currentRaceResult.SecondsLate)
-		if currentRaceResult.SecondsLate < 0 {
-			lateStr = "Early"
-		}
+// createLeaderboard creates leaderboard entries from race results
+func (s *Scoreboard) createLeaderboard(results []RaceResult) {
+	s.results = results
+	completed := s.completedInPeriod(results)
 
-		// Format distance and average speed
-		distanceStr := "-"
-		avgSpeedStr := "-"
-		if currentRaceResult.DistanceSailed > 0 {
-			distanceStr = fmt.Sprintf("%.0fm", currentRaceResult.DistanceSailed)
-		}
-		if currentRaceResult.AverageSpeed > 0 {
-			avgSpeedStr = fmt.Sprintf("%.1fkt", currentRaceResult.AverageSpeed)
-		}
+	// Sort by race time (ascending)
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].RaceTimeSeconds < completed[j].RaceTimeSeconds
+	})
 
-		s.currentRaceEntry = &LeaderboardEntry{
-			Rank:          currentRaceRank,
-			PlayerName:    currentRaceResult.PlayerName,
-			RaceTime:      raceTimeStr,
-			SecondsLate:   lateStr,
-			Distance:      distanceStr,
-			AvgSpeed:      avgSpeedStr,
-			IsCurrentRace: true,
+	// Create display entries for the whole field, scrolled to the top
+	s.leaderboard = make([]LeaderboardEntry, 0, len(completed))
+	s.currentRaceEntry = nil
+	s.scroll = 0
+	for i, result := range completed {
+		// Match by player name and exact race time (to identify the specific race)
+		isCurrentRace := s.currentResult != nil && s.currentResult.MarkRounded &&
+			result.PlayerName == s.currentResult.PlayerName &&
+			fmt.Sprintf("%.2f", result.RaceTimeSeconds) == fmt.Sprintf("%.2f", s.currentResult.RaceTimeSeconds)
+
+		s.leaderboard = append(s.leaderboard, newLeaderboardEntry(i+1, result, isCurrentRace))
+		if isCurrentRace && s.currentRaceEntry == nil {
+			s.currentRaceEntry = &s.leaderboard[len(s.leaderboard)-1]
 		}
 	}
-} // createLocalLeaderboard creates a local leaderboard for standalone mode
-func (s *Scoreboard) createLocalLeaderboard() {
-	if s.currentResult == nil {
-		return
-	}
+}
 
-	// Format current player's time
-	minutes := int(s.currentResult.RaceTimeSeconds) / 60
-	seconds := int(s.currentResult.RaceTimeSeconds) % 60
-	centiseconds := int((s.currentResult.RaceTimeSeconds - float64(int(s.currentResult.RaceTimeSeconds))) * 100)
+// newLeaderboardEntry formats a race result for display
+func newLeaderboardEntry(rank int, result RaceResult, isCurrentRace bool) LeaderboardEntry {
+	// Format race time
+	minutes := int(result.RaceTimeSeconds) / 60
+	seconds := int(result.RaceTimeSeconds) % 60
+	centiseconds := int((result.RaceTimeSeconds - float64(int(result.RaceTimeSeconds))) * 100)
 	raceTimeStr := fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, centiseconds)
 
-	lateStr := fmt.Sprintf("%.1f", s.currentResult.SecondsLate)
-	if s.currentResult.SecondsLate < 0 {
+	// Format seconds late
+	lateStr := fmt.Sprintf("%.1f", result.SecondsLate)
+	if result.SecondsLate < 0 {
 		lateStr = "Early"
 	}
 
-	// Format distance and average speed
+	// Format distance and average speed (handle old records without distance)
 	distanceStr := "-"
 	avgSpeedStr := "-"
-	if s.currentResult.DistanceSailed > 0 {
-		distanceStr = fmt.Sprintf("%.0fm", s.currentResult.DistanceSailed)
+	if result.DistanceSailed > 0 {
+		distanceStr = fmt.Sprintf("%.0fm", result.DistanceSailed)
 	}
-	if s.currentResult.AverageSpeed > 0 {
-		avgSpeedStr = fmt.Sprintf("%.1fkt", s.currentResult.AverageSpeed)
+	if result.AverageSpeed > 0 {
+		avgSpeedStr = fmt.Sprintf("%.1fkt", result.AverageSpeed)
 	}
 
-	s.leaderboard = []LeaderboardEntry{
-		{
-			Rank:          1,
-			PlayerName:    s.currentResult.PlayerName,
-			RaceTime:      raceTimeStr,
-			SecondsLate:   lateStr,
-			Distance:      distanceStr,
-			AvgSpeed:      avgSpeedStr,
-			IsCurrentRace: true,
-		},
+	return LeaderboardEntry{
+		Rank:          rank,
+		PlayerName:    result.PlayerName,
+		RaceTime:      raceTimeStr,
+		SecondsLate:   lateStr,
+		Distance:      distanceStr,
+		AvgSpeed:      avgSpeedStr,
+		IsCurrentRace: isCurrentRace,
 	}
-	s.currentRaceEntry = nil // No separate entry needed for local mode
+}
+
+// Leaderboard entries shown at once; Up/Down scrolls through the rest
+const leaderboardPageSize = 10
+
+// scrollBy moves the leaderboard window by delta entries, keeping it within the field
+func (s *Scoreboard) scrollBy(delta int) {
+	s.scroll = max(0, min(s.scroll+delta, len(s.leaderboard)-leaderboardPageSize))
+}
+
+// visibleEntries returns the window of entries scrolled into view
+func (s *Scoreboard) visibleEntries() []LeaderboardEntry {
+	end := min(s.scroll+leaderboardPageSize, len(s.leaderboard))
+	return s.leaderboard[s.scroll:end]
+}
+
+// pinnedEntry returns the current race when it is scrolled out of view, to show below the list
+func (s *Scoreboard) pinnedEntry() *LeaderboardEntry {
+	if s.currentRaceEntry == nil {
+		return nil
+	}
+	if rank := s.currentRaceEntry.Rank; rank > s.scroll && rank <= s.scroll+leaderboardPageSize {
+		return nil
+	}
+	return s.currentRaceEntry
+}
+
+// scrollFooter says which entries are in view ("Showing 11-20 of 47"), or is empty when
+// the whole field fits
+func (s *Scoreboard) scrollFooter() string {
+	if len(s.leaderboard) <= leaderboardPageSize {
+		return ""
+	}
+	return fmt.Sprintf("Showing %d-%d of %d", s.scroll+1, s.scroll+len(s.visibleEntries()), len(s.leaderboard))
 }
 
 // Draw renders the scoreboard overlay
@@ -480,7 +495,7 @@ func (s *Scoreboard) Draw(screen *ebiten.Image) {
 	}
 
 	// Draw semi-transparent overlay
-	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 200}, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 200}, false)
 
 	switch s.state {
 	case StateEnterName:
@@ -511,6 +526,11 @@ func (s *Scoreboard) drawNameEntry(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, timeText, centerX-70, centerY-90)
 	}
 
+	// Personal best for the name being typed
+	if readout := s.playerBestReadout(); readout != "" {
+		ebitenutil.DebugPrintAt(screen, readout, centerX-len(readout)*3, centerY-70)
+	}
+
 	// Name entry prompt
 	prompt := "Enter your name:"
 	ebitenutil.DebugPrintAt(screen, prompt, centerX-60, centerY-40)
@@ -529,15 +549,10 @@ func (s *Scoreboard) drawNameEntry(screen *ebiten.Image) {
 	if s.cursorBlink {
 		nameText += "|"
 	}
-	ebitenutil.DebugPrintAt(screen, nameText, fieldX+5, fieldY+5)
+	drawName(screen, nameText, fieldX+5, fieldY+5)
 
 	// Instructions
-	var instructions string
-	if IsWASM() {
-		instructions = "Press ENTER to submit • ESC to view leaderboard only"
-	} else {
-		instructions = "Press ENTER to continue • ESC to skip"
-	}
+	instructions := "Press ENTER to submit | ESC to view leaderboard only"
 	ebitenutil.DebugPrintAt(screen, instructions, centerX-130, centerY+40)
 
 	// Loading indicator
@@ -551,6 +566,19 @@ func (s *Scoreboard) drawNameEntry(screen *ebiten.Image) {
 	}
 }
 
+// periodTabs lists the leaderboard tabs with the selected one bracketed
+func (s *Scoreboard) periodTabs() string {
+	tabs := make([]string, 0, leaderboardPeriods)
+	for p := LeaderboardPeriod(0); p < leaderboardPeriods; p++ {
+		if p == s.period {
+			tabs = append(tabs, "["+p.String()+"]")
+		} else {
+			tabs = append(tabs, " "+p.String()+" ")
+		}
+	}
+	return "< " + strings.Join(tabs, " ") + " >"
+}
+
 // drawLeaderboard draws the leaderboard display
 func (s *Scoreboard) drawLeaderboard(screen *ebiten.Image) {
 	bounds := screen.Bounds()
@@ -561,6 +589,15 @@ func (s *Scoreboard) drawLeaderboard(screen *ebiten.Image) {
 	title := "🏆 LEADERBOARD 🏆"
 	ebitenutil.DebugPrintAt(screen, title, centerX-80, startY-30)
 
+	// Period tabs
+	tabs := s.periodTabs()
+	ebitenutil.DebugPrintAt(screen, tabs, centerX-len(tabs)*3, startY-5)
+
+	// Loading indicator while the results are on their way
+	if s.isLoading {
+		ebitenutil.DebugPrintAt(screen, "Loading...", centerX+150, startY-30)
+	}
+
 	// Headers
 	headerY := startY + 20
 	ebitenutil.DebugPrintAt(screen, "Rank", centerX-180, headerY)
@@ -574,8 +611,8 @@ func (s *Scoreboard) drawLeaderboard(screen *ebiten.Image) {
 	lineY := float32(headerY + 15)
 	vector.StrokeLine(screen, float32(centerX-190), lineY, float32(centerX+220), lineY, 1, color.RGBA{255, 255, 255, 255}, false)
 
-	// Leaderboard entries
-	for i, entry := range s.leaderboard {
+	// Leaderboard entries scrolled into view
+	for i, entry := range s.visibleEntries() {
 		entryY := startY + 50 + (i * 25)
 
 		// Highlight current race
@@ -588,20 +625,17 @@ func (s *Scoreboard) drawLeaderboard(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", entry.Rank), centerX-180, entryY)
 
 		// Truncate long names
-		displayName := entry.PlayerName
-		if len(displayName) > 12 {
-			displayName = displayName[:12] + "..."
-		}
-		ebitenutil.DebugPrintAt(screen, displayName, centerX-120, entryY)
+		displayName := truncateName(entry.PlayerName, displayNameLength)
+		drawName(screen, displayName, centerX-120, entryY)
 		ebitenutil.DebugPrintAt(screen, entry.RaceTime, centerX-20, entryY)
 		ebitenutil.DebugPrintAt(screen, entry.SecondsLate, centerX+60, entryY)
 		ebitenutil.DebugPrintAt(screen, entry.Distance, centerX+120, entryY)
 		ebitenutil.DebugPrintAt(screen, entry.AvgSpeed, centerX+170, entryY)
 	}
 
-	// Draw separator and current race entry if it's outside top 10
-	if s.currentRaceEntry != nil {
-		separatorY := startY + 50 + (len(s.leaderboard) * 25) + 10
+	// Draw separator and current race entry if it's scrolled out of view
+	if pinned := s.pinnedEntry(); pinned != nil {
+		separatorY := startY + 50 + (len(s.visibleEntries()) * 25) + 10
 
 		// Draw separator dots
 		ebitenutil.DebugPrintAt(screen, "...", centerX-10, separatorY)
@@ -614,26 +648,34 @@ func (s *Scoreboard) drawLeaderboard(screen *ebiten.Image) {
 		vector.DrawFilledRect(screen, float32(centerX-195), highlightY, 420, 20, color.RGBA{173, 216, 230, 150}, false)
 
 		// Draw entry data
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", s.currentRaceEntry.Rank), centerX-180, entryY)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", pinned.Rank), centerX-180, entryY)
 
 		// Truncate long names
-		displayName := s.currentRaceEntry.PlayerName
-		if len(displayName) > 12 {
-			displayName = displayName[:12] + "..."
-		}
-		ebitenutil.DebugPrintAt(screen, displayName, centerX-120, entryY)
-		ebitenutil.DebugPrintAt(screen, s.currentRaceEntry.RaceTime, centerX-20, entryY)
-		ebitenutil.DebugPrintAt(screen, s.currentRaceEntry.SecondsLate, centerX+60, entryY)
-		ebitenutil.DebugPrintAt(screen, s.currentRaceEntry.Distance, centerX+120, entryY)
-		ebitenutil.DebugPrintAt(screen, s.currentRaceEntry.AvgSpeed, centerX+170, entryY)
+		displayName := truncateName(pinned.PlayerName, displayNameLength)
+		drawName(screen, displayName, centerX-120, entryY)
+		ebitenutil.DebugPrintAt(screen, pinned.RaceTime, centerX-20, entryY)
+		ebitenutil.DebugPrintAt(screen, pinned.SecondsLate, centerX+60, entryY)
+		ebitenutil.DebugPrintAt(screen, pinned.Distance, centerX+120, entryY)
+		ebitenutil.DebugPrintAt(screen, pinned.AvgSpeed, centerX+170, entryY)
+	}
+
+	// Which part of the field is in view
+	if footer := s.scrollFooter(); footer != "" {
+		ebitenutil.DebugPrintAt(screen, footer, centerX-len(footer)*3, bounds.Dy()-75)
 	} // Instructions
 	var instructions string
-	if IsWASM() {
-		instructions = "Press ENTER or ESC to continue • Data saved online"
+	if s.savedOnline() {
+		instructions = "Press ENTER or ESC to continue | Data saved online"
 	} else {
-		instructions = "Press ENTER or ESC to continue • Local data only"
+		instructions = "Press ENTER or ESC to continue | Local data only"
 	}
 	ebitenutil.DebugPrintAt(screen, instructions, centerX-140, bounds.Dy()-50)
+
+	exportLine := "UP/DOWN - Scroll | LEFT/RIGHT - Period | X - Export CSV"
+	if s.exportNote != "" {
+		exportLine = s.exportNote
+	}
+	ebitenutil.DebugPrintAt(screen, exportLine, centerX-140, bounds.Dy()-30)
 }
 
 // drawError draws the error screen
@@ -648,10 +690,43 @@ func (s *Scoreboard) drawError(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Press ESC to continue", centerX-70, centerY+30)
 }
 
-// isValidNameChar checks if a character is valid for player names
+// Name lengths in characters (runes, so accented and CJK names count like any other)
+const (
+	maxNameLength     = 20 // Longest name that can be typed
+	displayNameLength = 12 // Longer names are truncated on the leaderboard
+)
+
+// isValidNameChar checks if a character is valid for player names: letters and digits
+// in any script, accents, spaces, hyphens and underscores (drawName shows the ones the
+// debug font lacks)
 func isValidNameChar(ch rune) bool {
-	return (ch >= 'a' && ch <= 'z') ||
-		(ch >= 'A' && ch <= 'Z') ||
-		(ch >= '0' && ch <= '9') ||
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || unicode.IsMark(ch) ||
 		ch == ' ' || ch == '-' || ch == '_'
 }
+
+// appendNameChars adds the valid typed characters to the name, up to maxNameLength
+func appendNameChars(name string, chars []rune) string {
+	count := utf8.RuneCountInString(name)
+	for _, ch := range chars {
+		if count < maxNameLength && isValidNameChar(ch) {
+			name += string(ch)
+			count++
+		}
+	}
+	return name
+}
+
+// deleteLastRune removes the last character of the name, however many bytes it takes
+func deleteLastRune(name string) string {
+	_, size := utf8.DecodeLastRuneInString(name)
+	return name[:len(name)-size]
+}
+
+// truncateName shortens a name to its first n characters plus "...", cutting on rune boundaries
+func truncateName(name string, n int) string {
+	runes := []rune(name)
+	if len(runes) <= n {
+		return name
+	}
+	return string(runes[:n]) + "..."
+}
@@ -0,0 +1,71 @@
+package game
+
+import "testing"
+
+func TestNameInput_UnicodeNames(t *testing.T) {
+	tests := []struct {
+		typed string
+		want  string
+	}{
+		{"José Müller", "José Müller"},
+		{"Åsa-Lena_Öberg", "Åsa-Lena_Öberg"},
+		{"山田太郎", "山田太郎"},
+		{"Ζωή 7", "Ζωή 7"},
+		{"Bad!Name<>", "BadName"}, // Punctuation is still dropped
+	}
+	for _, tt := range tests {
+		if got := appendNameChars("", []rune(tt.typed)); got != tt.want {
+			t.Errorf("Typing %q gave %q, want %q", tt.typed, got, tt.want)
+		}
+	}
+}
+
+func TestNameInput_LengthInCharacters(t *testing.T) {
+	// Twenty three-byte characters fit, the twenty-first doesn't
+	name := appendNameChars("", []rune("漢字漢字漢字漢字漢字漢字漢字漢字漢字漢字漢"))
+	if got := []rune(name); len(got) != maxNameLength {
+		t.Errorf("Expected %d characters, got %d (%q)", maxNameLength, len(got), name)
+	}
+
+	// Backspace removes a whole character
+	if got := deleteLastRune("Zoë"); got != "Zo" {
+		t.Errorf("Expected backspace to remove ë, got %q", got)
+	}
+	if got := deleteLastRune("田中"); got != "田" {
+		t.Errorf("Expected backspace to remove 中, got %q", got)
+	}
+	if got := deleteLastRune(""); got != "" {
+		t.Errorf("Expected backspace on an empty name to do nothing, got %q", got)
+	}
+}
+
+func TestTruncateName_RuneBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Skipper", "Skipper"},
+		{"Bartholomew Smith", "Bartholomew ..."},
+		{"Ångström-Éclair", "Ångström-Écl..."},
+		{"東京湾ヨットクラブの山田太郎さん", "東京湾ヨットクラブの山田..."},
+	}
+	for _, tt := range tests {
+		if got := truncateName(tt.name, displayNameLength); got != tt.want {
+			t.Errorf("truncateName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDebugFontCanDraw(t *testing.T) {
+	if !debugFontCanDraw("José Müller") {
+		t.Error("Expected Latin-1 names to use the debug font")
+	}
+	for _, name := range []string{"山田太郎", "Ζωή 7"} {
+		if debugFontCanDraw(name) {
+			t.Errorf("Expected %q to need the name font", name)
+		}
+	}
+	if nameFace() == nil {
+		t.Error("Expected the name font to load")
+	}
+}
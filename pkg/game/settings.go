@@ -0,0 +1,47 @@
+package game
+
+// Settings holds player preferences that survive restarts
+type Settings struct {
+	ShowWindLabels   bool          // Print numeric wind speed next to each wind barb
+	ShowApparentWind bool          // Show apparent wind alongside true wind on the compass rose
+	ShowWake         bool          // Draw a speed-scaled wake behind the boat
+	ShowVMC          bool          // Show VMG to the next mark alongside VMG to the wind
+	ShowLineSag      bool          // Show how far a mid-line start sags behind the line ends
+	ShowTargetSpeed  bool          // Show the polar target speed next to the actual speed
+	BulletTime       bool          // Easy mode: slow down before the gun and at the mark
+	Opponents        int           // Number of AI opponents in the fleet
+	AISkill          AISkill       // Skill tier of the AI opponents
+	Camera           CameraMode    // How the camera frames the course
+	ShowHelpOnPause  bool          // Show the full help when paused (off = a one-line pause indicator)
+	Zoom             float64       // World zoom, screen pixels per meter
+	MouseSteering    bool          // Steer toward the mouse cursor (desktop)
+	Controls         ControlLayout // Touch button size and placement
+
+	// Training aid: flash TACK NOW on reaching the layline to the upwind mark
+	ShowTackNow bool
+	// Silence the start sequence beeps
+	Muted bool
+	// Don't save a finished result until the scoreboard has handled it, so a crash or
+	// closed tab before then loses it
+	NoAutosave bool
+}
+
+// DefaultSettings returns the settings for a first launch
+func DefaultSettings() Settings {
+	return Settings{
+		ShowWindLabels:   false, // Off by default to avoid clutter
+		ShowApparentWind: false,
+		ShowWake:         true,
+		ShowVMC:          false,
+		ShowLineSag:      false,
+		ShowTargetSpeed:  true,
+		BulletTime:       false,
+		Opponents:        0, // Solo racing against the clock
+		AISkill:          AISkillClub,
+		Camera:           CameraFollow,
+		ShowHelpOnPause:  true,
+		Zoom:             1,
+		Controls:         DefaultControlLayout(),
+		ShowTackNow:      true,
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+)
+
+// Game time covered by one fixed physics step (Boat.Update is tuned for 60 FPS)
+const simStepDuration = time.Second / 60
+
+// SteerInput is the helm for a simulation step: -1 hard to port, +1 hard to starboard, 0 straight
+type SteerInput struct {
+	Turn float64
+}
+
+// Simulator runs a race without a window or any drawing, so tactics can be scripted and
+// the physics tuned from tests. It drives the same wind, boat physics, start, mark
+// rounding and finish logic as the game loop, on game time only.
+type Simulator struct {
+	game  *GameState
+	steps int // Physics steps run so far
+}
+
+// NewSimulator sets up a race on course sailing the given boat class (nil = keelboat).
+// Results and personal bests are kept in memory only, and the start sequence makes no sound.
+func NewSimulator(course CourseConfig, class *objects.BoatClass) (*Simulator, error) {
+	g, err := NewGameWithConfig(course, class)
+	if err != nil {
+		return nil, err
+	}
+	g.playTone = nil
+	g.isPaused = false
+	return &Simulator{game: g}, nil
+}
+
+// Game returns the simulated game, to set up conditions (SetWindReplay, SetCurrent,
+// SetOpponents) or inspect the boat between steps
+func (s *Simulator) Game() *GameState {
+	return s.game
+}
+
+// Step advances the race by dt of game time with the helm held at input
+func (s *Simulator) Step(dt time.Duration, input SteerInput) {
+	g := s.game
+	g.advanceClock(dt)
+	g.updateRace(dt)
+
+	// Run the physics steps that fall due by the new game time
+	due := int(g.elapsedTime / simStepDuration)
+	for ; s.steps < due; s.steps++ {
+		g.stepPhysics(input.Turn)
+	}
+}
+
+// Run steps the race by dt until the boat finishes or limit of game time has passed,
+// asking steer for the helm before each step. Returns the race result and whether the
+// boat finished.
+func (s *Simulator) Run(dt, limit time.Duration, steer func(g *GameState) SteerInput) (*RaceResult, bool) {
+	for !s.game.raceFinished && s.game.elapsedTime < limit {
+		s.Step(dt, steer(s.game))
+	}
+	return s.Result()
+}
+
+// Result returns the race result and whether the boat has finished (nil until it has)
+func (s *Simulator) Result() (*RaceResult, bool) {
+	if !s.game.raceFinished {
+		return nil, false
+	}
+	return s.game.raceResult(), true
+}
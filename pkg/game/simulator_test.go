@@ -0,0 +1,166 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createTestSimulator sets up the default course in a steady 12 kt easterly, so every leg
+// is a reach or a run, with the gun going straight away
+func createTestSimulator(t *testing.T) *Simulator {
+	sim, err := NewSimulator(DefaultCourseConfig(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := sim.Game()
+	g.SetWindReplay([]world.WindSample{{Time: 0, Direction: 90, Speed: 12}})
+	g.timerDuration = 0
+	return sim
+}
+
+// waypointHelm steers for each waypoint in turn, moving on within 15m of it
+func waypointHelm(waypoints []geometry.Point) func(g *GameState) SteerInput {
+	next := 0
+	return func(g *GameState) SteerInput {
+		if next < len(waypoints)-1 && distance(g.Boat.Pos, waypoints[next]) < 15 {
+			next++
+		}
+		off := geometry.AngleDiff(geometry.Bearing(g.Boat.Pos, waypoints[next]), g.Boat.Heading)
+		return SteerInput{Turn: math.Max(-1, math.Min(1, off/20))}
+	}
+}
+
+// roundTheCourse passes east of the upwind mark, crosses above it and runs back to the finish
+func roundTheCourse(g *GameState) func(g *GameState) SteerInput {
+	mark := g.Arena.Marks[2].Pos
+	line := g.Arena.Line.Pin.Pos.Y
+	return waypointHelm([]geometry.Point{
+		{X: mark.X + 40, Y: mark.Y - 40},
+		{X: mark.X - 40, Y: mark.Y - 40},
+		{X: mark.X - 40, Y: line + 100},
+	})
+}
+
+func TestSimulator_SailsTheCourse(t *testing.T) {
+	sim := createTestSimulator(t)
+
+	result, finished := sim.Run(time.Second/60, 10*time.Minute, roundTheCourse(sim.Game()))
+	if !finished {
+		t.Fatalf("Expected the boat to finish, ended at %+v", sim.Game().Boat.Pos)
+	}
+	if !result.MarkRounded {
+		t.Error("Expected the result to record the mark rounding")
+	}
+	if result.RaceTimeSeconds <= 0 {
+		t.Errorf("Expected a race time, got %.1fs", result.RaceTimeSeconds)
+	}
+	// Up past the mark and back is at least twice the 620m from the line to the mark
+	if result.DistanceSailed < 1240 {
+		t.Errorf("Expected at least 1240m sailed, got %.0fm", result.DistanceSailed)
+	}
+}
+
+func TestSimulator_IsDeterministic(t *testing.T) {
+	first := createTestSimulator(t)
+	second := createTestSimulator(t)
+
+	a, _ := first.Run(time.Second/60, 10*time.Minute, roundTheCourse(first.Game()))
+	b, _ := second.Run(time.Second/30, 10*time.Minute, roundTheCourse(second.Game()))
+	if a == nil || b == nil {
+		t.Fatal("Expected both races to finish")
+	}
+	// A coarser timestep only changes how often the helm and race checks run
+	if math.Abs(a.RaceTimeSeconds-b.RaceTimeSeconds) > 1 {
+		t.Errorf("Expected similar race times, got %.2fs and %.2fs", a.RaceTimeSeconds, b.RaceTimeSeconds)
+	}
+
+	// The same inputs give exactly the same race
+	again := createTestSimulator(t)
+	c, _ := again.Run(time.Second/60, 10*time.Minute, roundTheCourse(again.Game()))
+	if c.RaceTimeSeconds != a.RaceTimeSeconds || c.DistanceSailed != a.DistanceSailed {
+		t.Errorf("Expected identical repeated races, got %.3fs/%.1fm and %.3fs/%.1fm",
+			a.RaceTimeSeconds, a.DistanceSailed, c.RaceTimeSeconds, c.DistanceSailed)
+	}
+}
+
+func TestSimulator_StepRunsPhysicsOnGameTime(t *testing.T) {
+	sim := createTestSimulator(t)
+	start := sim.Game().Boat.Pos
+
+	// Sixty small steps and one second-long step cover the same ground
+	for i := 0; i < 60; i++ {
+		sim.Step(time.Second/60, SteerInput{})
+	}
+	small := sim.Game().Boat.Pos
+
+	other := createTestSimulator(t)
+	other.Step(time.Second, SteerInput{})
+	big := other.Game().Boat.Pos
+
+	if small == start {
+		t.Fatal("Expected the boat to move in a second of game time")
+	}
+	if math.Hypot(small.X-big.X, small.Y-big.Y) > 1e-9 {
+		t.Errorf("Expected the same position either way, got %+v and %+v", small, big)
+	}
+}
+
+func TestSimulator_UnfinishedHasNoResult(t *testing.T) {
+	sim := createTestSimulator(t)
+	result, finished := sim.Run(time.Second/60, 5*time.Second, func(*GameState) SteerInput { return SteerInput{} })
+	if finished || result != nil {
+		t.Errorf("Expected no result after 5 seconds, got %+v", result)
+	}
+}
+
+func TestSimulator_WindwardLeewardCourse(t *testing.T) {
+	sim, err := NewSimulator(WindwardLeewardCourseConfig(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := sim.Game()
+	g.SetWindReplay([]world.WindSample{{Time: 0, Direction: 90, Speed: 12}})
+	g.timerDuration = 0
+
+	// Keep clear of the gate on the way up, round the mark as before, then run down
+	// through the middle of the gate
+	mark := g.Arena.Marks[2].Pos
+	gate := g.Arena.Gate.Midpoint()
+	helm := waypointHelm([]geometry.Point{
+		{X: gate.X + 100, Y: gate.Y},
+		{X: mark.X + 40, Y: mark.Y - 40},
+		{X: mark.X - 40, Y: mark.Y - 40},
+		{X: gate.X, Y: gate.Y - 60},
+		{X: gate.X, Y: g.Arena.Line.Pin.Pos.Y + 100},
+	})
+	if _, finished := sim.Run(time.Second/60, 10*time.Minute, helm); !finished {
+		t.Fatalf("Expected the boat to finish, ended at %+v", g.Boat.Pos)
+	}
+	if !g.gatePassed {
+		t.Error("Expected the gate to be passed on the way to the finish")
+	}
+}
+
+func TestWindLog_RaceRelativeAndFreshOnRestart(t *testing.T) {
+	sim := createTestSimulator(t)
+	sim.Run(time.Second/60, 3*time.Second, func(*GameState) SteerInput { return SteerInput{} })
+	g := sim.Game()
+
+	samples := g.WindLog()
+	if len(samples) < 3 || samples[0].Time > 0.1 {
+		t.Fatalf("Expected a sample each second from the start of the sequence, got %+v", samples)
+	}
+
+	// A restarted race is logged from its own start, on the clock the replay plays it back on
+	g.restart()
+	if len(g.WindLog()) != 0 {
+		t.Errorf("Expected a fresh wind log after a restart, got %d samples", len(g.WindLog()))
+	}
+	if g.windReplay == nil {
+		t.Error("Expected the wind replay to carry over the restart")
+	}
+}
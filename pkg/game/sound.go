@@ -0,0 +1,43 @@
+package game
+
+import "time"
+
+// startTone is a sound in the start sequence
+type startTone int
+
+const (
+	toneCountdown startTone = iota // Short beep on each of the last ten seconds
+	toneGun                        // Long tone at the gun
+)
+
+// Seconds before the gun that get a countdown beep
+const countdownBeeps = 10
+
+// startBeep returns the tone due as the game clock moves from prev to now with the gun at
+// gun: a beep as each of the last ten seconds starts, and the long tone at the gun. Nothing
+// sounds when the clock stands still (paused) or goes back (rehearsal).
+func startBeep(prev, now, gun time.Duration) (startTone, bool) {
+	if now <= prev {
+		return 0, false
+	}
+	if prev < gun && now >= gun {
+		return toneGun, true
+	}
+	for s := 1; s <= countdownBeeps; s++ {
+		at := gun - time.Duration(s)*time.Second
+		if prev < at && now >= at {
+			return toneCountdown, true
+		}
+	}
+	return 0, false
+}
+
+// updateStartBeeps sounds the start sequence as the clock moves on from prev, unless muted
+func (g *GameState) updateStartBeeps(prev time.Duration) {
+	if g.playTone == nil || g.settings.Muted {
+		return
+	}
+	if tone, ok := startBeep(prev, g.elapsedTime, g.timerDuration); ok {
+		g.playTone(tone)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !js || !wasm
+
+package game
+
+// playStartTone is silent outside the browser
+func playStartTone(startTone) {}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartBeep_LastTenSecondsAndGun(t *testing.T) {
+	gun := 30 * time.Second
+	var beeps, guns int
+	for now := time.Duration(0); now <= 35*time.Second; now += time.Second / 60 {
+		tone, ok := startBeep(now-time.Second/60, now, gun)
+		if !ok {
+			continue
+		}
+		if tone == toneGun {
+			guns++
+			if now < gun || now > gun+time.Second/60 {
+				t.Errorf("Expected the gun tone at the gun, got it at %v", now)
+			}
+		} else {
+			beeps++
+			if now < gun-countdownBeeps*time.Second {
+				t.Errorf("Expected no countdown beep before the last ten seconds, got one at %v", now)
+			}
+		}
+	}
+	if beeps != countdownBeeps || guns != 1 {
+		t.Errorf("Expected %d beeps and one gun, got %d and %d", countdownBeeps, beeps, guns)
+	}
+}
+
+func TestStartBeep_SilentWhenClockStopsOrRewinds(t *testing.T) {
+	gun := 30 * time.Second
+	if _, ok := startBeep(25*time.Second, 25*time.Second, gun); ok {
+		t.Error("Expected no beep while the clock stands still (paused)")
+	}
+	if _, ok := startBeep(31*time.Second, 25*time.Second, gun); ok {
+		t.Error("Expected no beep when rehearsal rewinds the clock")
+	}
+}
+
+func TestUpdateStartBeeps_Muted(t *testing.T) {
+	g := createTestGame()
+	var played []startTone
+	g.playTone = func(tone startTone) { played = append(played, tone) }
+	g.timerDuration = 30 * time.Second
+
+	g.elapsedTime = 25 * time.Second
+	g.updateStartBeeps(24*time.Second + 900*time.Millisecond)
+	if len(played) != 1 || played[0] != toneCountdown {
+		t.Fatalf("Expected a countdown beep, got %v", played)
+	}
+
+	g.settings.Muted = true
+	g.elapsedTime = 26 * time.Second
+	g.updateStartBeeps(25*time.Second + 900*time.Millisecond)
+	if len(played) != 1 {
+		t.Errorf("Expected no beep while muted, got %v", played)
+	}
+}
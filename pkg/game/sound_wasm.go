@@ -0,0 +1,43 @@
+//go:build js && wasm
+
+package game
+
+import "syscall/js"
+
+// Web Audio context, created on the first tone (undefined until then, or if the browser has none)
+var audioContext js.Value
+
+// playStartTone plays a start sequence tone through the Web Audio API: a short high beep
+// for the countdown, a longer low tone for the gun
+func playStartTone(tone startTone) {
+	if audioContext.IsUndefined() {
+		ctor := js.Global().Get("AudioContext")
+		if ctor.IsUndefined() {
+			ctor = js.Global().Get("webkitAudioContext")
+		}
+		if ctor.IsUndefined() {
+			return
+		}
+		audioContext = ctor.New()
+	}
+	// Browsers hold a new context suspended until the player has interacted with the page
+	if audioContext.Get("state").String() == "suspended" {
+		audioContext.Call("resume")
+	}
+
+	frequency, length := 880.0, 0.15
+	if tone == toneGun {
+		frequency, length = 440.0, 1.0
+	}
+
+	now := audioContext.Get("currentTime").Float()
+	oscillator := audioContext.Call("createOscillator")
+	gain := audioContext.Call("createGain")
+	oscillator.Get("frequency").Set("value", frequency)
+	gain.Get("gain").Call("setValueAtTime", 0.2, now)
+	gain.Get("gain").Call("exponentialRampToValueAtTime", 0.001, now+length)
+	oscillator.Call("connect", gain)
+	gain.Call("connect", audioContext.Get("destination"))
+	oscillator.Call("start", now)
+	oscillator.Call("stop", now+length)
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// StartSignal is a signal of the start sequence. A real sequence runs five minutes: warning
+// at 5:00, preparatory at 4:00, one-minute at 1:00 and the start; the game scales it to the
+// countdown, so the warning goes at the beginning of the countdown.
+type StartSignal int
+
+const (
+	SignalWarning   StartSignal = iota // Class flag up
+	SignalPrep                         // Preparatory flag P up as well
+	SignalOneMinute                    // P comes down
+	SignalStart                        // Class flag comes down: the gun
+)
+
+func (s StartSignal) String() string {
+	switch s {
+	case SignalPrep:
+		return "PREPARATORY"
+	case SignalOneMinute:
+		return "ONE MINUTE"
+	case SignalStart:
+		return "START"
+	default:
+		return "WARNING"
+	}
+}
+
+// startSignal returns the signal in force with remaining of a countdown of total left,
+// the signal times scaled from the five-minute sequence
+func startSignal(remaining, total time.Duration) StartSignal {
+	switch {
+	case remaining <= 0:
+		return SignalStart
+	case remaining <= total/5: // 1:00 of 5:00
+		return SignalOneMinute
+	case remaining <= total*4/5: // 4:00 of 5:00
+		return SignalPrep
+	default:
+		return SignalWarning
+	}
+}
+
+// flagsUp reports which start flags fly during a signal: the class flag from the warning
+// until the start, and P from the preparatory until one minute
+func (s StartSignal) flagsUp() (class, prep bool) {
+	return s != SignalStart, s == SignalPrep
+}
+
+var (
+	classFlagColor = color.RGBA{255, 140, 0, 255} // Orange class flag
+	prepFlagColor  = color.RGBA{0, 70, 180, 255}  // Blue Peter: blue with a white square
+)
+
+// drawStartSignals shows the committee boat's flags and the signal name left of the
+// countdown, until the START banner goes
+func (g *GameState) drawStartSignals(screen *ebiten.Image) {
+	if g.raceStarted && g.elapsedTime-g.timerDuration >= 3*time.Second {
+		return
+	}
+	signal := startSignal(g.timerDuration-g.elapsedTime, g.timerDuration)
+	class, prep := signal.flagsUp()
+
+	x := float32(screen.Bounds().Dx()/2 - 200)
+	top, bottom := float32(6), float32(34)
+	for i, up := range []bool{class, prep} {
+		mast := x + float32(i)*24
+		vector.StrokeLine(screen, mast, top, mast, bottom, 1, color.White, false)
+		if !up {
+			continue
+		}
+		if i == 0 {
+			vector.DrawFilledRect(screen, mast+1, top, 16, 11, classFlagColor, false)
+		} else {
+			vector.DrawFilledRect(screen, mast+1, top, 16, 11, prepFlagColor, false)
+			vector.DrawFilledRect(screen, mast+6, top+3, 6, 5, color.White, false)
+		}
+	}
+	ebitenutil.DebugPrintAt(screen, signal.String(), int(x)-4, int(bottom)+2)
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartSignal_ScaledToCountdown(t *testing.T) {
+	tests := []struct {
+		remaining, total time.Duration
+		want             StartSignal
+	}{
+		// The 30 second countdown: prep at 24s, one minute at 6s
+		{30 * time.Second, 30 * time.Second, SignalWarning},
+		{25 * time.Second, 30 * time.Second, SignalWarning},
+		{24 * time.Second, 30 * time.Second, SignalPrep},
+		{7 * time.Second, 30 * time.Second, SignalPrep},
+		{6 * time.Second, 30 * time.Second, SignalOneMinute},
+		{time.Second, 30 * time.Second, SignalOneMinute},
+		{0, 30 * time.Second, SignalStart},
+		{-2 * time.Second, 30 * time.Second, SignalStart},
+		// A full five minute sequence
+		{4*time.Minute + 30*time.Second, 5 * time.Minute, SignalWarning},
+		{4 * time.Minute, 5 * time.Minute, SignalPrep},
+		{time.Minute, 5 * time.Minute, SignalOneMinute},
+	}
+	for _, tt := range tests {
+		if got := startSignal(tt.remaining, tt.total); got != tt.want {
+			t.Errorf("startSignal(%v, %v) = %v, want %v", tt.remaining, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestStartSignal_Flags(t *testing.T) {
+	tests := []struct {
+		signal      StartSignal
+		class, prep bool
+	}{
+		{SignalWarning, true, false},
+		{SignalPrep, true, true},
+		{SignalOneMinute, true, false},
+		{SignalStart, false, false},
+	}
+	for _, tt := range tests {
+		if class, prep := tt.signal.flagsUp(); class != tt.class || prep != tt.prep {
+			t.Errorf("%v: expected class %v and P %v, got %v and %v", tt.signal, tt.class, tt.prep, class, prep)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"math"
+)
+
+// Thresholds for judging a start at the gun
+const (
+	greatStartSeconds = 1.0  // Bow within this many seconds of the line at the gun
+	lateStartSeconds  = 4.0  // More than this behind the line is a late start
+	greatStartSpeed   = 90.0 // Percent of target beat speed for a great start
+	slowStartSpeed    = 70.0 // Below this percent of target beat speed is a slow start
+	wrongEndLoss      = 15.0 // Meters given away to the favored end before it counts as the wrong end
+)
+
+// StartMetrics captures the player's start quality at the gun
+type StartMetrics struct {
+	OCS             bool    // Over the line at the gun
+	SecondsLate     float64 // Seconds still needed to reach the line (+Inf if not closing)
+	SpeedPercentage float64 // Speed as a percentage of target beat speed
+	PositionLoss    float64 // Meters upwind given away by starting away from the favored end
+}
+
+// startVerdict sums up a start in a few words. Being over the line trumps everything,
+// then timing, then speed, then position on the line.
+func startVerdict(m StartMetrics) string {
+	switch {
+	case m.OCS:
+		return "Early - dip the line!"
+	case m.SecondsLate > lateStartSeconds:
+		return "Late off the line"
+	case m.SpeedPercentage < slowStartSpeed:
+		return "Slow off the line"
+	case m.PositionLoss > wrongEndLoss:
+		return "Wrong end of the line"
+	case m.SecondsLate <= greatStartSeconds && m.SpeedPercentage >= greatStartSpeed:
+		return "Great start!"
+	default:
+		return "Decent start"
+	}
+}
+
+// startMetrics measures the player's start at this instant
+func (g *GameState) startMetrics() StartMetrics {
+	return StartMetrics{
+		OCS:             g.isOCS,
+		SecondsLate:     g.Dashboard.CalculateTimeToLine(),
+		SpeedPercentage: g.targetSpeedPercentage(),
+		PositionLoss:    g.startPositionLoss(),
+	}
+}
+
+// targetSpeedPercentage returns the boat speed as a percentage of the target beat speed
+func (g *GameState) targetSpeedPercentage() float64 {
+	_, windSpeed := g.Wind.GetWind(g.Boat.Pos)
+	// Target beat speed is typically at 45-50 degree TWA - use 45 degrees
+	targetBeatSpeed := g.Boat.Polars.GetBoatSpeed(45.0, windSpeed)
+	if targetBeatSpeed <= 0 {
+		return 0
+	}
+	return (g.Boat.Speed / targetBeatSpeed) * 100
+}
+
+// startPositionLoss returns how far upwind of the boat's spot on the line the favored end is.
+// The bow is projected onto the line, so a boat halfway along a line with a 20m bias gives away 10m.
+func (g *GameState) startPositionLoss() float64 {
+	favoredEnd, advantage := g.Dashboard.CalculateLineBias()
+	pin, committee := g.Arena.Line.Ends()
+	length := g.Arena.Line.Length()
+	if favoredEnd == "Square" || length == 0 {
+		return 0
+	}
+
+	// Fraction of the way along the line from the pin (clamped to the ends)
+	bow := g.Boat.GetBowPosition()
+	along := ((bow.X-pin.X)*(committee.X-pin.X) + (bow.Y-pin.Y)*(committee.Y-pin.Y)) / (length * length)
+	along = math.Max(0, math.Min(1, along))
+
+	if favoredEnd == "Pin" {
+		return advantage * along
+	}
+	return advantage * (1 - along)
+}
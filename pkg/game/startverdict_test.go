@@ -0,0 +1,64 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestStartVerdict(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics StartMetrics
+		want    string
+	}{
+		{"On the line at full speed", StartMetrics{SecondsLate: 0.5, SpeedPercentage: 95}, "Great start!"},
+		{"Over the line", StartMetrics{OCS: true, SecondsLate: 0, SpeedPercentage: 100}, "Early - dip the line!"},
+		{"OCS beats every other fault", StartMetrics{OCS: true, SecondsLate: 10, SpeedPercentage: 20, PositionLoss: 50}, "Early - dip the line!"},
+		{"Well behind the line", StartMetrics{SecondsLate: 8, SpeedPercentage: 100}, "Late off the line"},
+		{"Not closing on the line", StartMetrics{SecondsLate: math.Inf(1), SpeedPercentage: 100}, "Late off the line"},
+		{"On time but luffing", StartMetrics{SecondsLate: 0.5, SpeedPercentage: 50}, "Slow off the line"},
+		{"Fast at the unfavored end", StartMetrics{SecondsLate: 0.5, SpeedPercentage: 95, PositionLoss: 40}, "Wrong end of the line"},
+		{"A little late", StartMetrics{SecondsLate: 2.5, SpeedPercentage: 95}, "Decent start"},
+		{"Not quite up to speed", StartMetrics{SecondsLate: 0.5, SpeedPercentage: 80}, "Decent start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startVerdict(tt.metrics); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStartPositionLoss(t *testing.T) {
+	g := createTestGame()
+	// Wind veered 10°: the committee end is about 69m further upwind than the pin
+	wind := &world.ConstantWind{Direction: 10, Speed: 10}
+	g.Wind = wind
+	g.Dashboard.Wind = wind
+	_, advantage := g.Dashboard.CalculateLineBias()
+
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{"At the committee end", 1200, 0},
+		{"Mid-line", 1000, advantage / 2},
+		{"At the pin end", 800, advantage},
+		{"Beyond the pin", 700, advantage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Boat.Pos = geometry.Point{X: tt.x, Y: 2420}
+			if got := g.startPositionLoss(); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("Expected %.1fm given away, got %.1fm", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeyValueStore persists small string values between sessions
+// (a config file on desktop, localStorage in the browser)
+type KeyValueStore interface {
+	Load(key string) (string, bool)
+	Save(key, value string) error
+	Delete(key string) error
+}
+
+// memoryStore is an in-memory KeyValueStore for tests
+type memoryStore struct {
+	values map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: make(map[string]string)}
+}
+
+func (m *memoryStore) Load(key string) (string, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *memoryStore) Save(key, value string) error {
+	m.values[key] = value
+	return nil
+}
+
+func (m *memoryStore) Delete(key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+// Storage key for a finished race that hasn't been through the scoreboard yet
+const pendingResultKey = "pending_result"
+
+// SavePendingResult stores a finished race result so it survives a crash or closed tab
+func SavePendingResult(store KeyValueStore, result *RaceResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return store.Save(pendingResultKey, string(data))
+}
+
+// LoadPendingResult returns a result saved by a previous session, if any
+func LoadPendingResult(store KeyValueStore) (*RaceResult, bool) {
+	data, ok := store.Load(pendingResultKey)
+	if !ok {
+		return nil, false
+	}
+
+	var result RaceResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		// Corrupt entry - drop it so it doesn't block future recoveries
+		store.Delete(pendingResultKey)
+		return nil, false
+	}
+	return &result, true
+}
+
+// ClearPendingResult removes the saved result once it has been handled
+func ClearPendingResult(store KeyValueStore) error {
+	return store.Delete(pendingResultKey)
+}
+
+// courseKey is the storage key for results of the race with the given course signature,
+// so laps, layouts and boat classes are each kept apart ("" = the key itself)
+func courseKey(key, course string) string {
+	if course == "" {
+		return key
+	}
+	return key + "_" + course
+}
+
+// Storage key for the fastest finish time on this device (milliseconds), per course
+const personalBestKey = "personal_best"
+
+// LoadPersonalBest returns the stored fastest finish time on the course, if any
+func LoadPersonalBest(store KeyValueStore, course string) (time.Duration, bool) {
+	key := courseKey(personalBestKey, course)
+	data, ok := store.Load(key)
+	if !ok {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(data, 10, 64)
+	if err != nil || ms <= 0 {
+		// Corrupt entry - drop it so the next finish sets a fresh best
+		store.Delete(key)
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// UpdatePersonalBest saves finish as the new best on the course if it beats the stored one.
+// Returns whether it is a new best and the previous best (0 on the first ever finish).
+func UpdatePersonalBest(store KeyValueStore, course string, finish time.Duration) (bool, time.Duration) {
+	previous, ok := LoadPersonalBest(store, course)
+	if ok && finish >= previous {
+		return false, previous
+	}
+	store.Save(courseKey(personalBestKey, course), strconv.FormatInt(finish.Milliseconds(), 10))
+	return true, previous
+}
+
+// Storage key for the track of the personal best run, replayed alongside later runs on
+// the same course
+const personalBestTrackKey = "personal_best_track"
+
+// SavePersonalBestTrack stores the track of the personal best run on the course
+func SavePersonalBestTrack(store KeyValueStore, course string, track *ReplayTrack) error {
+	data, err := json.Marshal(track)
+	if err != nil {
+		return err
+	}
+	return store.Save(courseKey(personalBestTrackKey, course), string(data))
+}
+
+// LoadPersonalBestTrack returns the stored track of the personal best run on the course, if any
+func LoadPersonalBestTrack(store KeyValueStore, course string) (*ReplayTrack, bool) {
+	key := courseKey(personalBestTrackKey, course)
+	data, ok := store.Load(key)
+	if !ok {
+		return nil, false
+	}
+
+	var track ReplayTrack
+	if err := json.Unmarshal([]byte(data), &track); err != nil || len(track.Frames) == 0 {
+		// Corrupt entry - drop it so the next personal best replaces it
+		store.Delete(key)
+		return nil, false
+	}
+	return &track, true
+}
+
+// Storage key for the race results kept on this device when there is no online
+// leaderboard, per course
+const localLeaderboardKey = "leaderboard"
+
+// LoadLocalResults returns the race results on the course saved on this device, oldest
+// first. A missing or corrupt history starts empty.
+func LoadLocalResults(store KeyValueStore, course string) []RaceResult {
+	key := courseKey(localLeaderboardKey, course)
+	data, ok := store.Load(key)
+	if !ok {
+		return nil
+	}
+
+	var results []RaceResult
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		// Corrupt entry - drop it so the next submitted result starts a fresh history
+		store.Delete(key)
+		return nil
+	}
+	return results
+}
+
+// SaveLocalResult adds a race result to the history of its course saved on this device
+func SaveLocalResult(store KeyValueStore, result *RaceResult) error {
+	data, err := json.Marshal(append(LoadLocalResults(store, result.Course), *result))
+	if err != nil {
+		return err
+	}
+	return store.Save(courseKey(localLeaderboardKey, result.Course), string(data))
+}
+
+// Storage key for each player's fastest race time (seconds) per course, keyed by lowercased name
+const playerBestsKey = "player_bests"
+
+// playerKey matches names however they were capitalized or padded when typed
+func playerKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// LoadPlayerBests returns every player's fastest race time on the course in seconds.
+// A missing or corrupt entry starts empty.
+func LoadPlayerBests(store KeyValueStore, course string) map[string]float64 {
+	key := courseKey(playerBestsKey, course)
+	bests := make(map[string]float64)
+	data, ok := store.Load(key)
+	if !ok {
+		return bests
+	}
+	if err := json.Unmarshal([]byte(data), &bests); err != nil {
+		// Corrupt entry - drop it so the next finish starts fresh bests
+		store.Delete(key)
+		return make(map[string]float64)
+	}
+	return bests
+}
+
+// UpdatePlayerBest saves seconds as the player's best on the course if it beats the stored
+// one. Returns whether it is a new best.
+func UpdatePlayerBest(store KeyValueStore, course, name string, seconds float64) (bool, error) {
+	bests := LoadPlayerBests(store, course)
+	if previous, ok := bests[playerKey(name)]; ok && seconds >= previous {
+		return false, nil
+	}
+	bests[playerKey(name)] = seconds
+	data, err := json.Marshal(bests)
+	if err != nil {
+		return false, err
+	}
+	return true, store.Save(courseKey(playerBestsKey, course), string(data))
+}
+
+// Storage key for the touch button layout the player picked
+const controlLayoutKey = "control_layout"
+
+// LoadControlLayout returns the saved touch button layout, or the default when none has
+// been picked. A corrupt entry is dropped.
+func LoadControlLayout(store KeyValueStore) ControlLayout {
+	data, ok := store.Load(controlLayoutKey)
+	if !ok {
+		return DefaultControlLayout()
+	}
+	var layout ControlLayout
+	if err := json.Unmarshal([]byte(data), &layout); err != nil {
+		store.Delete(controlLayoutKey)
+		return DefaultControlLayout()
+	}
+	return layout.normalized()
+}
+
+// SaveControlLayout stores the touch button layout for the next session
+func SaveControlLayout(store KeyValueStore, layout ControlLayout) error {
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return err
+	}
+	return store.Save(controlLayoutKey, string(data))
+}
@@ -0,0 +1,52 @@
+//go:build !js || !wasm
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// fileStore keeps each key in its own file under the user's config directory
+type fileStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store in the user's config directory for non-WASM builds
+func NewLocalStore() KeyValueStore {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return &fileStore{dir: filepath.Join(dir, "gosailing")}
+}
+
+func (fs *fileStore) path(key string) string {
+	return filepath.Join(fs.dir, key+".json")
+}
+
+// Load reads a value, reporting false if it doesn't exist
+func (fs *fileStore) Load(key string) (string, bool) {
+	data, err := os.ReadFile(fs.path(key))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Save writes a value, creating the config directory if needed
+func (fs *fileStore) Save(key, value string) error {
+	if err := os.MkdirAll(fs.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(fs.path(key), []byte(value), 0o644)
+}
+
+// Delete removes a value (missing values are not an error)
+func (fs *fileStore) Delete(key string) error {
+	err := os.Remove(fs.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
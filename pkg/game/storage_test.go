@@ -0,0 +1,325 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/objects"
+)
+
+func TestPendingResult_WriteAndRecover(t *testing.T) {
+	store := newMemoryStore()
+
+	// Race finishes - result is autosaved before any name entry
+	g := createTestGame()
+	g.store = store
+	g.finishTime = 95500 * time.Millisecond
+	g.secondsLate = 1.5
+	g.markRounded = true
+	g.distanceSailed = 1234
+	g.averageSpeed = 6.2
+	g.autosaveResult()
+
+	// Next launch finds the pending result
+	recovered, ok := LoadPendingResult(store)
+	if !ok {
+		t.Fatal("Expected a pending result after autosave")
+	}
+	if recovered.RaceTimeSeconds != 95.5 || recovered.SecondsLate != 1.5 || !recovered.MarkRounded ||
+		recovered.DistanceSailed != 1234 || recovered.AverageSpeed != 6.2 {
+		t.Errorf("Recovered result does not match saved race: %+v", recovered)
+	}
+
+	// Once handled by the scoreboard it is cleared
+	sb := &Scoreboard{store: store, currentResult: recovered}
+	sb.Hide()
+	if _, ok := LoadPendingResult(store); ok {
+		t.Error("Expected pending result to be cleared after the scoreboard closed")
+	}
+}
+
+func TestPendingResult_AutosaveOff(t *testing.T) {
+	g := createTestGame()
+	g.store = newMemoryStore()
+	g.finishTime = 95500 * time.Millisecond
+	g.SetAutosave(false)
+	g.autosaveResult()
+
+	if _, ok := LoadPendingResult(g.store); ok {
+		t.Error("Expected no pending result with autosave off")
+	}
+}
+
+func TestPendingResult_NoneSaved(t *testing.T) {
+	if _, ok := LoadPendingResult(newMemoryStore()); ok {
+		t.Error("Expected no pending result in an empty store")
+	}
+}
+
+func TestPendingResult_CorruptEntryDropped(t *testing.T) {
+	store := newMemoryStore()
+	store.Save(pendingResultKey, "{not json")
+
+	if _, ok := LoadPendingResult(store); ok {
+		t.Error("Expected corrupt pending result to be rejected")
+	}
+	if _, ok := store.Load(pendingResultKey); ok {
+		t.Error("Expected corrupt pending result to be removed")
+	}
+}
+
+func TestPersonalBest_FasterTimeUpdatesBest(t *testing.T) {
+	store := newMemoryStore()
+	g := createTestGame()
+	g.store = store
+
+	// First ever finish sets the best without a comparison
+	g.finishTime = 100 * time.Second
+	g.recordPersonalBest()
+	if !g.newPersonalBest || g.previousBest != 0 {
+		t.Fatalf("Expected first finish to set a personal best, got new=%v previous=%v", g.newPersonalBest, g.previousBest)
+	}
+	if got := personalBestBanner(g.finishTime, g.previousBest); got != "FIRST PERSONAL BEST SET!" {
+		t.Errorf("Unexpected first-finish banner %q", got)
+	}
+
+	// Faster finish beats it
+	g.finishTime = 95800 * time.Millisecond
+	g.recordPersonalBest()
+	if !g.newPersonalBest || g.previousBest != 100*time.Second {
+		t.Fatalf("Expected faster finish to be a new personal best, got new=%v previous=%v", g.newPersonalBest, g.previousBest)
+	}
+	if got := personalBestBanner(g.finishTime, g.previousBest); got != "NEW PERSONAL BEST! -4.2s" {
+		t.Errorf("Unexpected personal best banner %q", got)
+	}
+	if best, _ := LoadPersonalBest(store, g.courseSignature()); best != 95800*time.Millisecond {
+		t.Errorf("Expected stored best 1m35.8s, got %v", best)
+	}
+}
+
+func TestPersonalBest_SlowerTimeKeepsBest(t *testing.T) {
+	store := newMemoryStore()
+	g := createTestGame()
+	g.store = store
+	UpdatePersonalBest(store, g.courseSignature(), 90*time.Second)
+
+	g.finishTime = 92 * time.Second
+	g.recordPersonalBest()
+
+	if g.newPersonalBest {
+		t.Error("Expected a slower finish not to be a personal best")
+	}
+	if best, _ := LoadPersonalBest(store, g.courseSignature()); best != 90*time.Second {
+		t.Errorf("Expected stored best to stay 1m30s, got %v", best)
+	}
+}
+
+func TestPersonalBest_KeptApartPerCourse(t *testing.T) {
+	store := newMemoryStore()
+	g := createTestGame()
+	g.store = store
+	g.course.Laps = 1
+	UpdatePersonalBest(store, g.courseSignature(), 90*time.Second)
+
+	// A 3-lap race is slower than any 1-lap best but still the first on its course
+	g.course.Laps = 3
+	g.finishTime = 270 * time.Second
+	g.recordPersonalBest()
+	if !g.newPersonalBest || g.previousBest != 0 {
+		t.Errorf("Expected the first 3-lap finish to set its own best, got new=%v previous=%v", g.newPersonalBest, g.previousBest)
+	}
+	if result := g.raceResult(); result.Laps != 3 || result.Course != g.courseSignature() {
+		t.Errorf("Expected the result to record 3 laps on %q, got %d on %q", g.courseSignature(), result.Laps, result.Course)
+	}
+
+	// Same layout and laps in a dinghy is a different race too
+	dinghy := DefaultCourseConfig().signature(&objects.Dinghy)
+	if keelboat := DefaultCourseConfig().signature(nil); keelboat == dinghy {
+		t.Errorf("Expected keelboat and dinghy signatures to differ, both %q", dinghy)
+	}
+}
+
+func TestPersonalBest_CorruptEntryDropped(t *testing.T) {
+	store := newMemoryStore()
+	store.Save(personalBestKey, "fast")
+
+	if _, ok := LoadPersonalBest(store, ""); ok {
+		t.Error("Expected corrupt personal best to be rejected")
+	}
+	if _, ok := store.Load(personalBestKey); ok {
+		t.Error("Expected corrupt personal best to be removed")
+	}
+}
+
+func TestPersonalBestTrack_RoundTrip(t *testing.T) {
+	store := newMemoryStore()
+	if _, ok := LoadPersonalBestTrack(store, ""); ok {
+		t.Fatal("Expected no track before one is saved")
+	}
+
+	track := createTestReplay().Track()
+	track.GunTime = 2 * time.Second
+	if err := SavePersonalBestTrack(store, "", track); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, ok := LoadPersonalBestTrack(store, "")
+	if !ok || len(loaded.Frames) != len(track.Frames) || loaded.GunTime != track.GunTime {
+		t.Fatalf("Expected the saved track back, got %+v", loaded)
+	}
+
+	store.Save(personalBestTrackKey, "{")
+	if _, ok := LoadPersonalBestTrack(store, ""); ok {
+		t.Error("Expected corrupt track to be rejected")
+	}
+	if _, ok := store.Load(personalBestTrackKey); ok {
+		t.Error("Expected corrupt track to be removed")
+	}
+}
+
+func TestLocalResults_SavedAndRanked(t *testing.T) {
+	store := newMemoryStore()
+	if results := LoadLocalResults(store, ""); len(results) != 0 {
+		t.Fatalf("Expected no history in an empty store, got %d results", len(results))
+	}
+
+	// Twelve finished races, fastest first, and one that missed the mark
+	for i := 0; i < 12; i++ {
+		result := &RaceResult{PlayerName: "Sailor", RaceTimeSeconds: float64(100 + i), MarkRounded: true}
+		if err := SaveLocalResult(store, result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	SaveLocalResult(store, &RaceResult{PlayerName: "Skipper", RaceTimeSeconds: 50})
+
+	results := LoadLocalResults(store, "")
+	if len(results) != 13 {
+		t.Fatalf("Expected 13 saved results, got %d", len(results))
+	}
+
+	// The slowest race is the current one: top ten shown, plus it separately at 12th
+	sb := &Scoreboard{store: store, currentResult: &results[11]}
+	sb.createLeaderboard(results)
+	if visible := sb.visibleEntries(); len(visible) != 10 || visible[0].RaceTime != "01:40.00" {
+		t.Errorf("Expected the ten fastest finishes from 01:40.00, got %+v", visible)
+	}
+	if pinned := sb.pinnedEntry(); pinned == nil || pinned.Rank != 12 {
+		t.Errorf("Expected the current race as a separate 12th entry, got %+v", pinned)
+	}
+}
+
+func TestLocalResults_KeptApartPerCourse(t *testing.T) {
+	store := newMemoryStore()
+	SaveLocalResult(store, &RaceResult{PlayerName: "Sailor", RaceTimeSeconds: 100, Laps: 1, Course: "keelboat_1lap_a"})
+	SaveLocalResult(store, &RaceResult{PlayerName: "Sailor", RaceTimeSeconds: 300, Laps: 3, Course: "keelboat_3lap_a"})
+
+	backend := &localLeaderboard{store: store, course: "keelboat_3lap_a"}
+	backend.GetLeaderboard(time.Time{}, func(results []RaceResult, _ string) {
+		if len(results) != 1 || results[0].Laps != 3 {
+			t.Errorf("Expected only the 3-lap result, got %+v", results)
+		}
+	})
+}
+
+func TestLocalResults_CorruptEntryDropped(t *testing.T) {
+	store := newMemoryStore()
+	store.Save(localLeaderboardKey, "[{not json")
+
+	if results := LoadLocalResults(store, ""); len(results) != 0 {
+		t.Errorf("Expected a corrupt history to start empty, got %d results", len(results))
+	}
+	if _, ok := store.Load(localLeaderboardKey); ok {
+		t.Error("Expected corrupt history to be removed")
+	}
+
+	// The next result starts a fresh history
+	SaveLocalResult(store, &RaceResult{PlayerName: "Sailor", RaceTimeSeconds: 100, MarkRounded: true})
+	if results := LoadLocalResults(store, ""); len(results) != 1 {
+		t.Errorf("Expected one result after saving, got %d", len(results))
+	}
+}
+
+func TestPlayerBest_KeptPerName(t *testing.T) {
+	store := newMemoryStore()
+	if bests := LoadPlayerBests(store, ""); len(bests) != 0 {
+		t.Fatalf("Expected no bests in an empty store, got %v", bests)
+	}
+
+	if isNew, _ := UpdatePlayerBest(store, "", "Skipper", 100); !isNew {
+		t.Error("Expected the first race to set a best")
+	}
+	if isNew, _ := UpdatePlayerBest(store, "", " skipper ", 104); isNew {
+		t.Error("Expected a slower race under the same name not to replace the best")
+	}
+	UpdatePlayerBest(store, "", "Crew", 120)
+
+	bests := LoadPlayerBests(store, "")
+	if bests["skipper"] != 100 || bests["crew"] != 120 {
+		t.Errorf("Expected separate bests per player, got %v", bests)
+	}
+}
+
+func TestPlayerBest_CorruptEntryDropped(t *testing.T) {
+	store := newMemoryStore()
+	store.Save(playerBestsKey, "{not json")
+
+	if bests := LoadPlayerBests(store, ""); len(bests) != 0 {
+		t.Errorf("Expected corrupt bests to start empty, got %v", bests)
+	}
+	if _, ok := store.Load(playerBestsKey); ok {
+		t.Error("Expected corrupt bests to be removed")
+	}
+}
+
+func TestControlLayout_LoadDefaultsAndCorrupt(t *testing.T) {
+	store := newMemoryStore()
+	if layout := LoadControlLayout(store); layout != DefaultControlLayout() {
+		t.Errorf("Expected the default layout on first launch, got %+v", layout)
+	}
+
+	store.Save(controlLayoutKey, "{not json")
+	if layout := LoadControlLayout(store); layout != DefaultControlLayout() {
+		t.Errorf("Expected the default layout for a corrupt entry, got %+v", layout)
+	}
+	if _, ok := store.Load(controlLayoutKey); ok {
+		t.Error("Expected the corrupt entry to be removed")
+	}
+}
+
+func TestNewGame_KeepsResultsInMemoryUntilSetStore(t *testing.T) {
+	g := NewGame(nil)
+	if _, ok := g.store.(*memoryStore); !ok {
+		t.Fatalf("Expected a new game to use an in-memory store, got %T", g.store)
+	}
+
+	// Handing over a store picks up what it already holds
+	store := newMemoryStore()
+	SaveControlLayout(store, controlLayouts[1])
+	SavePersonalBestTrack(store, g.courseSignature(), &ReplayTrack{Frames: []ReplayFrame{{Time: time.Second}}})
+	g.SetStore(store)
+	if g.store != store || g.scoreboard.store != store {
+		t.Error("Expected the game and scoreboard to use the store")
+	}
+	if g.settings.Controls != controlLayouts[1] {
+		t.Errorf("Expected the saved touch layout, got %+v", g.settings.Controls)
+	}
+	if g.ghost == nil {
+		t.Error("Expected the saved personal best track to be raced")
+	}
+}
+
+func TestNewGame_GhostOnlyFromTheSameCourse(t *testing.T) {
+	store := newMemoryStore()
+	SavePersonalBestTrack(store, DefaultCourseConfig().signature(nil), &ReplayTrack{Frames: []ReplayFrame{{Time: time.Second}}})
+
+	// The same course in a dinghy, or round a gate, is a different race with no ghost yet
+	dinghy := NewGame(&objects.Dinghy)
+	dinghy.SetStore(store)
+	if dinghy.ghost != nil {
+		t.Error("Expected no ghost from a keelboat run in a dinghy")
+	}
+	gate := newGameWithCourse(WindwardLeewardCourseConfig(), nil)
+	gate.SetStore(store)
+	if gate.ghost != nil {
+		t.Error("Expected no ghost from the course without a gate")
+	}
+}
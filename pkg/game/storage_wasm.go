@@ -0,0 +1,56 @@
+//go:build js && wasm
+
+package game
+
+import (
+	"syscall/js"
+)
+
+// Prefix keeps the game's keys apart from anything else on the page's origin
+const localStoragePrefix = "gosailing."
+
+// localStorageStore keeps values in the browser's localStorage
+type localStorageStore struct{}
+
+// NewLocalStore creates a browser localStorage store for WASM builds
+func NewLocalStore() KeyValueStore {
+	return &localStorageStore{}
+}
+
+// storage returns the localStorage object, or undefined if unavailable (e.g. private mode)
+func (ls *localStorageStore) storage() js.Value {
+	return js.Global().Get("localStorage")
+}
+
+// Load reads a value, reporting false if it doesn't exist
+func (ls *localStorageStore) Load(key string) (string, bool) {
+	storage := ls.storage()
+	if storage.IsUndefined() || storage.IsNull() {
+		return "", false
+	}
+	value := storage.Call("getItem", localStoragePrefix+key)
+	if value.IsNull() || value.IsUndefined() {
+		return "", false
+	}
+	return value.String(), true
+}
+
+// Save writes a value (silently dropped if localStorage is unavailable)
+func (ls *localStorageStore) Save(key, value string) error {
+	storage := ls.storage()
+	if storage.IsUndefined() || storage.IsNull() {
+		return nil
+	}
+	storage.Call("setItem", localStoragePrefix+key, value)
+	return nil
+}
+
+// Delete removes a value
+func (ls *localStorageStore) Delete(key string) error {
+	storage := ls.storage()
+	if storage.IsUndefined() || storage.IsNull() {
+		return nil
+	}
+	storage.Call("removeItem", localStoragePrefix+key)
+	return nil
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// TackShift classifies the wind shift a boat tacked in
+type TackShift int
+
+const (
+	TackOnHeader TackShift = iota // Wind shifted against the old tack - tacking was right
+	TackOnLift                    // Wind shifted in favor of the old tack - tacking lost the lift
+	TackInSteady                  // No meaningful shift from the median direction
+)
+
+// Shifts smaller than this (degrees) are treated as steady wind
+const tackShiftThreshold = 1.0
+
+// TackRecord is the wind state sampled at a tack
+type TackRecord struct {
+	Time           time.Duration // Race time of the tack
+	WindDirection  float64       // Wind direction at the boat when tacking
+	Shift          float64       // Shift from the median direction (positive = veer/clockwise)
+	Classification TackShift
+}
+
+// medianWind is implemented by winds that oscillate around a median direction
+type medianWind interface {
+	MedianDirection() float64
+}
+
+// medianWindDirection returns the direction the wind oscillates around
+// (the current local direction for winds that don't oscillate)
+func medianWindDirection(wind world.Wind, pos geometry.Point) float64 {
+	if mw, ok := wind.(medianWind); ok {
+		return mw.MedianDirection()
+	}
+	dir, _ := wind.GetWind(pos)
+	return dir
+}
+
+// windInStartBias reports whether the wind is still in the opening shift that biases the
+// start line (never for winds without one)
+func windInStartBias(wind world.Wind) bool {
+	if bw, ok := wind.(interface{ InStartBias() bool }); ok {
+		return bw.InStartBias()
+	}
+	return false
+}
+
+// windShiftVisible reports whether the dashboard shows lifts and headers: only once the
+// race has started and the wind is oscillating around its median rather than biasing the line
+func (g *GameState) windShiftVisible() bool {
+	return g.raceStarted && !windInStartBias(g.Wind)
+}
+
+// classifyTack decides whether a tack from the tack given by oldTWA was on a header or a lift.
+// On port tack (TWA > 0) a veer (clockwise shift) is a header; on starboard a back is.
+func classifyTack(oldTWA, windDir, medianDir float64) (TackShift, float64) {
+	shift := windDir - medianDir
+	if shift > 180 {
+		shift -= 360
+	} else if shift < -180 {
+		shift += 360
+	}
+
+	if math.Abs(shift) < tackShiftThreshold {
+		return TackInSteady, shift
+	}
+	if shift*oldTWA > 0 {
+		return TackOnHeader, shift
+	}
+	return TackOnLift, shift
+}
+
+// detectTack records a tack when the TWA changes sign while sailing upwind
+func (g *GameState) detectTack(twa float64) {
+	prev := g.prevTWA
+	g.prevTWA = twa
+
+	upwind := math.Abs(prev) < 90 && math.Abs(twa) < 90
+	if !upwind || prev == 0 || prev*twa >= 0 {
+		return
+	}
+
+	windDir, _ := g.Wind.GetWind(g.Boat.Pos)
+	classification, shift := classifyTack(prev, windDir, medianWindDirection(g.Wind, g.Boat.Pos))
+	g.tacks = append(g.tacks, TackRecord{
+		Time:           g.raceTimer,
+		WindDirection:  windDir,
+		Shift:          shift,
+		Classification: classification,
+	})
+}
+
+// tackSummary reports how many tacks were on headers and lifts
+func tackSummary(tacks []TackRecord) string {
+	if len(tacks) == 0 {
+		return "No tacks"
+	}
+
+	headers, lifts := 0, 0
+	for _, tack := range tacks {
+		switch tack.Classification {
+		case TackOnHeader:
+			headers++
+		case TackOnLift:
+			lifts++
+		}
+	}
+
+	return fmt.Sprintf("You tacked on %s and %s", pluralize(headers, "header"), pluralize(lifts, "lift"))
+}
+
+// pluralize formats a count with a singular or plural noun
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/game/world"
+)
+
+func TestClassifyTack_HeaderVsLift(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldTWA   float64
+		windDir  float64
+		expected TackShift
+	}{
+		{"Port tack, wind veered - header", 40, 8, TackOnHeader},
+		{"Port tack, wind backed - lift", 40, 352, TackOnLift},
+		{"Starboard tack, wind backed - header", -40, 352, TackOnHeader},
+		{"Starboard tack, wind veered - lift", -40, 8, TackOnLift},
+		{"Steady wind", 40, 0.5, TackInSteady},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := classifyTack(tt.oldTWA, tt.windDir, 0)
+			if got != tt.expected {
+				t.Errorf("classifyTack(%.0f, %.0f, 0) = %v, expected %v", tt.oldTWA, tt.windDir, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectTack_RecordsWindAtTack(t *testing.T) {
+	g := createTestGame()
+	// Wind veered 10° right from a median of north
+	g.Wind = &world.ConstantWind{Direction: 10, Speed: 12}
+
+	// Sailing on port tack, then tacking onto starboard
+	g.detectTack(40)
+	g.detectTack(-40)
+
+	if len(g.tacks) != 1 {
+		t.Fatalf("Expected 1 tack recorded, got %d", len(g.tacks))
+	}
+	if g.tacks[0].WindDirection != 10 {
+		t.Errorf("Expected wind direction 10° recorded at the tack, got %.1f°", g.tacks[0].WindDirection)
+	}
+
+	// Gybing downwind is not a tack
+	g.detectTack(170)
+	g.detectTack(-170)
+	if len(g.tacks) != 1 {
+		t.Errorf("Expected gybe not to be counted as a tack, got %d tacks", len(g.tacks))
+	}
+}
+
+func TestTackSummary(t *testing.T) {
+	tacks := []TackRecord{
+		{Classification: TackOnHeader},
+		{Classification: TackOnHeader},
+		{Classification: TackOnLift},
+		{Classification: TackOnHeader},
+		{Classification: TackInSteady},
+		{Classification: TackOnHeader},
+	}
+
+	expected := "You tacked on 4 headers and 1 lift"
+	if got := tackSummary(tacks); got != expected {
+		t.Errorf("tackSummary() = %q, expected %q", got, expected)
+	}
+}
+
+func TestWindShiftVisible_HiddenDuringStartBias(t *testing.T) {
+	g := createTestGame()
+	wind := world.NewOscillatingWind(10, 10, WorldWidth, 0)
+	g.Wind = wind
+
+	if g.windShiftVisible() {
+		t.Error("Expected no lift/header indicator before the start")
+	}
+
+	// The gun can go while the opening bias shift is still running
+	g.raceStarted = true
+	if g.windShiftVisible() {
+		t.Error("Expected no lift/header indicator during the start-line bias shift")
+	}
+
+	for i := 0; i < 46; i++ {
+		wind.Advance(time.Second)
+	}
+	if !g.windShiftVisible() {
+		t.Error("Expected the lift/header indicator once the wind oscillates around its median")
+	}
+}
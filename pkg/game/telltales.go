@@ -9,6 +9,7 @@ import (
 	"github.com/mpihlak/gosailing2/pkg/dashboard"
 	"github.com/mpihlak/gosailing2/pkg/game/objects"
 	"github.com/mpihlak/gosailing2/pkg/game/world"
+	"github.com/mpihlak/gosailing2/pkg/polars"
 )
 
 // Telltales represents a single jib telltale that indicates sailing efficiency
@@ -18,6 +19,11 @@ type Telltales struct {
 	BaseY   float64 // Screen Y position (hinge point)
 	Angle   float64 // Telltale angle in degrees (0 = horizontal, negative = up, positive = down)
 	Visible bool    // Whether telltale should be shown (always true now)
+	// Response curve breakpoints as fractions of the best VMG. Lower values make the
+	// telltale more forgiving (beginners), higher values stricter (experts).
+	GrooveThreshold float64 // At or above this the telltale streams horizontally (in the groove)
+	GoodThreshold   float64 // Deflection reaches 45° here
+	PoorThreshold   float64 // Deflection reaches 75° here
 	// Wobble animation
 	elapsedTime float64 // Time elapsed for wobble animation
 	wobblePhase float64 // Phase offset for wobble (randomized)
@@ -25,15 +31,25 @@ type Telltales struct {
 
 // NewTelltales creates a new telltales instance
 func NewTelltales(screenWidth, screenHeight int) *Telltales {
-	return &Telltales{
+	t := &Telltales{
 		Length:      75.0,
-		BaseX:       float64(screenWidth/2 - 50), // Left of center
-		BaseY:       80.0,                        // Below timer and OCS warning
-		Angle:       0.0,                         // Start horizontal
-		Visible:     true,                        // Always visible now
+		BaseY:       80.0, // Below timer and OCS warning
+		Angle:       0.0,  // Start horizontal
+		Visible:     true, // Always visible now
 		elapsedTime: 0.0,
 		wobblePhase: math.Pi * 0.3, // Slight phase offset for natural look
+		// Default response curve
+		GrooveThreshold: 0.95,
+		GoodThreshold:   0.75,
+		PoorThreshold:   0.50,
 	}
+	t.Layout(screenWidth)
+	return t
+}
+
+// Layout centers the telltales for a screen of the given width
+func (t *Telltales) Layout(screenWidth int) {
+	t.BaseX = float64(screenWidth/2 - 50) // Left of center
 }
 
 // Update calculates telltale position based on boat performance
@@ -78,17 +94,8 @@ func (t *Telltales) findOptimalTWA(boat *objects.Boat, windSpeed float64, absTWA
 	bestTWA := 45.0 // Default fallback
 
 	if absTWA <= 90 {
-		// Upwind sailing - search for best VMG angle between 30-60 degrees
-		for angle := 30.0; angle <= 60.0; angle += 1.0 {
-			speed := boat.Polars.GetBoatSpeed(angle, windSpeed)
-			angleRad := angle * math.Pi / 180
-			vmg := speed * math.Cos(angleRad)
-
-			if vmg > bestVMG {
-				bestVMG = vmg
-				bestTWA = angle
-			}
-		}
+		// Upwind sailing - best VMG angle for the local wind (tighter in gusts)
+		bestTWA = polars.OptimalBeatAngle(boat.Polars, windSpeed)
 	} else {
 		// Downwind sailing - search for best VMG angle between 120-170 degrees
 		bestVMG = 1000.0 // Start with high value for downwind (looking for most negative VMG)
@@ -116,25 +123,7 @@ func (t *Telltales) calculateTelltaleAngle(absTWA, optimalTWA, efficiency, windS
 	// Clamp efficiency to reasonable range
 	efficiency = math.Max(0.0, math.Min(efficiency, 1.2)) // Allow slight over-efficiency
 
-	// Aggressive telltale deflection based on VMG efficiency
-	var deflectionAngle float64
-
-	if efficiency >= 0.95 {
-		// Very efficient sailing (95%+) - telltale nearly horizontal
-		deflectionAngle = 0.0
-	} else if efficiency >= 0.75 {
-		// Good sailing (75-95%) - linear interpolation from 0° to 45°
-		factor := (0.95 - efficiency) / (0.95 - 0.75) // 0 to 1 as efficiency drops from 95% to 75%
-		deflectionAngle = 45.0 * factor
-	} else if efficiency >= 0.50 {
-		// Poor sailing (50-75%) - steeper curve from 45° to 75°
-		factor := (0.75 - efficiency) / (0.75 - 0.50) // 0 to 1 as efficiency drops from 75% to 50%
-		deflectionAngle = 45.0 + 30.0*factor          // 45° to 75°
-	} else {
-		// Very poor sailing (below 50%) - nearly vertical at 85°
-		factor := math.Max(0.0, efficiency) / 0.50 // 0 to 1 as efficiency goes from 0% to 50%
-		deflectionAngle = 85.0 - 10.0*factor       // 85° down to 75°
-	}
+	deflectionAngle := t.deflection(efficiency)
 
 	// Determine direction based on sailing mode relative to optimal TWA
 	angleDiff := absTWA - optimalTWA
@@ -170,6 +159,28 @@ func (t *Telltales) calculateTelltaleAngle(absTWA, optimalTWA, efficiency, windS
 	t.Angle = baseAngle + totalWobble
 }
 
+// deflection returns how far the telltale droops or lifts (degrees) for a VMG efficiency,
+// using an aggressive response curve through the configured breakpoints
+func (t *Telltales) deflection(efficiency float64) float64 {
+	groove, good, poor := t.GrooveThreshold, t.GoodThreshold, t.PoorThreshold
+
+	if efficiency >= groove {
+		// In the groove - telltale nearly horizontal
+		return 0.0
+	} else if efficiency >= good {
+		// Good sailing - linear interpolation from 0° to 45°
+		factor := (groove - efficiency) / (groove - good) // 0 to 1 as efficiency drops to the good breakpoint
+		return 45.0 * factor
+	} else if efficiency >= poor {
+		// Poor sailing - steeper curve from 45° to 75°
+		factor := (good - efficiency) / (good - poor) // 0 to 1 as efficiency drops to the poor breakpoint
+		return 45.0 + 30.0*factor                     // 45° to 75°
+	}
+	// Very poor sailing - nearly vertical at 85°
+	factor := math.Max(0.0, efficiency) / poor // 0 to 1 as efficiency goes from 0 to the poor breakpoint
+	return 85.0 - 10.0*factor                  // 85° down to 75°
+}
+
 // Draw renders the single red telltale on screen
 func (t *Telltales) Draw(screen *ebiten.Image) {
 	if !t.Visible {
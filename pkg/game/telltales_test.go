@@ -0,0 +1,39 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestTelltales_LowerGrooveThresholdIsMoreForgiving(t *testing.T) {
+	expert := NewTelltales(ScreenWidth, ScreenHeight)
+	beginner := NewTelltales(ScreenWidth, ScreenHeight)
+	beginner.GrooveThreshold = 0.85
+
+	if got := expert.deflection(0.88); got <= 0 {
+		t.Errorf("Expected the default telltale to droop at 88%% efficiency, got %.1f°", got)
+	}
+	if got := beginner.deflection(0.88); got != 0 {
+		t.Errorf("Expected a horizontal telltale at 88%% with an 85%% groove threshold, got %.1f°", got)
+	}
+
+	// The curve still meets the good breakpoint at 45°
+	if got := beginner.deflection(beginner.GoodThreshold); got != 45 {
+		t.Errorf("Expected 45° at the good breakpoint, got %.1f°", got)
+	}
+}
+
+func TestTelltales_BreakpointsShapeCurve(t *testing.T) {
+	tt := NewTelltales(ScreenWidth, ScreenHeight)
+	tt.GoodThreshold = 0.6
+	tt.PoorThreshold = 0.4
+
+	if got := tt.deflection(0.6); got != 45 {
+		t.Errorf("Expected 45° at the good breakpoint, got %.1f°", got)
+	}
+	if got := tt.deflection(0.4); got != 75 {
+		t.Errorf("Expected 75° at the poor breakpoint, got %.1f°", got)
+	}
+	if got := tt.deflection(0); got != 85 {
+		t.Errorf("Expected 85° with no VMG, got %.1f°", got)
+	}
+}
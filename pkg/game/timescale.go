@@ -0,0 +1,44 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// Bullet time (easy mode) slows the simulation at the trickiest moments of the race
+const (
+	bulletTimeScale     = 0.5              // Simulation speed while slowed down
+	bulletTimeBeforeGun = 10 * time.Second // Slow down for the final seconds before the start
+	markZoneRadius      = 30.0             // Meters from the upwind mark (three boat lengths)
+)
+
+// timeScale returns the simulation speed multiplier for the current frame:
+// bulletTimeScale in the final seconds before the gun and inside the mark zone
+// when bullet time is enabled, otherwise real time
+func (g *GameState) timeScale() float64 {
+	if !g.settings.BulletTime {
+		return 1
+	}
+
+	if !g.raceStarted && g.timerDuration-g.elapsedTime <= bulletTimeBeforeGun {
+		return bulletTimeScale
+	}
+
+	if g.hasCrossedLine && !g.markRounded {
+		mark := g.Dashboard.UpwindMark
+		if math.Hypot(g.Boat.Pos.X-mark.X, g.Boat.Pos.Y-mark.Y) <= markZoneRadius {
+			return bulletTimeScale
+		}
+	}
+
+	return 1
+}
+
+// simulationSteps accumulates the time scale and returns how many fixed physics steps
+// to run this frame (at half speed the boat moves every other frame)
+func (g *GameState) simulationSteps(scale float64) int {
+	g.stepAccumulator += scale
+	steps := int(g.stepAccumulator)
+	g.stepAccumulator -= float64(steps)
+	return steps
+}
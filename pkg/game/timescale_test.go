@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestTimeScale_SlowsBeforeGunAndRestores(t *testing.T) {
+	g := createTestGame()
+	g.settings.BulletTime = true
+
+	g.elapsedTime = g.timerDuration - 15*time.Second
+	if scale := g.timeScale(); scale != 1 {
+		t.Errorf("Expected real time 15s before the gun, got %.2fx", scale)
+	}
+
+	g.elapsedTime = g.timerDuration - 5*time.Second
+	if scale := g.timeScale(); scale != bulletTimeScale {
+		t.Errorf("Expected %.1fx in the final seconds before the gun, got %.2fx", bulletTimeScale, scale)
+	}
+
+	g.elapsedTime = g.timerDuration + time.Second
+	g.raceStarted = true
+	if scale := g.timeScale(); scale != 1 {
+		t.Errorf("Expected real time restored after the gun, got %.2fx", scale)
+	}
+}
+
+func TestTimeScale_SlowsInMarkZone(t *testing.T) {
+	g := createTestGame()
+	g.settings.BulletTime = true
+	g.raceStarted = true
+	g.hasCrossedLine = true
+
+	g.Boat.Pos = geometry.Point{X: 1000, Y: 1820} // 20m from the upwind mark
+	if scale := g.timeScale(); scale != bulletTimeScale {
+		t.Errorf("Expected %.1fx inside the mark zone, got %.2fx", bulletTimeScale, scale)
+	}
+
+	g.markRounded = true
+	if scale := g.timeScale(); scale != 1 {
+		t.Errorf("Expected real time once the mark is rounded, got %.2fx", scale)
+	}
+}
+
+func TestTimeScale_OffByDefault(t *testing.T) {
+	g := createTestGame()
+	g.elapsedTime = g.timerDuration - 5*time.Second
+
+	if scale := g.timeScale(); scale != 1 {
+		t.Errorf("Expected real time with bullet time disabled, got %.2fx", scale)
+	}
+}
+
+func TestSimulationSteps_HalfSpeed(t *testing.T) {
+	g := createTestGame()
+
+	steps := 0
+	for i := 0; i < 10; i++ {
+		steps += g.simulationSteps(bulletTimeScale)
+	}
+	if steps != 5 {
+		t.Errorf("Expected 5 physics steps in 10 frames at half speed, got %d", steps)
+	}
+
+	steps = 0
+	for i := 0; i < 10; i++ {
+		steps += g.simulationSteps(1)
+	}
+	if steps != 10 {
+		t.Errorf("Expected one step per frame at real time, got %d", steps)
+	}
+}
@@ -3,11 +3,14 @@ package world
 import (
 	"image/color"
 	"math"
+	"strconv"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
 )
 
 // CollisionType identifies what was hit
@@ -29,44 +32,84 @@ type CollisionEvent struct {
 // Mark radius constant (meters)
 const MarkRadius = 0.5
 
+// Committee boat hull radius (meters)
+const CommitteeRadius = 5.0
+
+// Rounding is the side a boat leaves a mark on when rounding it
+type Rounding int
+
+const (
+	RoundToPort      Rounding = iota // Mark on the boat's left, rounded counter-clockwise
+	RoundToStarboard                 // Mark on the boat's right, rounded clockwise
+)
+
+func (r Rounding) String() string {
+	if r == RoundToStarboard {
+		return "starboard"
+	}
+	return "port"
+}
+
 type Mark struct {
 	Pos  geometry.Point
 	Name string
+
+	// Side the mark must be left on (rounding marks only)
+	Rounding Rounding
+
+	// Whether boats bounce off the mark rather than just touching it. Only the committee
+	// boat is solid; the buoys are flagged as touched.
+	Solid bool
 }
 
-func (m *Mark) Draw(screen *ebiten.Image) {
+// Radius returns the mark's collision radius in meters
+func (m *Mark) Radius() float64 {
+	if m.Solid {
+		return CommitteeRadius
+	}
+	return MarkRadius
+}
+
+func (m *Mark) Draw(screen *ebiten.Image, view View) {
+	x, y := view.ToScreen(m.Pos.X, m.Pos.Y)
+	u := view.Length(1) // Pixels per meter
+
 	if m.Name == "Pin" {
-		// Draw a small flag at the pin end
-		// Flag pole (vertical line)
-		ebitenutil.DrawLine(screen, m.Pos.X, m.Pos.Y-10, m.Pos.X, m.Pos.Y+5, color.RGBA{139, 69, 19, 255}) // Brown pole
-		// Flag (small triangle)
-		flagColor := color.RGBA{255, 0, 0, 255} // Red flag
-		// Draw flag as small filled triangle
-		for i := 0; i < 6; i++ {
-			ebitenutil.DrawLine(screen, m.Pos.X, m.Pos.Y-10+float64(i), m.Pos.X+8-float64(i), m.Pos.Y-10+float64(i), flagColor)
-		}
-		// Mark base (small circle)
-		ebitenutil.DrawRect(screen, m.Pos.X-2, m.Pos.Y-2, 4, 4, color.RGBA{255, 0, 0, 255})
-	} else if m.Name == "Upwind" {
-		// Draw upwind mark with orange flag (same design as pin)
-		// Flag pole (vertical line)
-		ebitenutil.DrawLine(screen, m.Pos.X, m.Pos.Y-10, m.Pos.X, m.Pos.Y+5, color.RGBA{139, 69, 19, 255}) // Brown pole
-		// Flag (small triangle)
-		flagColor := color.RGBA{255, 165, 0, 255} // Orange flag
-		// Draw flag as small filled triangle
-		for i := 0; i < 6; i++ {
-			ebitenutil.DrawLine(screen, m.Pos.X, m.Pos.Y-10+float64(i), m.Pos.X+8-float64(i), m.Pos.Y-10+float64(i), flagColor)
-		}
-		// Mark base (small circle)
-		ebitenutil.DrawRect(screen, m.Pos.X-2, m.Pos.Y-2, 4, 4, color.RGBA{255, 165, 0, 255}) // Orange base
+		// Draw a small red flag at the pin end
+		drawFlagMark(screen, x, y, u, color.RGBA{255, 0, 0, 255})
+	} else if m.Solid {
+		// Draw the committee boat
+		ebitenutil.DrawRect(screen, x-5*u, y-5*u, 10*u, 10*u, color.RGBA{255, 0, 0, 255})
 	} else {
-		// Draw regular mark (committee boat)
-		ebitenutil.DrawRect(screen, m.Pos.X-5, m.Pos.Y-5, 10, 10, color.RGBA{255, 0, 0, 255})
+		// Draw rounding marks, whatever the course calls them, with an orange flag (same design as pin)
+		drawFlagMark(screen, x, y, u, color.RGBA{255, 165, 0, 255})
 	}
 }
 
+// drawFlagMark draws a buoy flying a small flag of the given color at screen position x, y
+// (u pixels per meter)
+func drawFlagMark(screen *ebiten.Image, x, y, u float64, flagColor color.Color) {
+	// Flag pole (vertical line)
+	ebitenutil.DrawLine(screen, x, y-10*u, x, y+5*u, color.RGBA{139, 69, 19, 255}) // Brown pole
+	// Draw flag as small filled triangle
+	for i := 0.0; i < 6; i += 1 / u {
+		ebitenutil.DrawLine(screen, x, y+(i-10)*u, x+(8-i)*u, y+(i-10)*u, flagColor)
+	}
+	// Mark base (small circle)
+	ebitenutil.DrawRect(screen, x-2*u, y-2*u, 4*u, 4*u, flagColor)
+}
+
 type Arena struct {
-	Marks []*Mark
+	Marks          []*Mark
+	Line           *StartLine    // Start/finish line between the pin and committee marks
+	Gate           *Gate         // Leeward gate to pass between before finishing (nil = no gate)
+	Polars         polars.Polars // Boat performance for layline angles (nil = fixed 45°)
+	Current        Current       // Tidal current shown as arrows (nil = slack water)
+	ShowWindLabels bool          // Print numeric wind speed next to each wind barb
+	ShowLineSag    bool          // Show the mid-line sag coaching overlay before the start
+
+	barbs  barbLayer   // Wind barbs drawn for the visible course, redrawn when the wind shifts
+	course courseLayer // Marks, lines and current arrows, redrawn when the camera moves on
 }
 
 // CheckCollisions detects if boat has collided with any marks
@@ -82,7 +125,7 @@ func (a *Arena) CheckCollisions(boatPos geometry.Point, boatRadius float64) []Co
 		distance := math.Sqrt(dx*dx + dy*dy)
 
 		// Check if collision occurred
-		if distance < (boatRadius + MarkRadius) {
+		if distance < (boatRadius + mark.Radius()) {
 			collisions = append(collisions, CollisionEvent{
 				Type:      CollisionMark,
 				MarkName:  mark.Name,
@@ -95,8 +138,10 @@ func (a *Arena) CheckCollisions(boatPos geometry.Point, boatRadius float64) []Co
 	return collisions
 }
 
-// drawDottedLine draws a dotted line between two points
-func (a *Arena) drawDottedLine(screen *ebiten.Image, x1, y1, x2, y2 float64, lineColor color.Color) {
+// drawDottedLine draws a dotted line between two world points
+func (a *Arena) drawDottedLine(screen *ebiten.Image, view View, wx1, wy1, wx2, wy2 float64, lineColor color.Color) {
+	x1, y1 := view.ToScreen(wx1, wy1)
+	x2, y2 := view.ToScreen(wx2, wy2)
 	dx := x2 - x1
 	dy := y2 - y1
 	distance := math.Sqrt(dx*dx + dy*dy)
@@ -109,9 +154,9 @@ func (a *Arena) drawDottedLine(screen *ebiten.Image, x1, y1, x2, y2 float64, lin
 	unitX := dx / distance
 	unitY := dy / distance
 
-	// Draw dotted line with 5 pixel segments and 2.5 pixel gaps
-	segmentLength := 5.0
-	gapLength := 2.5
+	// Draw dotted line with 5 meter segments and 2.5 meter gaps
+	segmentLength := view.Length(5.0)
+	gapLength := view.Length(2.5)
 	totalStep := segmentLength + gapLength
 
 	for t := 0.0; t < distance; t += totalStep {
@@ -130,12 +175,13 @@ func (a *Arena) drawDottedLine(screen *ebiten.Image, x1, y1, x2, y2 float64, lin
 }
 
 // drawWindBarb draws a wind barb at the specified position showing wind direction and strength
-func (a *Arena) drawWindBarb(screen *ebiten.Image, x, y float64, windDir, windSpeed float64) {
+func (a *Arena) drawWindBarb(screen *ebiten.Image, view View, wx, wy float64, windDir, windSpeed float64) {
 	// Light gray color as requested
 	windColor := color.RGBA{192, 192, 192, 255}
+	x, y := view.ToScreen(wx, wy)
 
 	// Wind barb shaft length (main line showing direction)
-	shaftLength := 20.0
+	shaftLength := view.Length(20.0)
 
 	// Convert wind direction to radians (wind direction is where wind comes FROM)
 	dirRad := windDir * math.Pi / 180.0
@@ -153,7 +199,7 @@ func (a *Arena) drawWindBarb(screen *ebiten.Image, x, y float64, windDir, windSp
 	halfBarb := (int(windSpeed) % 10) >= 5
 
 	// Barb length and perpendicular angle
-	barbLength := 8.0
+	barbLength := view.Length(8.0)
 	perpAngle := (dirRad + math.Pi) + math.Pi/2 // Perpendicular to shaft direction
 
 	// Draw full barbs (every 10 knots)
@@ -188,74 +234,233 @@ func (a *Arena) drawWindBarb(screen *ebiten.Image, x, y float64, windDir, windSp
 	}
 }
 
+// laylineBearings returns the bearings (degrees) along which the starboard and port laylines
+// extend downwind from the mark. They follow the local wind at the mark, so a gust there
+// (higher wind, tighter beat angle) narrows the laylines.
+func (a *Arena) laylineBearings(mark *Mark, wind Wind) (starboard, port float64) {
+	windDir, beatAngle := a.markBeat(mark, wind)
+	downwind := windDir + 180
+	return math.Mod(downwind+beatAngle, 360), math.Mod(downwind-beatAngle+360, 360)
+}
+
+// markBeat returns the wind direction and best beat angle at the mark (45° without wind or polars)
+func (a *Arena) markBeat(mark *Mark, wind Wind) (windDir, beatAngle float64) {
+	windDir, beatAngle = 0.0, 45.0
+	if wind != nil {
+		var windSpeed float64
+		windDir, windSpeed = wind.GetWind(mark.Pos)
+		if a.Polars != nil {
+			beatAngle = polars.OptimalBeatAngle(a.Polars, windSpeed)
+		}
+	}
+	return windDir, beatAngle
+}
+
+// Degrees of slack when deciding whether the boat is on a layline (heading changes in 1° steps)
+const laylineTolerance = 1.0
+
+// CanFetchMark reports whether a boat at pos sailing upwind on heading can lay the upwind mark
+// on its current tack without tacking again, i.e. it is on or above that tack's layline.
+func (a *Arena) CanFetchMark(pos geometry.Point, heading float64, wind Wind) bool {
+	if len(a.Marks) < 3 {
+		return false
+	}
+	upwindMark := a.Marks[2]
+	windDir, beatAngle := a.markBeat(upwindMark, wind)
+
+	twa := geometry.NormalizeAngle(heading - windDir)
+	if twa == 0 || math.Abs(twa) >= 90 {
+		return false // Head to wind or not beating
+	}
+
+	// Bearing to the mark relative to the wind; the mark must be upwind of the boat
+	bearing := math.Atan2(upwindMark.Pos.X-pos.X, pos.Y-upwindMark.Pos.Y) * 180 / math.Pi
+	offset := geometry.NormalizeAngle(bearing - windDir)
+	if math.Abs(offset) >= 90 {
+		return false
+	}
+
+	if twa > 0 {
+		// Port tack: the mark must be at least a beat angle to the right of the wind
+		return offset >= beatAngle-laylineTolerance
+	}
+	// Starboard tack: at least a beat angle to the left of the wind
+	return offset <= -beatAngle+laylineTolerance
+}
+
+// BelowLaylines reports whether a boat beating toward the upwind mark is below both laylines:
+// it can't lay the mark on its current tack, and tacking now wouldn't lay it either, so it
+// should keep going
+func (a *Arena) BelowLaylines(pos geometry.Point, heading float64, wind Wind) bool {
+	if len(a.Marks) < 3 {
+		return false
+	}
+	upwindMark := a.Marks[2]
+	windDir, _ := a.markBeat(upwindMark, wind)
+
+	twa := geometry.NormalizeAngle(heading - windDir)
+	if twa == 0 || math.Abs(twa) >= 90 {
+		return false // Head to wind or not beating
+	}
+	bearing := math.Atan2(upwindMark.Pos.X-pos.X, pos.Y-upwindMark.Pos.Y) * 180 / math.Pi
+	if math.Abs(geometry.NormalizeAngle(bearing-windDir)) >= 90 {
+		return false // Mark is not upwind
+	}
+
+	otherTack := windDir - twa
+	return !a.CanFetchMark(pos, heading, wind) && !a.CanFetchMark(pos, otherTack, wind)
+}
+
+// LaylineMargin returns how far past the layline for the other tack a boat beating toward
+// the upwind mark on heading is, in degrees: 0 on the layline, negative below it, positive
+// when carrying on would overstand. Returns false when the boat isn't beating or the mark
+// isn't upwind.
+func (a *Arena) LaylineMargin(pos geometry.Point, heading float64, wind Wind) (float64, bool) {
+	if len(a.Marks) < 3 {
+		return 0, false
+	}
+	upwindMark := a.Marks[2]
+	windDir, beatAngle := a.markBeat(upwindMark, wind)
+
+	twa := geometry.NormalizeAngle(heading - windDir)
+	if twa == 0 || math.Abs(twa) >= 90 {
+		return 0, false // Head to wind or not beating
+	}
+	bearing := math.Atan2(upwindMark.Pos.X-pos.X, pos.Y-upwindMark.Pos.Y) * 180 / math.Pi
+	offset := geometry.NormalizeAngle(bearing - windDir)
+	if math.Abs(offset) >= 90 {
+		return 0, false // Mark is not upwind
+	}
+
+	if twa > 0 {
+		// Port tack: starboard lays the mark once it's a beat angle left of the wind
+		return -offset - beatAngle, true
+	}
+	// Starboard tack: port lays the mark once it's a beat angle right of the wind
+	return offset - beatAngle, true
+}
+
 // drawLaylines draws the starboard and port laylines for the upwind mark
-func (a *Arena) drawLaylines(screen *ebiten.Image) {
+func (a *Arena) drawLaylines(screen *ebiten.Image, view View, wind Wind) {
 	// Find upwind mark (third mark in the array)
 	if len(a.Marks) < 3 {
 		return
 	}
 	upwindMark := a.Marks[2]
 
-	// Wind is from North (0 degrees), laylines show the close-hauled approach paths to the mark
-	// Since positive Y is down (toward starting line), we want laylines extending in positive Y direction
-	// Starboard tack: boats sail at 45° to wind (northeast), layline extends southwest from mark
-	// Port tack: boats sail at -45° to wind (northwest), layline extends southeast from mark
+	// Laylines show the close-hauled approach paths to the mark, extending downwind from it
+	// either side of the local wind: with wind from North and a 45° beat, starboard runs
+	// southwest (225°) and port southeast (135°); both rotate with the wind direction
 
 	laylineColor := color.RGBA{128, 128, 128, 100} // Light gray with transparency
 
 	// Calculate layline length (extend toward starting line)
 	laylineLength := 1500.0
 
-	// Starboard layline: extends southwest from mark (225°)
-	starboardAngle := 225.0 * math.Pi / 180
+	starboardBearing, portBearing := a.laylineBearings(upwindMark, wind)
+
+	// Starboard layline
+	starboardAngle := starboardBearing * math.Pi / 180
 	starboardEndX := upwindMark.Pos.X + laylineLength*math.Sin(starboardAngle)
-	starboardEndY := upwindMark.Pos.Y - laylineLength*math.Cos(starboardAngle) // Negative cos(225°) makes this positive Y
+	starboardEndY := upwindMark.Pos.Y - laylineLength*math.Cos(starboardAngle)
 
-	// Port layline: extends southeast from mark (135°)
-	portAngle := 135.0 * math.Pi / 180
+	// Port layline
+	portAngle := portBearing * math.Pi / 180
 	portEndX := upwindMark.Pos.X + laylineLength*math.Sin(portAngle)
-	portEndY := upwindMark.Pos.Y - laylineLength*math.Cos(portAngle) // Negative cos(135°) makes this positive Y
+	portEndY := upwindMark.Pos.Y - laylineLength*math.Cos(portAngle)
 
 	// Draw both laylines as dotted lines (extending toward starting line)
-	a.drawDottedLine(screen, upwindMark.Pos.X, upwindMark.Pos.Y, starboardEndX, starboardEndY, laylineColor)
-	a.drawDottedLine(screen, upwindMark.Pos.X, upwindMark.Pos.Y, portEndX, portEndY, laylineColor)
+	a.drawDottedLine(screen, view, upwindMark.Pos.X, upwindMark.Pos.Y, starboardEndX, starboardEndY, laylineColor)
+	a.drawDottedLine(screen, view, upwindMark.Pos.X, upwindMark.Pos.Y, portEndX, portEndY, laylineColor)
 }
 
-// drawWindIndicators draws wind barbs across the course at regular intervals
-func (a *Arena) drawWindIndicators(screen *ebiten.Image, wind Wind) {
-	// Grid spacing - every 150 pixels as requested
-	gridSpacing := 150.0
+// drawRhumbLine draws the direct line from the middle of the start line to the upwind mark
+func (a *Arena) drawRhumbLine(screen *ebiten.Image, view View) {
+	if a.Line == nil || len(a.Marks) < 3 {
+		return
+	}
+	start := a.Line.Midpoint()
+	mark := a.Marks[2].Pos
+
+	x1, y1 := view.ToScreen(start.X, start.Y)
+	x2, y2 := view.ToScreen(mark.X, mark.Y)
+	ebitenutil.DrawLine(screen, x1, y1, x2, y2, color.RGBA{255, 255, 255, 40})
+}
 
-	// Get screen bounds to know the area we need to cover
+// windSpeedLabel formats a wind speed (knots) for display next to a barb
+func windSpeedLabel(windSpeed float64) string {
+	return strconv.Itoa(int(math.Round(windSpeed)))
+}
+
+// drawWindIndicators draws wind barbs across the course at regular intervals. The barbs are
+// cached in a layer that is only redrawn when the wind shifts or the camera reaches new grid
+// points, and the layer is drawn at the camera offset.
+func (a *Arena) drawWindIndicators(screen *ebiten.Image, view View, wind Wind) {
+	// Cover the visible world area, keeping the grid fixed in world coordinates
 	bounds := screen.Bounds()
-	startX := 0.0
-	startY := 0.0
-	endX := float64(bounds.Max.X)
-	endY := float64(bounds.Max.Y)
-
-	// Draw wind barbs at grid points
-	for x := startX; x <= endX; x += gridSpacing {
-		for y := startY; y <= endY; y += gridSpacing {
-			// Get wind at this position
-			windDir, windSpeed := wind.GetWind(geometry.Point{X: x, Y: y})
-
-			// Draw wind barb at this grid point
-			a.drawWindBarb(screen, x, y, windDir, windSpeed)
-		}
+	grid := visibleGrid(view, bounds.Max.X, bounds.Max.Y)
+	samples := grid.sampleWind(wind)
+	if a.barbs.stale(grid, samples, a.ShowWindLabels) {
+		a.barbs.redraw(a, grid, samples, a.ShowWindLabels)
 	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(view.ToScreen(a.barbs.origin()))
+	screen.DrawImage(a.barbs.image, op)
 }
 
-func (a *Arena) Draw(screen *ebiten.Image, raceStarted bool, wind Wind) {
+// Draw renders the course onto screen, mapping world coordinates through view
+func (a *Arena) Draw(screen *ebiten.Image, raceStarted bool, wind Wind, view View) {
+	// Gust and lull patches sit on the water under everything else
+	if gw, ok := wind.(gustyWind); ok {
+		a.drawGusts(screen, view, gw.ActiveGusts())
+	}
+	if sw, ok := wind.(*ShadowedWind); ok {
+		a.drawShadows(screen, view, sw)
+	}
+
 	// Draw wind indicators first (in background)
 	if wind != nil {
-		a.drawWindIndicators(screen, wind)
+		a.drawWindIndicators(screen, view, wind)
+	}
+
+	// Coaching overlay: how far a mid-line start sags behind the ends
+	if a.ShowLineSag && !raceStarted {
+		a.drawLineSag(screen, view, wind)
+	}
+
+	// Draw laylines for upwind mark (if we have 3 marks including upwind); they follow
+	// the wind at the mark so they're drawn every frame
+	if len(a.Marks) >= 3 {
+		a.drawLaylines(screen, view, wind)
+	}
+
+	// The static course on top, from a cached layer drawn at the camera offset
+	bounds := screen.Bounds()
+	grid := visibleGrid(view, bounds.Max.X, bounds.Max.Y)
+	positions := a.coursePositions()
+	if a.course.stale(grid, raceStarted, positions) {
+		a.course.redraw(a, grid, raceStarted, positions)
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(view.ToScreen(a.course.origin()))
+	screen.DrawImage(a.course.image, op)
+
+	// Ring the favored end of the line before the start
+	if a.Line != nil && wind != nil && !raceStarted {
+		a.drawFavoredEnd(screen, view, wind)
 	}
+}
 
-	// Draw starting line if we have exactly 2 marks (Pin and Committee)
-	if len(a.Marks) == 2 {
-		pin := a.Marks[0]
-		committee := a.Marks[1]
+// drawCourse draws the parts of the course that don't move: current arrows, the start line,
+// rhumb line, gate and marks
+func (a *Arena) drawCourse(screen *ebiten.Image, view View, raceStarted bool) {
+	if a.Current != nil {
+		a.drawCurrentIndicators(screen, view)
+	}
 
+	// Draw starting line between the pin and committee
+	if a.Line != nil {
 		// Choose line color based on race state
 		var lineColor color.Color
 		if raceStarted {
@@ -265,16 +470,45 @@ func (a *Arena) Draw(screen *ebiten.Image, raceStarted bool, wind Wind) {
 		}
 
 		// Draw dotted line
-		a.drawDottedLine(screen, pin.Pos.X, pin.Pos.Y, committee.Pos.X, committee.Pos.Y, lineColor)
+		pin, committee := a.Line.Ends()
+		a.drawDottedLine(screen, view, pin.X, pin.Y, committee.X, committee.Y, lineColor)
 	}
 
-	// Draw laylines for upwind mark (if we have 3 marks including upwind)
+	// Rhumb line from the start to the upwind mark
 	if len(a.Marks) >= 3 {
-		a.drawLaylines(screen)
+		a.drawRhumbLine(screen, view)
 	}
 
-	// Draw marks
+	// Leeward gate and its zones
+	if a.Gate != nil {
+		a.drawGate(screen, view)
+	}
+
+	// Draw marks; the leeward gate marks fly yellow flags
 	for _, mark := range a.Marks {
-		mark.Draw(screen)
+		if a.Gate.Has(mark) {
+			x, y := view.ToScreen(mark.Pos.X, mark.Pos.Y)
+			drawFlagMark(screen, x, y, view.Length(1), color.RGBA{255, 255, 0, 255})
+			continue
+		}
+		mark.Draw(screen, view)
 	}
 }
+
+// drawFavoredEnd rings the end of the start line further upwind in the current wind
+func (a *Arena) drawFavoredEnd(screen *ebiten.Image, view View, wind Wind) {
+	windDir, _ := wind.GetWind(a.Line.Midpoint())
+	favored, _, _ := a.Line.Bias(windDir)
+	end := a.Line.Pin
+	switch favored {
+	case "Square":
+		return
+	case "Committee":
+		end = a.Line.Committee
+	}
+
+	x, y := view.ToScreen(end.Pos.X, end.Pos.Y)
+	r := view.Length(end.Radius() + 8)
+	vector.StrokeCircle(screen, float32(x), float32(y), float32(r), float32(view.Length(1.5)), color.RGBA{0, 220, 0, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "FAVORED", int(x)-21, int(y+r)+2)
+}
@@ -1,16 +1,18 @@
 package world
 
 import (
+	"math"
 	"testing"
 
 	"github.com/mpihlak/gosailing2/pkg/geometry"
+	"github.com/mpihlak/gosailing2/pkg/polars"
 )
 
 func TestCheckCollisions_DirectHit(t *testing.T) {
 	arena := &Arena{
 		Marks: []*Mark{
 			{Pos: geometry.Point{X: 1000, Y: 2400}, Name: "Pin"},
-			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
 		},
 	}
 
@@ -86,7 +88,7 @@ func TestCheckCollisions_MultipleMarks(t *testing.T) {
 	arena := &Arena{
 		Marks: []*Mark{
 			{Pos: geometry.Point{X: 1000, Y: 2400}, Name: "Pin"},
-			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
 			{Pos: geometry.Point{X: 1100, Y: 1800}, Name: "Upwind"},
 		},
 	}
@@ -106,6 +108,33 @@ func TestCheckCollisions_MultipleMarks(t *testing.T) {
 	}
 }
 
+func TestCheckCollisions_CommitteeBoatHull(t *testing.T) {
+	arena := &Arena{
+		Marks: []*Mark{
+			{Pos: geometry.Point{X: 1000, Y: 2400}, Name: "Pin"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+		},
+	}
+
+	// 8m from each: clear of the pin buoy but touching the committee boat's hull
+	if collisions := arena.CheckCollisions(geometry.Point{X: 1008, Y: 2400}, 5.0); len(collisions) != 0 {
+		t.Errorf("Expected no collision 8m from the pin, got %d", len(collisions))
+	}
+	collisions := arena.CheckCollisions(geometry.Point{X: 1192, Y: 2400}, 5.0)
+	if len(collisions) != 1 || collisions[0].MarkName != "Committee" {
+		t.Errorf("Expected a collision with the committee boat, got %+v", collisions)
+	}
+	if arena.Marks[0].Radius() != MarkRadius || arena.Marks[1].Radius() != CommitteeRadius {
+		t.Error("Expected only the committee boat to have a hull's radius")
+	}
+
+	// A buoy is only touched, whatever the course calls it
+	buoy := &Mark{Pos: geometry.Point{X: 1500, Y: 1500}, Name: "Committee"}
+	if buoy.Radius() != MarkRadius {
+		t.Errorf("Expected a buoy named Committee to keep the buoy radius, got %.1f", buoy.Radius())
+	}
+}
+
 func TestCheckCollisions_NoMarks(t *testing.T) {
 	arena := &Arena{
 		Marks: []*Mark{},
@@ -139,3 +168,198 @@ func TestCheckCollisions_DiagonalDistance(t *testing.T) {
 		t.Errorf("Expected collision at diagonal distance, got %d", len(collisions))
 	}
 }
+
+// gustWind is constant wind with a stronger gust patch around a point
+type gustWind struct {
+	base, gust float64
+	center     geometry.Point
+	radius     float64
+}
+
+func (w *gustWind) GetWind(pos geometry.Point) (float64, float64) {
+	dx, dy := pos.X-w.center.X, pos.Y-w.center.Y
+	if dx*dx+dy*dy <= w.radius*w.radius {
+		return 0, w.gust
+	}
+	return 0, w.base
+}
+
+func TestLaylineBearings_TightenInGustAtMark(t *testing.T) {
+	mark := &Mark{Pos: geometry.Point{X: 1000, Y: 1800}, Name: "Upwind"}
+	arena := &Arena{
+		Marks:  []*Mark{mark},
+		Polars: &polars.RealisticPolar{},
+	}
+
+	baseWind := &ConstantWind{Direction: 0, Speed: 8}
+	gust := &gustWind{base: 8, gust: 14, center: mark.Pos, radius: 100}
+
+	baseStarboard, basePort := arena.laylineBearings(mark, baseWind)
+	gustStarboard, gustPort := arena.laylineBearings(mark, gust)
+
+	// Laylines extend downwind (around 180°), narrower in the gust
+	baseSpread := baseStarboard - basePort
+	gustSpread := gustStarboard - gustPort
+	if gustSpread >= baseSpread {
+		t.Errorf("Expected laylines to narrow in a gust at the mark: base spread %.1f°, gust spread %.1f°", baseSpread, gustSpread)
+	}
+
+	// Gust away from the mark does not affect the laylines
+	farGust := &gustWind{base: 8, gust: 14, center: geometry.Point{X: 0, Y: 0}, radius: 100}
+	farStarboard, farPort := arena.laylineBearings(mark, farGust)
+	if farStarboard != baseStarboard || farPort != basePort {
+		t.Errorf("Expected gust away from the mark to leave laylines unchanged, got %.1f°/%.1f°", farStarboard, farPort)
+	}
+}
+
+func TestLaylineBearings_DefaultWithoutPolars(t *testing.T) {
+	mark := &Mark{Pos: geometry.Point{X: 1000, Y: 1800}, Name: "Upwind"}
+	arena := &Arena{Marks: []*Mark{mark}}
+
+	starboard, port := arena.laylineBearings(mark, nil)
+	if starboard != 225 || port != 135 {
+		t.Errorf("Expected default laylines 225°/135°, got %.1f°/%.1f°", starboard, port)
+	}
+}
+
+func TestLaylineBearings_FollowMedianDirection(t *testing.T) {
+	mark := &Mark{Pos: geometry.Point{X: 1000, Y: 1800}, Name: "Upwind"}
+	arena := &Arena{Marks: []*Mark{mark}}
+
+	starboard, port := arena.laylineBearings(mark, NewOscillatingWind(10, 10, 2000, 20))
+	if starboard != 245 || port != 155 {
+		t.Errorf("Expected laylines 245°/155° with the wind from 20°, got %.1f°/%.1f°", starboard, port)
+	}
+}
+
+func TestWindSpeedLabel_AtSamplePoints(t *testing.T) {
+	wind := &VariableWind{Direction: 0, LeftSpeed: 8, RightSpeed: 14, WorldWidth: 2000}
+
+	tests := []struct {
+		name     string
+		pos      geometry.Point
+		expected string
+	}{
+		{"Left edge", geometry.Point{X: 0, Y: 150}, "8"},
+		{"Grid point 600m", geometry.Point{X: 600, Y: 150}, "10"},
+		{"Just right of center", geometry.Point{X: 1050, Y: 150}, "11"},
+		{"Half knot rounds up", geometry.Point{X: 1500, Y: 150}, "13"},
+		{"Right edge", geometry.Point{X: 2000, Y: 150}, "14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, speed := wind.GetWind(tt.pos)
+			if got := windSpeedLabel(speed); got != tt.expected {
+				t.Errorf("windSpeedLabel(%.2f) = %q, expected %q", speed, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanFetchMark(t *testing.T) {
+	// Upwind mark at (1000, 1000), wind from north, fixed 45° beat (no polars)
+	arena := &Arena{
+		Marks: []*Mark{
+			{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+			{Pos: geometry.Point{X: 1000, Y: 1000}, Name: "Upwind"},
+		},
+	}
+	wind := &ConstantWind{Direction: 0, Speed: 10}
+
+	tests := []struct {
+		name     string
+		pos      geometry.Point
+		heading  float64
+		expected bool
+	}{
+		{"Starboard on the layline", geometry.Point{X: 1500, Y: 1500}, 315, true},
+		{"Starboard above the layline", geometry.Point{X: 1500, Y: 1200}, 315, true},
+		{"Starboard below the layline", geometry.Point{X: 1200, Y: 1800}, 315, false},
+		{"Port on the layline", geometry.Point{X: 500, Y: 1500}, 45, true},
+		{"Port below the layline", geometry.Point{X: 800, Y: 1800}, 45, false},
+		{"Wrong tack for the layline", geometry.Point{X: 1500, Y: 1500}, 45, false},
+		{"Head to wind", geometry.Point{X: 1000, Y: 1500}, 0, false},
+		{"Reaching, not beating", geometry.Point{X: 1500, Y: 1500}, 270, false},
+		{"Mark already behind", geometry.Point{X: 1500, Y: 900}, 315, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := arena.CanFetchMark(tt.pos, tt.heading, wind); got != tt.expected {
+				t.Errorf("CanFetchMark(%v, %.0f°) = %v, expected %v", tt.pos, tt.heading, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBelowLaylines(t *testing.T) {
+	// Same layout as TestCanFetchMark: mark at (1000, 1000), wind from north, 45° beat
+	arena := &Arena{
+		Marks: []*Mark{
+			{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+			{Pos: geometry.Point{X: 1000, Y: 1000}, Name: "Upwind"},
+		},
+	}
+	wind := &ConstantWind{Direction: 0, Speed: 10}
+
+	tests := []struct {
+		name     string
+		pos      geometry.Point
+		heading  float64
+		expected bool
+	}{
+		{"Starboard below both laylines", geometry.Point{X: 1200, Y: 1800}, 315, true},
+		{"Port below both laylines", geometry.Point{X: 800, Y: 1800}, 45, true},
+		{"Starboard on the layline", geometry.Point{X: 1500, Y: 1500}, 315, false},
+		{"Port past the starboard layline", geometry.Point{X: 1500, Y: 1500}, 45, false},
+		{"Reaching, not beating", geometry.Point{X: 1000, Y: 1800}, 270, false},
+		{"Mark already behind", geometry.Point{X: 1000, Y: 900}, 315, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := arena.BelowLaylines(tt.pos, tt.heading, wind); got != tt.expected {
+				t.Errorf("BelowLaylines(%v, %.0f°) = %v, expected %v", tt.pos, tt.heading, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLaylineMargin(t *testing.T) {
+	// Same layout as TestCanFetchMark: mark at (1000, 1000), wind from north, 45° beat
+	arena := &Arena{
+		Marks: []*Mark{
+			{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+			{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+			{Pos: geometry.Point{X: 1000, Y: 1000}, Name: "Upwind"},
+		},
+	}
+	wind := &ConstantWind{Direction: 0, Speed: 10}
+
+	tests := []struct {
+		name    string
+		pos     geometry.Point
+		heading float64
+		want    float64
+	}{
+		{"Port on the starboard layline", geometry.Point{X: 1500, Y: 1500}, 45, 0},
+		{"Port past the starboard layline", geometry.Point{X: 1500, Y: 1200}, 45, math.Atan2(500, 200)*180/math.Pi - 45},
+		{"Port below the starboard layline", geometry.Point{X: 1200, Y: 1800}, 45, math.Atan2(200, 800)*180/math.Pi - 45},
+		{"Starboard on the port layline", geometry.Point{X: 500, Y: 1500}, 315, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := arena.LaylineMargin(tt.pos, tt.heading, wind)
+			if !ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("LaylineMargin(%v, %.0f°) = %.2f, %v, expected %.2f", tt.pos, tt.heading, got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := arena.LaylineMargin(geometry.Point{X: 1000, Y: 1500}, 270, wind); ok {
+		t.Error("Expected no margin when reaching")
+	}
+}
@@ -0,0 +1,87 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// BoundaryMode is how the edge of the sailing area stops a boat that reaches it
+type BoundaryMode int
+
+const (
+	BoundaryWall     BoundaryMode = iota // A hard edge the boat bounces off, like the committee boat
+	BoundaryShallows                     // Shallow water along the edge slows the boat until it runs aground at the edge
+)
+
+// ErrInvalidBoundary is returned when parsing a boundary mode that isn't known
+var ErrInvalidBoundary = errors.New("boundary must be \"wall\" or \"shallows\"")
+
+func (m BoundaryMode) String() string {
+	if m == BoundaryShallows {
+		return "shallows"
+	}
+	return "wall"
+}
+
+// MarshalText writes the mode by name, so course files say "wall" or "shallows"
+func (m BoundaryMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses "wall" or "shallows"; an empty name is a wall
+func (m *BoundaryMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "wall":
+		*m = BoundaryWall
+	case "shallows":
+		*m = BoundaryShallows
+	default:
+		return fmt.Errorf("%w (got %q)", ErrInvalidBoundary, text)
+	}
+	return nil
+}
+
+// Width in meters of the shallows along the edge in BoundaryShallows mode
+const ShallowsWidth = 100.0
+
+// Boundary is the edge of the sailing area, the rectangle from (0, 0) to (Width, Height)
+type Boundary struct {
+	Width, Height float64
+	Mode          BoundaryMode
+}
+
+// DistanceToEdge returns how far pos is inside the nearest edge in meters (negative outside)
+func (bd *Boundary) DistanceToEdge(pos geometry.Point) float64 {
+	return math.Min(math.Min(pos.X, bd.Width-pos.X), math.Min(pos.Y, bd.Height-pos.Y))
+}
+
+// Clamp returns pos moved back inside the boundary, and the outward normal of the edge it
+// was past (zero when already inside)
+func (bd *Boundary) Clamp(pos geometry.Point) (geometry.Point, float64, float64) {
+	var nx, ny float64
+	switch {
+	case pos.X < 0:
+		pos.X, nx = 0, -1
+	case pos.X > bd.Width:
+		pos.X, nx = bd.Width, 1
+	}
+	switch {
+	case pos.Y < 0:
+		pos.Y, ny = 0, -1
+	case pos.Y > bd.Height:
+		pos.Y, ny = bd.Height, 1
+	}
+	return pos, nx, ny
+}
+
+// ShallowsDepth returns how far into the shallows pos is: 0 in open water, rising to 1 at the
+// edge. Always 0 for a wall.
+func (bd *Boundary) ShallowsDepth(pos geometry.Point) float64 {
+	if bd.Mode != BoundaryShallows {
+		return 0
+	}
+	return math.Max(0, math.Min(1, 1-bd.DistanceToEdge(pos)/ShallowsWidth))
+}
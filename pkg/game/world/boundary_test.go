@@ -0,0 +1,38 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestBoundary_ClampAndShallows(t *testing.T) {
+	bd := &Boundary{Width: 2000, Height: 3000, Mode: BoundaryShallows}
+
+	pos, nx, ny := bd.Clamp(geometry.Point{X: -20, Y: 3050})
+	if pos != (geometry.Point{X: 0, Y: 3000}) || nx != -1 || ny != 1 {
+		t.Errorf("Expected the corner and outward normal (-1, 1), got %+v (%.0f, %.0f)", pos, nx, ny)
+	}
+	if _, nx, ny := bd.Clamp(geometry.Point{X: 1000, Y: 1500}); nx != 0 || ny != 0 {
+		t.Errorf("Expected no edge crossed inside the boundary, got (%.0f, %.0f)", nx, ny)
+	}
+
+	tests := []struct {
+		pos  geometry.Point
+		want float64
+	}{
+		{geometry.Point{X: 1000, Y: 1500}, 0},
+		{geometry.Point{X: 1950, Y: 1500}, 0.5},
+		{geometry.Point{X: 1000, Y: 0}, 1},
+	}
+	for _, tt := range tests {
+		if got := bd.ShallowsDepth(tt.pos); got != tt.want {
+			t.Errorf("ShallowsDepth(%+v) = %.2f, expected %.2f", tt.pos, got, tt.want)
+		}
+	}
+
+	bd.Mode = BoundaryWall
+	if got := bd.ShallowsDepth(geometry.Point{X: 1000, Y: 0}); got != 0 {
+		t.Errorf("Expected no shallows along a wall, got %.2f", got)
+	}
+}
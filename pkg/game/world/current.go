@@ -0,0 +1,95 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Current is moving water that carries boats along regardless of their heading.
+// Unlike wind, the direction is where the current flows TO (its set), 0 = North.
+type Current interface {
+	GetCurrent(pos geometry.Point) (direction, speed float64)
+}
+
+// ConstantCurrent flows the same way at the same rate everywhere on the course
+type ConstantCurrent struct {
+	Direction float64 // Direction the water flows toward
+	Speed     float64 // Knots
+}
+
+func (cc *ConstantCurrent) GetCurrent(_ geometry.Point) (float64, float64) {
+	return cc.Direction, cc.Speed
+}
+
+// VariableCurrent provides a current that varies in strength across the course,
+// e.g. stronger tide out in the channel than along the shore
+type VariableCurrent struct {
+	Direction  float64 // Direction the water flows toward (constant)
+	LeftSpeed  float64 // Current speed on left side (X=0)
+	RightSpeed float64 // Current speed on right side (X=WorldWidth)
+	WorldWidth float64 // Width of the world for interpolation
+}
+
+func (vc *VariableCurrent) GetCurrent(pos geometry.Point) (float64, float64) {
+	if math.IsNaN(pos.X) || math.IsInf(pos.X, 0) || vc.WorldWidth <= 0 {
+		return vc.Direction, vc.LeftSpeed
+	}
+
+	xRatio := math.Max(0, math.Min(1, pos.X/vc.WorldWidth))
+	speed := vc.LeftSpeed + (vc.RightSpeed-vc.LeftSpeed)*xRatio
+	if math.IsNaN(speed) || speed < 0 {
+		speed = vc.LeftSpeed
+	}
+
+	return vc.Direction, speed
+}
+
+const (
+	currentGridSpacing   = 150.0 // Same spacing as the wind barbs
+	currentArrowPerKnot  = 15.0  // Arrow length in meters per knot of current
+	currentMinArrowSpeed = 0.05  // Slack water below this (knots) draws nothing
+)
+
+// drawCurrentArrow draws an arrow centered on a world position pointing where the water flows
+func (a *Arena) drawCurrentArrow(screen *ebiten.Image, view View, wx, wy, direction, speed float64) {
+	if speed < currentMinArrowSpeed {
+		return
+	}
+	currentColor := color.RGBA{80, 140, 255, 110} // Faint blue
+
+	dirRad := direction * math.Pi / 180
+	dx, dy := math.Sin(dirRad), -math.Cos(dirRad)
+	half := currentArrowPerKnot * speed / 2
+
+	x, y := view.ToScreen(wx, wy)
+	tailX, tailY := x-view.Length(half)*dx, y-view.Length(half)*dy
+	tipX, tipY := x+view.Length(half)*dx, y+view.Length(half)*dy
+	ebitenutil.DrawLine(screen, tailX, tailY, tipX, tipY, currentColor)
+
+	// Arrowhead: two short strokes swept back from the tip
+	headLength := view.Length(5.0)
+	for _, side := range []float64{-1, 1} {
+		angle := dirRad + math.Pi + side*math.Pi/6
+		ebitenutil.DrawLine(screen, tipX, tipY, tipX+headLength*math.Sin(angle), tipY-headLength*math.Cos(angle), currentColor)
+	}
+}
+
+// drawCurrentIndicators draws current arrows across the visible course, offset half a grid
+// cell from the wind barbs so the two don't overlap
+func (a *Arena) drawCurrentIndicators(screen *ebiten.Image, view View) {
+	bounds := screen.Bounds()
+	startX := math.Floor(view.OffsetX/currentGridSpacing)*currentGridSpacing + currentGridSpacing/2
+	startY := math.Floor(view.OffsetY/currentGridSpacing)*currentGridSpacing + currentGridSpacing/2
+	endX, endY := view.ToWorld(float64(bounds.Max.X), float64(bounds.Max.Y))
+
+	for x := startX; x <= endX; x += currentGridSpacing {
+		for y := startY; y <= endY; y += currentGridSpacing {
+			direction, speed := a.Current.GetCurrent(geometry.Point{X: x, Y: y})
+			a.drawCurrentArrow(screen, view, x, y, direction, speed)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestVariableCurrent_InterpolatesAcrossCourse(t *testing.T) {
+	current := &VariableCurrent{Direction: 45, LeftSpeed: 0.5, RightSpeed: 2.5, WorldWidth: 2000}
+
+	tests := []struct {
+		x     float64
+		speed float64
+	}{
+		{-100, 0.5}, // Clamped to the left edge
+		{0, 0.5},
+		{1000, 1.5},
+		{2000, 2.5},
+		{2500, 2.5}, // Clamped to the right edge
+	}
+	for _, tt := range tests {
+		dir, speed := current.GetCurrent(geometry.Point{X: tt.x, Y: 1000})
+		if dir != 45 {
+			t.Errorf("Expected current direction 45° at X=%.0f, got %.1f°", tt.x, dir)
+		}
+		if math.Abs(speed-tt.speed) > 1e-9 {
+			t.Errorf("Expected %.1f kts at X=%.0f, got %.2f kts", tt.speed, tt.x, speed)
+		}
+	}
+}
+
+func TestVariableCurrent_InvalidPosition(t *testing.T) {
+	current := &VariableCurrent{Direction: 180, LeftSpeed: 1, RightSpeed: 3, WorldWidth: 2000}
+
+	_, speed := current.GetCurrent(geometry.Point{X: math.NaN(), Y: 0})
+	if speed != 1 {
+		t.Errorf("Expected fallback to the left speed for a NaN position, got %.2f", speed)
+	}
+}
@@ -0,0 +1,66 @@
+package world
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Zone around each gate mark (meters, three boat lengths)
+const GateZoneRadius = 30.0
+
+// Gate is a pair of marks boats pass between, like the leeward gate of a windward-leeward
+// course. Like the start line it refers to the arena's marks.
+type Gate struct {
+	Left  *Mark
+	Right *Mark
+}
+
+// Has reports whether m is one of the gate's marks (false for no gate)
+func (gt *Gate) Has(m *Mark) bool {
+	return gt != nil && (m == gt.Left || m == gt.Right)
+}
+
+// Midpoint returns the middle of the gate
+func (gt *Gate) Midpoint() geometry.Point {
+	return geometry.Point{
+		X: (gt.Left.Pos.X + gt.Right.Pos.X) / 2,
+		Y: (gt.Left.Pos.Y + gt.Right.Pos.Y) / 2,
+	}
+}
+
+// Passed reports whether moving from one point to another went between the gate marks,
+// coming from the side of the gate that upwind is on
+func (gt *Gate) Passed(from, to, upwind geometry.Point) bool {
+	l, r := gt.Left.Pos, gt.Right.Pos
+	dx, dy := r.X-l.X, r.Y-l.Y
+	side := func(p geometry.Point) float64 {
+		return dx*(p.Y-l.Y) - dy*(p.X-l.X)
+	}
+
+	// Start on the upwind side and end on the gate line or beyond it
+	sFrom, sTo, sUpwind := side(from), side(to), side(upwind)
+	if sFrom*sUpwind <= 0 || sTo*sUpwind > 0 {
+		return false
+	}
+
+	// Where the path crosses the gate line, as a fraction of the way from left to right
+	f := sFrom / (sFrom - sTo)
+	x, y := from.X+(to.X-from.X)*f, from.Y+(to.Y-from.Y)*f
+	along := ((x-l.X)*dx + (y-l.Y)*dy) / (dx*dx + dy*dy)
+	return along >= 0 && along <= 1
+}
+
+// drawGate shows the gate as a dotted line between its marks with the zone around each
+func (a *Arena) drawGate(screen *ebiten.Image, view View) {
+	gateColor := color.RGBA{255, 255, 0, 255}
+	zoneColor := color.RGBA{255, 255, 0, 80}
+	for _, mark := range []*Mark{a.Gate.Left, a.Gate.Right} {
+		x, y := view.ToScreen(mark.Pos.X, mark.Pos.Y)
+		vector.StrokeCircle(screen, float32(x), float32(y), float32(view.Length(GateZoneRadius)), 1, zoneColor, false)
+	}
+	l, r := a.Gate.Left.Pos, a.Gate.Right.Pos
+	a.drawDottedLine(screen, view, l.X, l.Y, r.X, r.Y, gateColor)
+}
@@ -0,0 +1,62 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createTestGate returns an 80m gate across the course at Y=2250, with upwind to the north
+func createTestGate() (*Gate, geometry.Point) {
+	gate := &Gate{
+		Left:  &Mark{Pos: geometry.Point{X: 960, Y: 2250}, Name: "Gate Left"},
+		Right: &Mark{Pos: geometry.Point{X: 1040, Y: 2250}, Name: "Gate Right"},
+	}
+	return gate, geometry.Point{X: 1000, Y: 1800}
+}
+
+func TestGate_Passed(t *testing.T) {
+	gate, upwind := createTestGate()
+
+	tests := []struct {
+		name     string
+		from, to geometry.Point
+		want     bool
+	}{
+		{"Down through the middle", geometry.Point{X: 1000, Y: 2249}, geometry.Point{X: 1000, Y: 2251}, true},
+		{"Onto the gate line", geometry.Point{X: 1000, Y: 2249}, geometry.Point{X: 1000, Y: 2250}, true},
+		{"Diagonally inside the left mark", geometry.Point{X: 955, Y: 2245}, geometry.Point{X: 965, Y: 2255}, true},
+		{"Outside the left mark", geometry.Point{X: 950, Y: 2249}, geometry.Point{X: 950, Y: 2251}, false},
+		{"Outside the right mark", geometry.Point{X: 1050, Y: 2249}, geometry.Point{X: 1050, Y: 2251}, false},
+		{"Back up through the gate", geometry.Point{X: 1000, Y: 2251}, geometry.Point{X: 1000, Y: 2249}, false},
+		{"Still above the gate", geometry.Point{X: 1000, Y: 2240}, geometry.Point{X: 1000, Y: 2245}, false},
+	}
+	for _, tt := range tests {
+		if got := gate.Passed(tt.from, tt.to, upwind); got != tt.want {
+			t.Errorf("%s: Passed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGate_Midpoint(t *testing.T) {
+	gate, _ := createTestGate()
+	if got := gate.Midpoint(); got != (geometry.Point{X: 1000, Y: 2250}) {
+		t.Errorf("Expected the midpoint at (1000, 2250), got %+v", got)
+	}
+}
+
+func TestGate_Has(t *testing.T) {
+	gate, _ := createTestGate()
+	if !gate.Has(gate.Left) || !gate.Has(gate.Right) {
+		t.Error("Expected the gate marks to be recognized")
+	}
+	// Membership goes by the marks themselves, not their names
+	for _, name := range []string{"Pin", "Committee", "Upwind", "Gateway", gate.Left.Name} {
+		if gate.Has(&Mark{Name: name, Pos: gate.Left.Pos}) {
+			t.Errorf("Expected a mark named %q not to be a gate mark", name)
+		}
+	}
+	if (*Gate)(nil).Has(gate.Left) {
+		t.Error("Expected no gate marks without a gate")
+	}
+}
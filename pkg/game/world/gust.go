@@ -0,0 +1,151 @@
+package world
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Gust is a patch of stronger (puff) or lighter (lull) wind drifting downwind across the course
+type Gust struct {
+	Center     geometry.Point
+	Radius     float64        // Meters
+	DeltaSpeed float64        // Knots added at the center (negative for a lull)
+	Velocity   geometry.Point // Drift in meters per second
+	Age        float64        // Seconds since the gust formed
+	Lifetime   float64        // Seconds until the gust dies out (0 = never)
+}
+
+// GustConfig tunes how often gusts form and how strong they are
+type GustConfig struct {
+	Frequency float64 // Average new gusts per minute (0 = no gusts)
+	Strength  float64 // Largest speed change at a gust's center, knots
+}
+
+// DefaultGustConfig returns the gust settings used by the game
+func DefaultGustConfig() GustConfig {
+	return GustConfig{Frequency: 6, Strength: 4}
+}
+
+const (
+	gustMinRadius    = 80.0  // Meters
+	gustMaxRadius    = 200.0 // Meters
+	gustMinLifetime  = 30.0  // Seconds
+	gustMaxLifetime  = 60.0  // Seconds
+	gustLullChance   = 0.35  // Fraction of new patches that are lulls
+	gustDriftPerKnot = 1.5   // Drift speed in meters per second per knot of wind
+	gustMaxStep      = 1.0   // Longest time step (seconds) a single update advances gusts
+)
+
+// envelope fades a gust in as it forms and out as it dies (0..1)
+func (g *Gust) envelope() float64 {
+	if g.Lifetime <= 0 {
+		return 1
+	}
+	return math.Max(0, math.Sin(math.Pi*g.Age/g.Lifetime))
+}
+
+// SpeedDelta returns the wind speed change (knots) this gust adds at pos, strongest at the
+// center and tapering smoothly to nothing at the edge
+func (g *Gust) SpeedDelta(pos geometry.Point) float64 {
+	d := math.Hypot(pos.X-g.Center.X, pos.Y-g.Center.Y)
+	if g.Radius <= 0 || d >= g.Radius {
+		return 0
+	}
+	falloff := 0.5 * (1 + math.Cos(math.Pi*d/g.Radius))
+	return g.DeltaSpeed * falloff * g.envelope()
+}
+
+// Expired reports whether the gust has died out
+func (g *Gust) Expired() bool {
+	return g.Lifetime > 0 && g.Age >= g.Lifetime
+}
+
+// SetGusts turns on gusts and lulls with the given config, spawning them anywhere across
+// the world (WorldWidth wide and worldHeight tall)
+func (ow *OscillatingWind) SetGusts(config GustConfig, worldHeight float64) {
+	ow.gustConfig = config
+	ow.worldHeight = worldHeight
+}
+
+// ActiveGusts returns the gusts and lulls currently on the course
+func (ow *OscillatingWind) ActiveGusts() []*Gust {
+	return ow.gusts
+}
+
+// updateGusts drifts, ages and expires the gusts and occasionally spawns a new one,
+// dt seconds of game time on
+func (ow *OscillatingWind) updateGusts(dt float64) {
+	if dt <= 0 {
+		return
+	}
+	dt = math.Min(dt, gustMaxStep)
+
+	active := ow.gusts[:0]
+	for _, g := range ow.gusts {
+		g.Age += dt
+		g.Center.X += g.Velocity.X * dt
+		g.Center.Y += g.Velocity.Y * dt
+		if !g.Expired() {
+			active = append(active, g)
+		}
+	}
+	ow.gusts = active
+
+	if rand.Float64() < ow.gustConfig.Frequency/60*dt {
+		ow.gusts = append(ow.gusts, ow.newGust())
+	}
+}
+
+// newGust creates a random puff or lull somewhere on the course, drifting with the wind
+func (ow *OscillatingWind) newGust() *Gust {
+	center := geometry.Point{
+		X: rand.Float64() * ow.baseWind.WorldWidth,
+		Y: rand.Float64() * ow.worldHeight,
+	}
+	delta := ow.gustConfig.Strength * (0.5 + 0.5*rand.Float64())
+	if rand.Float64() < gustLullChance {
+		delta = -delta
+	}
+
+	// Wind direction is where it blows FROM, so the patch drifts the opposite way
+	windDir, windSpeed := ow.baseWind.GetWind(center)
+	dirRad := windDir * math.Pi / 180
+	drift := gustDriftPerKnot * windSpeed
+
+	return &Gust{
+		Center:     center,
+		Radius:     gustMinRadius + rand.Float64()*(gustMaxRadius-gustMinRadius),
+		DeltaSpeed: delta,
+		Velocity:   geometry.Point{X: -math.Sin(dirRad) * drift, Y: math.Cos(dirRad) * drift},
+		Lifetime:   gustMinLifetime + rand.Float64()*(gustMaxLifetime-gustMinLifetime),
+	}
+}
+
+// gustyWind is implemented by winds that carry moving gusts and lulls
+type gustyWind interface {
+	ActiveGusts() []*Gust
+}
+
+// drawGusts shades each gust as a patch of water: darker for puffs, lighter for lulls,
+// the way wind shows on the water surface
+func (a *Arena) drawGusts(screen *ebiten.Image, view View, gusts []*Gust) {
+	for _, g := range gusts {
+		alpha := math.Min(70, 15*math.Abs(g.DeltaSpeed)) * g.envelope()
+		if alpha < 1 {
+			continue
+		}
+
+		patchColor := color.RGBA{0, 20, 60, uint8(alpha)} // Dark ruffled water
+		if g.DeltaSpeed < 0 {
+			patchColor = color.RGBA{200, 220, 255, uint8(alpha)} // Glassy calm
+		}
+
+		x, y := view.ToScreen(g.Center.X, g.Center.Y)
+		vector.DrawFilledCircle(screen, float32(x), float32(y), float32(view.Length(g.Radius)), patchColor, true)
+	}
+}
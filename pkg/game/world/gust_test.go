@@ -0,0 +1,100 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestGust_SpeedDeltaTapersToEdge(t *testing.T) {
+	gust := &Gust{Center: geometry.Point{X: 1000, Y: 1000}, Radius: 100, DeltaSpeed: 4}
+
+	if got := gust.SpeedDelta(gust.Center); math.Abs(got-4) > 1e-9 {
+		t.Errorf("Expected the full 4 kts at the center, got %.2f", got)
+	}
+	half := gust.SpeedDelta(geometry.Point{X: 1050, Y: 1000})
+	if half <= 0 || half >= 4 {
+		t.Errorf("Expected a partial boost halfway out, got %.2f", half)
+	}
+	if got := gust.SpeedDelta(geometry.Point{X: 1100, Y: 1000}); got != 0 {
+		t.Errorf("Expected no boost at the edge, got %.2f", got)
+	}
+}
+
+func TestOscillatingWind_SumsGusts(t *testing.T) {
+	wind := NewOscillatingWind(10.0, 10.0, 2000.0, 0)
+	pos := geometry.Point{X: 1000, Y: 1000}
+	wind.gusts = []*Gust{
+		{Center: pos, Radius: 150, DeltaSpeed: 4},
+		{Center: geometry.Point{X: 1000, Y: 1200}, Radius: 150, DeltaSpeed: -3}, // Too far away
+	}
+
+	if _, speed := wind.GetWind(pos); math.Abs(speed-14) > 1e-9 {
+		t.Errorf("Expected 10 kts plus a 4 kt puff, got %.2f", speed)
+	}
+
+	// A deep lull can't make the wind blow backwards
+	wind.gusts = append(wind.gusts, &Gust{Center: pos, Radius: 150, DeltaSpeed: -20})
+	if _, speed := wind.GetWind(pos); speed != 0 {
+		t.Errorf("Expected a deep lull to leave no wind, got %.2f", speed)
+	}
+}
+
+func TestOscillatingWind_GustsDriftAndExpire(t *testing.T) {
+	wind := NewOscillatingWind(10.0, 10.0, 2000.0, 0)
+	gust := &Gust{
+		Center:     geometry.Point{X: 1000, Y: 1000},
+		Radius:     100,
+		DeltaSpeed: 4,
+		Velocity:   geometry.Point{X: 0, Y: 15}, // Downwind in a northerly
+		Lifetime:   10,
+	}
+	wind.gusts = []*Gust{gust}
+
+	for s := 1; s <= 5; s++ {
+		wind.updateGusts(1)
+	}
+	if math.Abs(gust.Center.Y-1075) > 1e-9 {
+		t.Errorf("Expected the gust to drift 75m downwind in 5s, center at Y=%.1f", gust.Center.Y)
+	}
+	if len(wind.ActiveGusts()) != 1 {
+		t.Fatalf("Expected the gust to still be active halfway through its life")
+	}
+
+	for s := 6; s <= 10; s++ {
+		wind.updateGusts(1)
+	}
+	if len(wind.ActiveGusts()) != 0 {
+		t.Errorf("Expected the gust to expire after its lifetime, %d still active", len(wind.ActiveGusts()))
+	}
+}
+
+func TestOscillatingWind_SpawnsGustsWhenEnabled(t *testing.T) {
+	wind := NewOscillatingWind(10.0, 10.0, 2000.0, 0)
+
+	// Gusts are off until configured
+	for s := 1; s <= 60; s++ {
+		wind.UpdateWithElapsedTime(float64(s))
+	}
+	if len(wind.ActiveGusts()) != 0 {
+		t.Fatalf("Expected no gusts without a config, got %d", len(wind.ActiveGusts()))
+	}
+
+	wind.SetGusts(GustConfig{Frequency: 60, Strength: 4}, 3000)
+	for s := 61; s <= 90; s++ {
+		wind.UpdateWithElapsedTime(float64(s))
+	}
+	gusts := wind.ActiveGusts()
+	if len(gusts) == 0 {
+		t.Fatal("Expected gusts to form at 60 per minute")
+	}
+	for _, g := range gusts {
+		if math.Abs(g.DeltaSpeed) < 2 || math.Abs(g.DeltaSpeed) > 4 {
+			t.Errorf("Expected gust strength between 2 and 4 kts, got %.2f", g.DeltaSpeed)
+		}
+		if g.Velocity.Y <= 0 {
+			t.Errorf("Expected gusts to drift downwind (south) in a northerly, velocity %v", g.Velocity)
+		}
+	}
+}
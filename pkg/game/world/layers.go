@@ -0,0 +1,103 @@
+package world
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Meters between wind barbs, and the cells the cached course layers are laid out in: a
+// layer is redrawn when the camera crosses into another cell
+const layerGridSpacing = 150.0
+
+// layerGrid is the block of grid points covering the visible course
+type layerGrid struct {
+	StartX, StartY float64 // World position of the top-left grid point
+	Cols, Rows     int
+	Scale          float64 // Render pixels per meter
+}
+
+// visibleGrid returns the grid points covering a target of the given size through view,
+// fixed in world coordinates so the layers don't slide as the camera pans. There are enough
+// for any camera position, so the grid only changes when the camera crosses a grid line.
+func visibleGrid(view View, width, height int) layerGrid {
+	worldWidth, worldHeight := float64(width)/view.scale(), float64(height)/view.scale()
+	return layerGrid{
+		StartX: math.Floor(view.OffsetX/layerGridSpacing) * layerGridSpacing,
+		StartY: math.Floor(view.OffsetY/layerGridSpacing) * layerGridSpacing,
+		Cols:   int(math.Ceil(worldWidth/layerGridSpacing)) + 1,
+		Rows:   int(math.Ceil(worldHeight/layerGridSpacing)) + 1,
+		Scale:  view.scale(),
+	}
+}
+
+// point returns the world position of a grid point
+func (g layerGrid) point(col, row int) geometry.Point {
+	return geometry.Point{X: g.StartX + float64(col)*layerGridSpacing, Y: g.StartY + float64(row)*layerGridSpacing}
+}
+
+// Meters around the grid for static course features drawn across its edge
+const coursePadding = 30.0
+
+// courseLayer caches the parts of the course that don't move: the current arrows, start
+// line, rhumb line, gate and marks. They're redrawn when the camera crosses into another
+// grid cell, the line changes color at the start, or a mark moves (the line being resized).
+type courseLayer struct {
+	image       *ebiten.Image
+	grid        layerGrid
+	raceStarted bool
+	positions   []geometry.Point // Mark and line end positions it was drawn with
+}
+
+// coursePositions returns the positions of everything in the course layer that can move
+func (a *Arena) coursePositions() []geometry.Point {
+	var positions []geometry.Point
+	if a.Line != nil {
+		pin, committee := a.Line.Ends()
+		positions = append(positions, pin, committee)
+	}
+	for _, mark := range a.Marks {
+		positions = append(positions, mark.Pos)
+	}
+	if a.Gate != nil {
+		positions = append(positions, a.Gate.Left.Pos, a.Gate.Right.Pos)
+	}
+	return positions
+}
+
+// stale reports whether the cached course no longer matches what should be drawn
+func (l *courseLayer) stale(grid layerGrid, raceStarted bool, positions []geometry.Point) bool {
+	if l.image == nil || grid != l.grid || raceStarted != l.raceStarted || len(positions) != len(l.positions) {
+		return true
+	}
+	for i, p := range positions {
+		if p != l.positions[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// origin returns the world position of the cached image's top-left corner
+func (l *courseLayer) origin() (float64, float64) {
+	return l.grid.StartX - coursePadding, l.grid.StartY - coursePadding
+}
+
+// redraw renders the static course for the grid onto the cached image
+func (l *courseLayer) redraw(a *Arena, grid layerGrid, raceStarted bool, positions []geometry.Point) {
+	l.grid, l.raceStarted, l.positions = grid, raceStarted, positions
+
+	// The grid cells plus the padding each side cover the screen from any camera position
+	// within the first cell
+	width := int(math.Ceil((float64(grid.Cols)*layerGridSpacing + 2*coursePadding) * grid.Scale))
+	height := int(math.Ceil((float64(grid.Rows)*layerGridSpacing + 2*coursePadding) * grid.Scale))
+	if l.image == nil || l.image.Bounds().Dx() != width || l.image.Bounds().Dy() != height {
+		l.image = ebiten.NewImage(width, height)
+	} else {
+		l.image.Clear()
+	}
+
+	originX, originY := l.origin()
+	a.drawCourse(l.image, View{OffsetX: originX, OffsetY: originY, Scale: grid.Scale}, raceStarted)
+}
@@ -0,0 +1,61 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestVisibleGrid_FixedInWorld(t *testing.T) {
+	grid := visibleGrid(View{OffsetX: 200, OffsetY: 400, Scale: 1}, 1280, 720)
+	if grid.StartX != 150 || grid.StartY != 300 {
+		t.Errorf("Expected the grid to start at (150, 300), got (%.0f, %.0f)", grid.StartX, grid.StartY)
+	}
+	// Enough to cover 1280x720 from any offset within a grid cell
+	if grid.Cols != 10 || grid.Rows != 6 {
+		t.Errorf("Expected 10x6 barbs, got %dx%d", grid.Cols, grid.Rows)
+	}
+
+	// Panning within a grid cell keeps the same barbs
+	if moved := visibleGrid(View{OffsetX: 290, OffsetY: 440, Scale: 1}, 1280, 720); moved != grid {
+		t.Errorf("Expected the same grid after a small pan, got %+v", moved)
+	}
+}
+
+func TestCourseLayer_RedrawsWhenCourseChanges(t *testing.T) {
+	line := &StartLine{
+		Pin:       &Mark{Pos: geometry.Point{X: 800, Y: 2500}, Name: "Pin"},
+		Committee: &Mark{Pos: geometry.Point{X: 1200, Y: 2500}, Name: "Committee", Solid: true},
+	}
+	arena := &Arena{Line: line, Marks: []*Mark{line.Pin, line.Committee, {Pos: geometry.Point{X: 1000, Y: 1300}, Name: "Upwind"}}}
+	view := View{OffsetX: 400, OffsetY: 2000, Scale: 1}
+	grid := visibleGrid(view, 1280, 720)
+
+	var layer courseLayer
+	if !layer.stale(grid, false, arena.coursePositions()) {
+		t.Fatal("Expected an empty layer to need drawing")
+	}
+	layer.redraw(arena, grid, false, arena.coursePositions())
+	if layer.stale(grid, false, arena.coursePositions()) {
+		t.Error("Expected no redraw for an unchanged course")
+	}
+
+	// Panning within the grid cell reuses it; panning further doesn't
+	if panned := visibleGrid(View{OffsetX: 420, OffsetY: 2010, Scale: 1}, 1280, 720); layer.stale(panned, false, arena.coursePositions()) {
+		t.Error("Expected no redraw for a small pan")
+	}
+	if panned := visibleGrid(View{OffsetX: 600, OffsetY: 2000, Scale: 1}, 1280, 720); !layer.stale(panned, false, arena.coursePositions()) {
+		t.Error("Expected a redraw once the camera moves into the next cell")
+	}
+
+	// The line turns green at the start
+	if !layer.stale(grid, true, arena.coursePositions()) {
+		t.Error("Expected a redraw at the start")
+	}
+
+	// Resizing the line moves the pin
+	line.Pin.Pos.X -= 50
+	if !layer.stale(grid, false, arena.coursePositions()) {
+		t.Error("Expected a redraw when the line is resized")
+	}
+}
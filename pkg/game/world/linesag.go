@@ -0,0 +1,93 @@
+package world
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// lineSagRatio is how far the middle of a fleet typically sags below the line,
+// as a fraction of line length (boats in the middle can't see the line and hang back)
+const lineSagRatio = 0.05
+
+// LineSag describes where a mid-line start ends up compared to the line ends
+type LineSag struct {
+	Mid             geometry.Point // Middle of the ideal flat line
+	Sag             geometry.Point // Where the middle of the fleet actually starts
+	Depth           float64        // Distance from Mid to Sag (meters)
+	BehindPin       float64        // How far downwind of the pin end the sagged start is (meters)
+	BehindCommittee float64        // How far downwind of the committee end the sagged start is (meters)
+}
+
+// CalculateLineSag returns the mid-line sag for a start line with the wind from windDir.
+// The middle of the line is pushed straight downwind; the distances behind each end are
+// measured along the wind axis, so on a biased line the favored end gains even more.
+func CalculateLineSag(pin, committee geometry.Point, windDir float64) LineSag {
+	length := math.Hypot(committee.X-pin.X, committee.Y-pin.Y)
+	depth := length * lineSagRatio
+
+	// Unit vector pointing upwind (toward where the wind comes from)
+	windRad := windDir * math.Pi / 180
+	upX, upY := math.Sin(windRad), -math.Cos(windRad)
+
+	mid := geometry.Point{X: (pin.X + committee.X) / 2, Y: (pin.Y + committee.Y) / 2}
+	sag := geometry.Point{X: mid.X - depth*upX, Y: mid.Y - depth*upY}
+
+	// Upwind distance of each end ahead of the sagged start
+	behind := func(end geometry.Point) float64 {
+		return (end.X-sag.X)*upX + (end.Y-sag.Y)*upY
+	}
+
+	return LineSag{
+		Mid:             mid,
+		Sag:             sag,
+		Depth:           depth,
+		BehindPin:       behind(pin),
+		BehindCommittee: behind(committee),
+	}
+}
+
+// drawLineSag draws the sagging mid-line curve below the start line with the distances
+// it gives away to each end
+func (a *Arena) drawLineSag(screen *ebiten.Image, view View, wind Wind) {
+	if a.Line == nil {
+		return
+	}
+	pin, committee := a.Line.Ends()
+
+	windDir := 0.0
+	if wind != nil {
+		windDir, _ = wind.GetWind(a.Line.Midpoint())
+	}
+	sag := CalculateLineSag(pin, committee, windDir)
+
+	sagColor := color.RGBA{255, 200, 0, 160} // Amber, semi-transparent
+
+	// Quadratic curve from pin through the sag point to committee (control point doubles the sag)
+	const segments = 16
+	ctrlX := 2*sag.Sag.X - sag.Mid.X
+	ctrlY := 2*sag.Sag.Y - sag.Mid.Y
+	prevX, prevY := view.ToScreen(pin.X, pin.Y)
+	for i := 1; i <= segments; i++ {
+		t := float64(i) / segments
+		wx := (1-t)*(1-t)*pin.X + 2*(1-t)*t*ctrlX + t*t*committee.X
+		wy := (1-t)*(1-t)*pin.Y + 2*(1-t)*t*ctrlY + t*t*committee.Y
+		x, y := view.ToScreen(wx, wy)
+		if i%2 == 1 { // Dashed
+			ebitenutil.DrawLine(screen, prevX, prevY, x, y, sagColor)
+		}
+		prevX, prevY = x, y
+	}
+
+	// Sag depth marker from the ideal line down to the sagged start
+	midX, midY := view.ToScreen(sag.Mid.X, sag.Mid.Y)
+	sagX, sagY := view.ToScreen(sag.Sag.X, sag.Sag.Y)
+	ebitenutil.DrawLine(screen, midX, midY, sagX, sagY, sagColor)
+
+	label := fmt.Sprintf("Mid-line sag: %.0fm behind pin, %.0fm behind committee", sag.BehindPin, sag.BehindCommittee)
+	ebitenutil.DebugPrintAt(screen, label, int(sagX)-160, int(sagY+view.Length(6)))
+}
@@ -0,0 +1,48 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestCalculateLineSag_SquareLine(t *testing.T) {
+	pin := geometry.Point{X: 800, Y: 2400}
+	committee := geometry.Point{X: 1200, Y: 2400}
+
+	sag := CalculateLineSag(pin, committee, 0)
+
+	if sag.Mid != (geometry.Point{X: 1000, Y: 2400}) {
+		t.Errorf("Expected mid-line at (1000, 2400), got %v", sag.Mid)
+	}
+	// 5% of a 400m line, pushed downwind (south, +Y)
+	if math.Abs(sag.Depth-20) > 1e-9 {
+		t.Errorf("Expected 20m sag on a 400m line, got %.2f", sag.Depth)
+	}
+	if math.Abs(sag.Sag.X-1000) > 1e-9 || math.Abs(sag.Sag.Y-2420) > 1e-9 {
+		t.Errorf("Expected sagged start at (1000, 2420), got %v", sag.Sag)
+	}
+	if math.Abs(sag.BehindPin-20) > 1e-9 || math.Abs(sag.BehindCommittee-20) > 1e-9 {
+		t.Errorf("Expected both ends 20m ahead on a square line, got pin %.2f committee %.2f", sag.BehindPin, sag.BehindCommittee)
+	}
+}
+
+func TestCalculateLineSag_BiasedLine(t *testing.T) {
+	pin := geometry.Point{X: 800, Y: 2400}
+	committee := geometry.Point{X: 1200, Y: 2400}
+
+	// Wind veered 10° right: committee end is upwind (favored)
+	sag := CalculateLineSag(pin, committee, 10)
+
+	if sag.BehindCommittee <= sag.BehindPin {
+		t.Errorf("Expected sagged start further behind the favored committee end, got pin %.2f committee %.2f",
+			sag.BehindPin, sag.BehindCommittee)
+	}
+	// Bias adds the same amount to one end as it takes from the other
+	bias := 200 * math.Sin(10*math.Pi/180)
+	if math.Abs(sag.BehindCommittee-(sag.Depth+bias)) > 1e-9 || math.Abs(sag.BehindPin-(sag.Depth-bias)) > 1e-9 {
+		t.Errorf("Expected pin %.2f committee %.2f, got pin %.2f committee %.2f",
+			sag.Depth-bias, sag.Depth+bias, sag.BehindPin, sag.BehindCommittee)
+	}
+}
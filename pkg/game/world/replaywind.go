@@ -0,0 +1,143 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// WindSample is the wind at one moment of a recorded race
+type WindSample struct {
+	Time      float64 `json:"time"`      // Game elapsed seconds
+	Direction float64 `json:"direction"` // Degrees the wind blows FROM
+	Speed     float64 `json:"speed"`     // Knots
+}
+
+// ReplayWind plays back a recorded wind log, so a race can be sailed again in exactly the
+// same conditions. The wind is the same everywhere on the course at a given moment.
+type ReplayWind struct {
+	Samples []WindSample // Ordered by time
+	elapsed float64      // Game elapsed seconds being played back
+}
+
+// NewReplayWind creates a wind that plays back samples from the start of the log
+func NewReplayWind(samples []WindSample) *ReplayWind {
+	return &ReplayWind{Samples: samples}
+}
+
+// SetElapsed moves playback to the given game elapsed time in seconds
+func (rw *ReplayWind) SetElapsed(seconds float64) {
+	rw.elapsed = seconds
+}
+
+// GetWind returns the recorded wind at the playback time, interpolated between samples.
+// Before the first sample and after the last the wind holds steady.
+func (rw *ReplayWind) GetWind(_ geometry.Point) (float64, float64) {
+	n := len(rw.Samples)
+	if n == 0 {
+		return 0, 0
+	}
+
+	i := sort.Search(n, func(i int) bool { return rw.Samples[i].Time > rw.elapsed })
+	if i == 0 {
+		return rw.Samples[0].Direction, rw.Samples[0].Speed
+	}
+	if i == n {
+		return rw.Samples[n-1].Direction, rw.Samples[n-1].Speed
+	}
+
+	a, b := rw.Samples[i-1], rw.Samples[i]
+	t := (rw.elapsed - a.Time) / (b.Time - a.Time)
+
+	// Turn the short way round, so 355° to 5° passes through North
+	turn := math.Mod(b.Direction-a.Direction+540, 360) - 180
+	direction := math.Mod(a.Direction+turn*t+360, 360)
+	return direction, a.Speed + (b.Speed-a.Speed)*t
+}
+
+// MedianDirection returns the average direction over the log, which the recorded wind
+// oscillated around
+func (rw *ReplayWind) MedianDirection() float64 {
+	var x, y float64
+	for _, s := range rw.Samples {
+		rad := s.Direction * math.Pi / 180
+		x += math.Sin(rad)
+		y += math.Cos(rad)
+	}
+	if x == 0 && y == 0 {
+		return 0
+	}
+	return math.Mod(math.Atan2(x, y)*180/math.Pi+360, 360)
+}
+
+// Seconds between samples in a recorded wind log
+const windLogInterval = 1.0
+
+// WindRecorder samples the wind at the boat once a second on the race's game clock, the
+// same clock ReplayWind plays the log back on. When the clock goes back (a rehearsal
+// rewind) the samples from then on are dropped, so the log holds the wind last sailed in.
+type WindRecorder struct {
+	Samples []WindSample
+}
+
+// Record samples the wind at pos if a second of game time has passed since the last sample
+func (wr *WindRecorder) Record(elapsedSeconds float64, wind Wind, pos geometry.Point) {
+	keep := sort.Search(len(wr.Samples), func(i int) bool { return wr.Samples[i].Time >= elapsedSeconds })
+	wr.Samples = wr.Samples[:keep]
+
+	if n := len(wr.Samples); n > 0 && elapsedSeconds < wr.Samples[n-1].Time+windLogInterval {
+		return
+	}
+	direction, speed := wind.GetWind(pos)
+	wr.Samples = append(wr.Samples, WindSample{Time: elapsedSeconds, Direction: direction, Speed: speed})
+}
+
+// SaveWindLog writes a recorded wind log to a JSON file
+func SaveWindLog(path string, samples []WindSample) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteWindLog(f, samples); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteWindLog encodes a wind log as JSON
+func WriteWindLog(w io.Writer, samples []WindSample) error {
+	return json.NewEncoder(w).Encode(samples)
+}
+
+// LoadWindLog reads a wind log from a JSON file
+func LoadWindLog(path string) ([]WindSample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadWindLog(f)
+}
+
+// ReadWindLog decodes a wind log from JSON, rejecting logs that can't be played back
+func ReadWindLog(r io.Reader) ([]WindSample, error) {
+	var samples []WindSample
+	if err := json.NewDecoder(r).Decode(&samples); err != nil {
+		return nil, fmt.Errorf("invalid wind log JSON: %w", err)
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("wind log has no samples")
+	}
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Time <= samples[i-1].Time {
+			return nil, fmt.Errorf("wind log sample %d at %.1fs is not after the previous one", i, samples[i].Time)
+		}
+	}
+	return samples, nil
+}
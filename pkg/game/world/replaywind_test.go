@@ -0,0 +1,122 @@
+package world
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestReplayWind_InterpolatesBetweenSamples(t *testing.T) {
+	wind := NewReplayWind([]WindSample{
+		{Time: 0, Direction: 350, Speed: 10},
+		{Time: 1, Direction: 10, Speed: 12},
+		{Time: 2, Direction: 20, Speed: 8},
+	})
+	pos := geometry.Point{X: 500, Y: 500}
+
+	tests := []struct {
+		elapsed        float64
+		wantDir, wantV float64
+	}{
+		{-5, 350, 10}, // Before the log: first sample
+		{0.5, 0, 11},  // Through North, not the long way round
+		{1.25, 12.5, 11},
+		{60, 20, 8}, // After the log: last sample
+	}
+	for _, tt := range tests {
+		wind.SetElapsed(tt.elapsed)
+		dir, speed := wind.GetWind(pos)
+		if math.Abs(geometry.NormalizeAngle(dir-tt.wantDir)) > 1e-9 || math.Abs(speed-tt.wantV) > 1e-9 {
+			t.Errorf("At %.2fs expected %.1f° %.1f kts, got %.1f° %.1f kts", tt.elapsed, tt.wantDir, tt.wantV, dir, speed)
+		}
+	}
+}
+
+func TestWindRecorder_SamplesEverySecond(t *testing.T) {
+	live := &ConstantWind{Direction: 5, Speed: 11}
+	var recorder WindRecorder
+	for frame := 0; frame <= 3*60; frame++ {
+		recorder.Record(float64(frame)/60, live, geometry.Point{X: 1000, Y: 2000})
+	}
+
+	if len(recorder.Samples) != 4 {
+		t.Fatalf("Expected a sample at 0, 1, 2 and 3 seconds, got %d", len(recorder.Samples))
+	}
+	for _, s := range recorder.Samples {
+		if s.Direction != 5 || s.Speed != 11 {
+			t.Errorf("Expected the live wind in every sample, got %+v", s)
+		}
+	}
+}
+
+func TestWindLog_RoundTrip(t *testing.T) {
+	samples := []WindSample{{Time: 0, Direction: 0, Speed: 10}, {Time: 1, Direction: 4.5, Speed: 10.5}}
+	path := filepath.Join(t.TempDir(), "wind.json")
+
+	if err := SaveWindLog(path, samples); err != nil {
+		t.Fatalf("SaveWindLog failed: %v", err)
+	}
+	loaded, err := LoadWindLog(path)
+	if err != nil {
+		t.Fatalf("LoadWindLog failed: %v", err)
+	}
+	if len(loaded) != len(samples) || loaded[1] != samples[1] {
+		t.Errorf("Expected %v back, got %v", samples, loaded)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWindLog(&buf, samples); err != nil {
+		t.Fatalf("WriteWindLog failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"direction":4.5`) {
+		t.Errorf("Expected JSON field names in the log, got %s", buf.String())
+	}
+}
+
+func TestReadWindLog_RejectsBadLogs(t *testing.T) {
+	for _, log := range []string{`[]`, `[{"time":1},{"time":1}]`, `not json`} {
+		if _, err := ReadWindLog(strings.NewReader(log)); err == nil {
+			t.Errorf("Expected an error for wind log %s", log)
+		}
+	}
+}
+
+func TestWindRecorder_ResailsFromARewind(t *testing.T) {
+	var recorder WindRecorder
+	first := &ConstantWind{Direction: 5, Speed: 11}
+	for frame := 0; frame <= 4*60; frame++ {
+		recorder.Record(float64(frame)/60, first, geometry.Point{})
+	}
+	// Rewound to 2 seconds and sailed on in a different wind: the log is what was sailed last
+	second := &ConstantWind{Direction: 20, Speed: 9}
+	for frame := 2 * 60; frame <= 3*60; frame++ {
+		recorder.Record(float64(frame)/60, second, geometry.Point{})
+	}
+
+	if len(recorder.Samples) != 4 {
+		t.Fatalf("Expected samples at 0 to 3 seconds, got %d", len(recorder.Samples))
+	}
+	for i, s := range recorder.Samples {
+		if math.Abs(s.Time-float64(i)) > 0.05 {
+			t.Errorf("Expected sample %d at %ds, got %.2fs", i, i, s.Time)
+		}
+		want := first.Direction
+		if i >= 2 {
+			want = second.Direction
+		}
+		if s.Direction != want {
+			t.Errorf("Expected sample %d from the wind sailed last (%.0f°), got %.0f°", i, want, s.Direction)
+		}
+	}
+
+	// Played back on the same clock, the log gives the wind sailed at that moment
+	replay := NewReplayWind(recorder.Samples)
+	replay.SetElapsed(2.5)
+	if dir, _ := replay.GetWind(geometry.Point{}); dir != second.Direction {
+		t.Errorf("Expected the resailed wind at 2.5s, got %.0f°", dir)
+	}
+}
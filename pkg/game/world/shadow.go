@@ -0,0 +1,159 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// ShadowConfig tunes the disturbed air downwind of the committee boat. Marks are much
+// smaller and cast a proportionally shorter, weaker shadow.
+type ShadowConfig struct {
+	Length   float64 // Meters the committee boat's shadow reaches downwind (0 = no shadows)
+	Strength float64 // Fraction of wind speed lost just behind the committee boat (0..1)
+}
+
+// DefaultShadowConfig returns the wind shadow settings used by the game
+func DefaultShadowConfig() ShadowConfig {
+	return ShadowConfig{Length: 100, Strength: 0.5}
+}
+
+const (
+	shadowHalfAngle = 15.0 // Degrees either side of straight downwind the shadow spreads
+	shadowMaxBend   = 8.0  // Degrees the wind bends back in toward the middle of the shadow
+	markShadowScale = 0.3  // Size and strength of a mark's shadow relative to the committee boat's
+)
+
+// ShadowedWind wraps a wind with the disturbed air downwind of the marks and the
+// committee boat: lighter wind that bends in behind the obstacle. The shadows swing
+// round with the wind.
+type ShadowedWind struct {
+	Wind              // Undisturbed wind
+	Marks     []*Mark // Marks casting a shadow
+	Committee *Mark   // The committee boat, which casts the full shadow
+	Config    ShadowConfig
+}
+
+// NewShadowedWind adds the shadows of marks to wind
+func NewShadowedWind(wind Wind, marks []*Mark, committee *Mark, config ShadowConfig) *ShadowedWind {
+	return &ShadowedWind{Wind: wind, Marks: marks, Committee: committee, Config: config}
+}
+
+// Undisturbed returns the wind without any shadows
+func (sw *ShadowedWind) Undisturbed() Wind {
+	return sw.Wind
+}
+
+// ActiveGusts returns the gusts and lulls of the undisturbed wind, if it has any
+func (sw *ShadowedWind) ActiveGusts() []*Gust {
+	if gw, ok := sw.Wind.(gustyWind); ok {
+		return gw.ActiveGusts()
+	}
+	return nil
+}
+
+// MedianDirection returns the direction the undisturbed wind oscillates around (its
+// direction at the origin if it doesn't oscillate)
+func (sw *ShadowedWind) MedianDirection() float64 {
+	if mw, ok := sw.Wind.(interface{ MedianDirection() float64 }); ok {
+		return mw.MedianDirection()
+	}
+	direction, _ := sw.Wind.GetWind(geometry.Point{})
+	return direction
+}
+
+// InStartBias reports whether the undisturbed wind is still in its opening start-line bias shift
+func (sw *ShadowedWind) InStartBias() bool {
+	if bw, ok := sw.Wind.(interface{ InStartBias() bool }); ok {
+		return bw.InStartBias()
+	}
+	return false
+}
+
+// GetWind returns the wind at pos, lighter and bent in the shadow of any mark upwind of it
+func (sw *ShadowedWind) GetWind(pos geometry.Point) (float64, float64) {
+	direction, speed := sw.Wind.GetWind(pos)
+	for _, mark := range sw.Marks {
+		length, strength := sw.shadowSize(mark)
+		intensity, side := shadowAt(pos, mark.Pos, direction, length)
+		if intensity <= 0 {
+			continue
+		}
+		speed *= 1 - strength*intensity
+		direction -= side * shadowMaxBend * intensity
+	}
+	return math.Mod(direction+360, 360), speed
+}
+
+// shadowSize returns how far downwind mark's shadow reaches and how much wind it takes
+func (sw *ShadowedWind) shadowSize(mark *Mark) (float64, float64) {
+	length, strength := sw.Config.Length, math.Max(0, math.Min(1, sw.Config.Strength))
+	if mark != sw.Committee {
+		return length * markShadowScale, strength * markShadowScale
+	}
+	return length, strength
+}
+
+// shadowAt returns how deep pos is in the shadow of an obstacle at source (0 outside, 1
+// right behind it) for wind from windDir, and which side of the shadow's center line it
+// is on: +1 to the right looking downwind, -1 to the left
+func shadowAt(pos, source geometry.Point, windDir, length float64) (float64, float64) {
+	if length <= 0 {
+		return 0, 0
+	}
+
+	// Wind blows toward windDir+180; along is the distance downwind, across to the right of that
+	downRad := (windDir + 180) * math.Pi / 180
+	dx, dy := pos.X-source.X, pos.Y-source.Y
+	along := dx*math.Sin(downRad) - dy*math.Cos(downRad)
+	across := dx*math.Cos(downRad) + dy*math.Sin(downRad)
+	if along <= 0 || along >= length {
+		return 0, 0
+	}
+
+	offAxis := math.Atan2(math.Abs(across), along) * 180 / math.Pi
+	if offAxis >= shadowHalfAngle {
+		return 0, 0
+	}
+
+	side := 1.0
+	if across < 0 {
+		side = -1
+	}
+	return (1 - along/length) * (1 - offAxis/shadowHalfAngle), side
+}
+
+// drawShadows shades the disturbed air behind each mark as a faint cone that fades out downwind
+func (a *Arena) drawShadows(screen *ebiten.Image, view View, sw *ShadowedWind) {
+	step := 1 / view.Length(1) // One pixel in meters
+	tan := math.Tan(shadowHalfAngle * math.Pi / 180)
+
+	for _, mark := range sw.Marks {
+		length, strength := sw.shadowSize(mark)
+		if length <= 0 || strength <= 0 {
+			continue
+		}
+
+		// Orient the cone by the undisturbed wind at the mark
+		windDir, _ := sw.Wind.GetWind(mark.Pos)
+		downRad := (windDir + 180) * math.Pi / 180
+		downX, downY := math.Sin(downRad), -math.Cos(downRad)
+		rightX, rightY := math.Cos(downRad), math.Sin(downRad)
+
+		// Fill the cone with lines across it, lightening toward the tail
+		for along := step; along < length; along += step {
+			alpha := 60 * strength * (1 - along/length)
+			if alpha < 1 {
+				break
+			}
+			half := along * tan
+			cx, cy := mark.Pos.X+downX*along, mark.Pos.Y+downY*along
+			x1, y1 := view.ToScreen(cx-rightX*half, cy-rightY*half)
+			x2, y2 := view.ToScreen(cx+rightX*half, cy+rightY*half)
+			vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), 1, color.RGBA{0, 0, 0, uint8(alpha)}, false)
+		}
+	}
+}
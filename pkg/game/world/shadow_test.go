@@ -0,0 +1,90 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// createShadowedWind puts a committee boat and a mark in a 12 kt northerly
+func createShadowedWind() (*ShadowedWind, *Mark, *Mark) {
+	committee := &Mark{Pos: geometry.Point{X: 1000, Y: 1000}, Name: "Committee", Solid: true}
+	pin := &Mark{Pos: geometry.Point{X: 500, Y: 1000}, Name: "Pin"}
+	wind := NewShadowedWind(&ConstantWind{Direction: 0, Speed: 12}, []*Mark{pin, committee}, committee, ShadowConfig{Length: 100, Strength: 0.5})
+	return wind, committee, pin
+}
+
+func TestShadowedWind_LighterDownwindOfCommittee(t *testing.T) {
+	wind, _, _ := createShadowedWind()
+
+	// Wind from the north, so the shadow lies south (larger Y) of the committee boat
+	_, behind := wind.GetWind(geometry.Point{X: 1000, Y: 1010})
+	_, further := wind.GetWind(geometry.Point{X: 1000, Y: 1060})
+	if math.Abs(behind-12*(1-0.5*0.9)) > 1e-9 {
+		t.Errorf("Expected %.2f kts 10m behind the committee boat, got %.2f", 12*(1-0.5*0.9), behind)
+	}
+	if further <= behind || further >= 12 {
+		t.Errorf("Expected the shadow to fill in downwind, got %.2f then %.2f", behind, further)
+	}
+
+	for _, pos := range []geometry.Point{
+		{X: 1000, Y: 990},  // Upwind
+		{X: 1000, Y: 1110}, // Past the end of the shadow
+		{X: 1030, Y: 1020}, // Off to the side of the cone
+	} {
+		if dir, speed := wind.GetWind(pos); dir != 0 || speed != 12 {
+			t.Errorf("Expected clean wind at %+v, got %.1f° %.2f kts", pos, dir, speed)
+		}
+	}
+}
+
+func TestShadowedWind_MarksCastSmallerShadows(t *testing.T) {
+	wind, _, _ := createShadowedWind()
+
+	_, behindPin := wind.GetWind(geometry.Point{X: 500, Y: 1010})
+	_, behindCommittee := wind.GetWind(geometry.Point{X: 1000, Y: 1010})
+	if behindPin <= behindCommittee {
+		t.Errorf("Expected the pin's shadow to be weaker than the committee boat's, got %.2f vs %.2f kts", behindPin, behindCommittee)
+	}
+	if _, speed := wind.GetWind(geometry.Point{X: 500, Y: 1040}); speed != 12 {
+		t.Errorf("Expected the pin's shadow to end within 30m, got %.2f kts at 40m", speed)
+	}
+}
+
+func TestShadowedWind_BendsInBehindObstacle(t *testing.T) {
+	wind, _, _ := createShadowedWind()
+
+	// Looking downwind (south) the boat's west side is on the right; the wind there is
+	// turned toward the center line, so it comes from slightly west of north
+	west, _ := wind.GetWind(geometry.Point{X: 995, Y: 1040})
+	east, _ := wind.GetWind(geometry.Point{X: 1005, Y: 1040})
+	if west < 180 || west > 359.9 {
+		t.Errorf("Expected the wind west of the center line to back, got %.1f°", west)
+	}
+	if east <= 0 || east > 180 {
+		t.Errorf("Expected the wind east of the center line to veer, got %.1f°", east)
+	}
+}
+
+func TestShadowedWind_FollowsWindDirection(t *testing.T) {
+	wind, committee, _ := createShadowedWind()
+	wind.Wind = &ConstantWind{Direction: 90, Speed: 12}
+
+	// Easterly: the shadow now lies west of the committee boat
+	if _, speed := wind.GetWind(geometry.Point{X: committee.Pos.X - 20, Y: committee.Pos.Y}); speed >= 12 {
+		t.Errorf("Expected a shadow west of the committee boat in an easterly, got %.2f kts", speed)
+	}
+	if _, speed := wind.GetWind(geometry.Point{X: committee.Pos.X, Y: committee.Pos.Y + 20}); speed != 12 {
+		t.Errorf("Expected clean air south of the committee boat in an easterly, got %.2f kts", speed)
+	}
+}
+
+func TestShadowedWind_ZeroLengthDisablesShadows(t *testing.T) {
+	wind, _, _ := createShadowedWind()
+	wind.Config.Length = 0
+
+	if dir, speed := wind.GetWind(geometry.Point{X: 1000, Y: 1010}); dir != 0 || speed != 12 {
+		t.Errorf("Expected no shadow with zero length, got %.1f° %.2f kts", dir, speed)
+	}
+}
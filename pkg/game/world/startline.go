@@ -0,0 +1,89 @@
+package world
+
+import (
+	"math"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+// Start line length limits when resizing during setup (meters)
+const (
+	MinStartLineLength = 100.0
+	MaxStartLineLength = 800.0
+)
+
+// StartLine is the start/finish line between the pin and committee marks. It refers to the
+// arena's marks rather than copying their positions, so everything that reads the line
+// (OCS checks, dashboard readouts, drawing) sees the same ends after the line is changed.
+type StartLine struct {
+	Pin       *Mark
+	Committee *Mark
+}
+
+// Line ends further upwind by less than this (meters) leave the line square
+const squareLineTolerance = 1.0
+
+// Bias returns which end of the line is favored in wind from windDir: "Pin" or "Committee"
+// for the end further upwind, or "Square". degrees is how far the line is turned from square
+// to the wind, meters how much further upwind the favored end is.
+func (l *StartLine) Bias(windDir float64) (favored string, degrees, meters float64) {
+	pin, committee := l.Ends()
+
+	// Unit vector pointing upwind (towards where the wind comes from)
+	windRad := windDir * math.Pi / 180
+	upwindX := math.Sin(windRad)
+	upwindY := -math.Cos(windRad) // Y inverted
+
+	// Positive advantage means the committee end is further upwind than the pin
+	advantage := (committee.X-pin.X)*upwindX + (committee.Y-pin.Y)*upwindY
+	if math.Abs(advantage) < squareLineTolerance || l.Length() == 0 {
+		return "Square", 0, 0
+	}
+	degrees = math.Asin(math.Min(1, math.Abs(advantage)/l.Length())) * 180 / math.Pi
+	if advantage > 0 {
+		return "Committee", degrees, advantage
+	}
+	return "Pin", degrees, -advantage
+}
+
+// Ends returns the pin and committee positions
+func (l *StartLine) Ends() (pin, committee geometry.Point) {
+	return l.Pin.Pos, l.Committee.Pos
+}
+
+// Midpoint returns the middle of the line
+func (l *StartLine) Midpoint() geometry.Point {
+	return geometry.Point{
+		X: (l.Pin.Pos.X + l.Committee.Pos.X) / 2,
+		Y: (l.Pin.Pos.Y + l.Committee.Pos.Y) / 2,
+	}
+}
+
+// Length returns the distance between the ends in meters
+func (l *StartLine) Length() float64 {
+	return math.Hypot(l.Committee.Pos.X-l.Pin.Pos.X, l.Committee.Pos.Y-l.Pin.Pos.Y)
+}
+
+// WithinBounds reports whether x lies between the pin and committee ends
+func (l *StartLine) WithinBounds(x float64) bool {
+	minX := math.Min(l.Pin.Pos.X, l.Committee.Pos.X)
+	maxX := math.Max(l.Pin.Pos.X, l.Committee.Pos.X)
+	return x >= minX && x <= maxX
+}
+
+// SetLength resizes the line about its midpoint, keeping its orientation.
+// The length is clamped to MinStartLineLength..MaxStartLineLength.
+func (l *StartLine) SetLength(length float64) {
+	length = math.Max(MinStartLineLength, math.Min(MaxStartLineLength, length))
+
+	current := l.Length()
+	if current == 0 {
+		return // No orientation to keep
+	}
+	mid := l.Midpoint()
+	halfX := (l.Committee.Pos.X - l.Pin.Pos.X) / current * length / 2
+	halfY := (l.Committee.Pos.Y - l.Pin.Pos.Y) / current * length / 2
+
+	l.Pin.Pos = geometry.Point{X: mid.X - halfX, Y: mid.Y - halfY}
+	l.Committee.Pos = geometry.Point{X: mid.X + halfX, Y: mid.Y + halfY}
+}
@@ -0,0 +1,73 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+func TestStartLine_SetLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   float64
+		expected float64
+	}{
+		{"Lengthen", 500, 500},
+		{"Shorten", 200, 200},
+		{"Clamped to minimum", 10, MinStartLineLength},
+		{"Clamped to maximum", 5000, MaxStartLineLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := &StartLine{
+				Pin:       &Mark{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+				Committee: &Mark{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+			}
+
+			line.SetLength(tt.length)
+
+			if line.Length() != tt.expected {
+				t.Errorf("Expected length %.0f, got %.0f", tt.expected, line.Length())
+			}
+			if line.Midpoint() != (geometry.Point{X: 1000, Y: 2400}) {
+				t.Errorf("Expected line to stay centered, got %v", line.Midpoint())
+			}
+			if !line.WithinBounds(1000+tt.expected/2) || line.WithinBounds(1000+tt.expected/2+1) {
+				t.Errorf("Expected bounds to end at the committee mark")
+			}
+		})
+	}
+}
+
+func TestStartLine_Bias(t *testing.T) {
+	line := &StartLine{
+		Pin:       &Mark{Pos: geometry.Point{X: 800, Y: 2400}, Name: "Pin"},
+		Committee: &Mark{Pos: geometry.Point{X: 1200, Y: 2400}, Name: "Committee", Solid: true},
+	}
+
+	tests := []struct {
+		name    string
+		windDir float64
+		favored string
+		degrees float64
+	}{
+		{"Square to a northerly", 0, "Square", 0},
+		{"Veered: committee end upwind", 10, "Committee", 10},
+		{"Backed: pin end upwind", 352, "Pin", 8},
+		{"Backed the other way round", -8, "Pin", 8},
+		{"Hard veer", 40, "Committee", 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			favored, degrees, meters := line.Bias(tt.windDir)
+			if favored != tt.favored || math.Abs(degrees-tt.degrees) > 1e-9 {
+				t.Errorf("Expected %s by %.0f°, got %s by %.2f°", tt.favored, tt.degrees, favored, degrees)
+			}
+			if want := 400 * math.Sin(tt.degrees*math.Pi/180); math.Abs(meters-want) > 1e-9 {
+				t.Errorf("Expected the favored end %.1fm upwind, got %.1fm", want, meters)
+			}
+		})
+	}
+}
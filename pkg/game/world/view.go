@@ -0,0 +1,33 @@
+package world
+
+// View maps world coordinates (meters) to pixels on a render target
+type View struct {
+	OffsetX float64 // World X at the left edge of the target (camera position)
+	OffsetY float64 // World Y at the top edge of the target
+	Scale   float64 // Render pixels per meter (2 when supersampling)
+}
+
+// ToScreen converts a world position to render-target pixels
+func (v View) ToScreen(x, y float64) (float64, float64) {
+	s := v.scale()
+	return (x - v.OffsetX) * s, (y - v.OffsetY) * s
+}
+
+// Length converts a world distance to render-target pixels
+func (v View) Length(l float64) float64 {
+	return l * v.scale()
+}
+
+// ToWorld converts render-target pixels back to a world position
+func (v View) ToWorld(px, py float64) (float64, float64) {
+	s := v.scale()
+	return px/s + v.OffsetX, py/s + v.OffsetY
+}
+
+// scale returns the pixel scale, treating an unset scale as 1:1
+func (v View) scale() float64 {
+	if v.Scale <= 0 {
+		return 1
+	}
+	return v.Scale
+}
@@ -21,23 +21,66 @@ func (cw *ConstantWind) GetWind(_ geometry.Point) (float64, float64) {
 	return cw.Direction, cw.Speed
 }
 
-// VariableWind provides wind that varies in strength across the course
+// VariableWind provides wind that varies in strength across the course.
+// Left and right are as seen looking upwind along the course axis; with the axis at
+// North (the default) they are simply X=0 and X=WorldWidth.
 type VariableWind struct {
 	Direction  float64 // Wind direction (constant)
 	LeftSpeed  float64 // Wind speed on left side (X=0)
 	RightSpeed float64 // Wind speed on right side (X=WorldWidth)
 	WorldWidth float64 // Width of the world for interpolation
+
+	Axis   float64        // Course axis the sides are measured across (0 = North)
+	Center geometry.Point // Point on the axis midway between the sides (zero = middle of the world)
+
+	// Optional build or fade up the course; with both speeds zero the wind is the same top to bottom
+	BottomSpeed float64 // Average wind speed across the bottom of the course (Y=WorldHeight)
+	TopSpeed    float64 // Average wind speed across the top of the course (Y=0)
+	WorldHeight float64 // Height of the world for interpolation
+}
+
+// center returns the point the course axis pivots about, the middle of the world unless set
+func (vw *VariableWind) center() geometry.Point {
+	if vw.Center == (geometry.Point{}) {
+		return geometry.Point{X: vw.WorldWidth / 2, Y: vw.WorldHeight / 2}
+	}
+	return vw.Center
+}
+
+// crossCourse returns how far across the course pos is, from the left side (0) to the right (1)
+func (vw *VariableWind) crossCourse(pos geometry.Point) float64 {
+	center := vw.center()
+
+	// Unit vector pointing to the right of the axis (bearing Axis+90)
+	axisRad := vw.Axis * math.Pi / 180
+	across := (pos.X-center.X)*math.Cos(axisRad) + (pos.Y-center.Y)*math.Sin(axisRad)
+	return 0.5 + across/vw.WorldWidth
+}
+
+// upCourse returns how far up the course pos is, from the bottom (0) to the top (1)
+func (vw *VariableWind) upCourse(pos geometry.Point) float64 {
+	center := vw.center()
+
+	// Unit vector pointing up the axis (bearing Axis), Y inverted
+	axisRad := vw.Axis * math.Pi / 180
+	up := (pos.X-center.X)*math.Sin(axisRad) - (pos.Y-center.Y)*math.Cos(axisRad)
+	return 0.5 + up/vw.WorldHeight
+}
+
+// hasGradient reports whether the wind builds or fades up the course
+func (vw *VariableWind) hasGradient() bool {
+	return (vw.BottomSpeed != 0 || vw.TopSpeed != 0) && vw.WorldHeight > 0 && vw.LeftSpeed+vw.RightSpeed > 0
 }
 
 func (vw *VariableWind) GetWind(pos geometry.Point) (float64, float64) {
 	// Validate inputs to prevent NaN
-	if math.IsNaN(pos.X) || math.IsInf(pos.X, 0) || vw.WorldWidth <= 0 {
+	if math.IsNaN(pos.X) || math.IsInf(pos.X, 0) || math.IsNaN(pos.Y) || math.IsInf(pos.Y, 0) || vw.WorldWidth <= 0 {
 		return vw.Direction, vw.LeftSpeed // Return safe fallback
 	}
 
-	// Interpolate wind speed based on X position
-	// X=0 (left) = LeftSpeed, X=WorldWidth (right) = RightSpeed
-	xRatio := pos.X / vw.WorldWidth
+	// Interpolate wind speed across the course axis
+	// Left side = LeftSpeed, right side = RightSpeed
+	xRatio := vw.crossCourse(pos)
 	if xRatio < 0 {
 		xRatio = 0
 	} else if xRatio > 1 {
@@ -47,6 +90,14 @@ func (vw *VariableWind) GetWind(pos geometry.Point) (float64, float64) {
 	// Linear interpolation between left and right speeds
 	speed := vw.LeftSpeed + (vw.RightSpeed-vw.LeftSpeed)*xRatio
 
+	// Scale the side-to-side profile so its average matches the speed at this height up the
+	// course, a bilinear blend across the four corners
+	if vw.hasGradient() {
+		yRatio := math.Max(0, math.Min(1, vw.upCourse(pos)))
+		average := (vw.LeftSpeed + vw.RightSpeed) / 2
+		speed *= (vw.BottomSpeed + (vw.TopSpeed-vw.BottomSpeed)*yRatio) / average
+	}
+
 	// Validate result
 	if math.IsNaN(speed) || math.IsInf(speed, 0) || speed < 0 {
 		speed = vw.LeftSpeed // Fallback to left speed
@@ -58,27 +109,35 @@ func (vw *VariableWind) GetWind(pos geometry.Point) (float64, float64) {
 // OscillatingWind wraps VariableWind with random directional oscillations
 type OscillatingWind struct {
 	baseWind        *VariableWind
-	medianDirection float64 // Base wind direction (0 = North)
+	medianDirection float64 // Base wind direction (0 = North), also the course axis
 
-	// Oscillation state
-	shiftStartTime   time.Time     // When current shift started
+	// Oscillation state, timed on the game clock so the wind holds still while paused
+	clock            time.Duration // Game time the wind has been running
+	lastElapsed      float64       // Game elapsed seconds at the last update
+	shiftStartTime   time.Duration // When current shift started
 	shiftDuration    time.Duration // How long this shift lasts
 	shiftAngle       float64       // Target shift angle (-10 to +10 degrees)
 	currentDirection float64       // Current wind direction including shift
 
 	// Phase tracking
 	shiftPhase     int           // 0=shifting out, 1=at peak, 2=shifting back
-	phaseStartTime time.Time     // When current phase started
+	phaseStartTime time.Duration // When current phase started
 	phaseDuration  time.Duration // Duration of current phase
 
 	// Start line bias (initial oscillation)
 	isInitialBias      bool      // Whether this is the first bias oscillation
 	initialBiasAngle   float64   // Fixed bias angle for initial oscillation
-	gameStartTime      time.Time // When the game started (for 3s delay)
 	isInInitialBiasCycle bool    // Whether we're currently executing the initial bias cycle
+
+	// Gusts and lulls drifting across the course (none unless enabled with SetGusts)
+	gusts       []*Gust
+	gustConfig  GustConfig
+	worldHeight float64 // Height of the area gusts spawn in
 }
 
-func NewOscillatingWind(leftSpeed, rightSpeed, worldWidth float64) *OscillatingWind {
+// NewOscillatingWind creates a wind oscillating around medianDirection (0 = North), stronger
+// on one side of the course than the other
+func NewOscillatingWind(leftSpeed, rightSpeed, worldWidth, medianDirection float64) *OscillatingWind {
 	// Randomly determine start line bias
 	// Positive angle = committee boat favored (starboard tack lift)
 	// Negative angle = pin favored (port tack lift)
@@ -89,43 +148,45 @@ func NewOscillatingWind(leftSpeed, rightSpeed, worldWidth float64) *OscillatingW
 	// Random bias between 5 and 15 degrees
 	biasAngle := biasDirection * (5.0 + rand.Float64()*10.0)
 
-	now := time.Now()
 	ow := &OscillatingWind{
 		baseWind: &VariableWind{
-			Direction:  0,
+			Direction:  medianDirection,
 			LeftSpeed:  leftSpeed,
 			RightSpeed: rightSpeed,
 			WorldWidth: worldWidth,
+			Axis:       medianDirection,
 		},
-		medianDirection:  0, // North
-		currentDirection: 0,
+		medianDirection:  medianDirection,
+		currentDirection: medianDirection,
 		shiftPhase:       0,
-		shiftStartTime:   now,
-		phaseStartTime:   now,
 		// Initial bias setup
 		isInitialBias:    true,
 		initialBiasAngle: biasAngle,
-		gameStartTime:    now,
 	}
-	// Initialize first shift with bias - will start after 3 second delay
-	ow.startNewShift(now)
+	// Initialize first shift with bias
+	ow.startNewShift(0)
 	return ow
 }
 
+// Update advances the wind by one frame at 60 FPS
 func (ow *OscillatingWind) Update() {
-	ow.UpdateWithElapsedTime(0)
+	ow.Advance(time.Second / 60)
 }
 
+// UpdateWithElapsedTime advances the wind to the game's elapsed time. The clock never runs
+// backwards: if the game rewinds (start rehearsal) the wind carries on from where it was.
 func (ow *OscillatingWind) UpdateWithElapsedTime(gameElapsedSeconds float64) {
-	now := time.Now()
+	dt := gameElapsedSeconds - ow.lastElapsed
+	ow.lastElapsed = gameElapsedSeconds
+	ow.Advance(time.Duration(math.Max(0, dt) * float64(time.Second)))
+}
 
-	// Check if we need to start a new shift cycle
-	if ow.shiftPhase == 0 && ow.shiftStartTime.IsZero() {
-		// Initialize first shift
-		ow.startNewShift(now)
-	}
+// Advance moves the shift cycle and any gusts forward by dt of game time
+func (ow *OscillatingWind) Advance(dt time.Duration) {
+	ow.clock += dt
+	now := ow.clock
 
-	elapsedPhase := now.Sub(ow.phaseStartTime)
+	elapsedPhase := now - ow.phaseStartTime
 
 	switch ow.shiftPhase {
 	case 0: // Shifting out from median to target angle
@@ -183,9 +244,11 @@ func (ow *OscillatingWind) UpdateWithElapsedTime(gameElapsedSeconds float64) {
 
 	// Update the base wind direction
 	ow.baseWind.Direction = ow.currentDirection
+
+	ow.updateGusts(dt.Seconds())
 }
 
-func (ow *OscillatingWind) startNewShift(now time.Time) {
+func (ow *OscillatingWind) startNewShift(now time.Duration) {
 	// Check if this is the initial bias shift
 	if ow.isInitialBias {
 		// Use fixed bias parameters for initial shift
@@ -219,6 +282,37 @@ func (ow *OscillatingWind) startNewShift(now time.Time) {
 	}
 }
 
+// SetCourseCenter sets the point the course axis passes through, midway between the
+// strong and weak sides and halfway up any gradient. Across the course it only matters
+// when the median direction is not North.
+func (ow *OscillatingWind) SetCourseCenter(center geometry.Point) {
+	ow.baseWind.Center = center
+}
+
+// SetGradient makes the wind build or fade up the course, averaging bottomSpeed across the
+// bottom of a worldHeight tall course and topSpeed across the top
+func (ow *OscillatingWind) SetGradient(bottomSpeed, topSpeed, worldHeight float64) {
+	ow.baseWind.BottomSpeed = bottomSpeed
+	ow.baseWind.TopSpeed = topSpeed
+	ow.baseWind.WorldHeight = worldHeight
+}
+
+// MedianDirection returns the direction the wind oscillates around
+func (ow *OscillatingWind) MedianDirection() float64 {
+	return ow.medianDirection
+}
+
+// InStartBias reports whether the wind is still in its opening shift, which biases the
+// start line rather than oscillating around the median
+func (ow *OscillatingWind) InStartBias() bool {
+	return ow.isInInitialBiasCycle
+}
+
+// GetWind returns the wind at pos, including any gusts or lulls passing over it
 func (ow *OscillatingWind) GetWind(pos geometry.Point) (float64, float64) {
-	return ow.baseWind.GetWind(pos)
+	direction, speed := ow.baseWind.GetWind(pos)
+	for _, g := range ow.gusts {
+		speed += g.SpeedDelta(pos)
+	}
+	return direction, math.Max(0, speed)
 }
@@ -3,13 +3,14 @@ package world
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/mpihlak/gosailing2/pkg/geometry"
 )
 
 func TestOscillatingWind_LeftSide(t *testing.T) {
 	// Left side: 14 kts, Right side: 8 kts
-	wind := NewOscillatingWind(14.0, 8.0, 2000.0)
+	wind := NewOscillatingWind(14.0, 8.0, 2000.0, 0)
 
 	// Test at far left (X=0)
 	dir, speed := wind.GetWind(geometry.Point{X: 0, Y: 1000})
@@ -24,7 +25,7 @@ func TestOscillatingWind_LeftSide(t *testing.T) {
 }
 
 func TestOscillatingWind_RightSide(t *testing.T) {
-	wind := NewOscillatingWind(14.0, 8.0, 2000.0)
+	wind := NewOscillatingWind(14.0, 8.0, 2000.0, 0)
 
 	// Test at far right (X=2000)
 	dir, speed := wind.GetWind(geometry.Point{X: 2000, Y: 1000})
@@ -39,7 +40,7 @@ func TestOscillatingWind_RightSide(t *testing.T) {
 }
 
 func TestOscillatingWind_Center(t *testing.T) {
-	wind := NewOscillatingWind(14.0, 8.0, 2000.0)
+	wind := NewOscillatingWind(14.0, 8.0, 2000.0, 0)
 
 	// Test at center (X=1000)
 	dir, speed := wind.GetWind(geometry.Point{X: 1000, Y: 1000})
@@ -56,7 +57,7 @@ func TestOscillatingWind_Center(t *testing.T) {
 }
 
 func TestOscillatingWind_Interpolation(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 20.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 20.0, 2000.0, 0)
 
 	tests := []struct {
 		name          string
@@ -83,7 +84,7 @@ func TestOscillatingWind_Interpolation(t *testing.T) {
 }
 
 func TestOscillatingWind_DirectionConstant(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 15.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 15.0, 2000.0, 0)
 
 	// Wind direction should be 0 (North) everywhere initially
 	positions := []geometry.Point{
@@ -103,7 +104,7 @@ func TestOscillatingWind_DirectionConstant(t *testing.T) {
 }
 
 func TestOscillatingWind_NegativePosition(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 20.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 20.0, 2000.0, 0)
 
 	// Test with X < 0 (should clamp to 0)
 	_, speed := wind.GetWind(geometry.Point{X: -100, Y: 1000})
@@ -114,7 +115,7 @@ func TestOscillatingWind_NegativePosition(t *testing.T) {
 }
 
 func TestOscillatingWind_BeyondWorldWidth(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 20.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 20.0, 2000.0, 0)
 
 	// Test with X > worldWidth (should clamp to worldWidth)
 	_, speed := wind.GetWind(geometry.Point{X: 3000, Y: 1000})
@@ -125,7 +126,7 @@ func TestOscillatingWind_BeyondWorldWidth(t *testing.T) {
 }
 
 func TestOscillatingWind_UpdateWithElapsedTime(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 20.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 20.0, 2000.0, 0)
 
 	// Get initial direction
 	dirBefore, _ := wind.GetWind(geometry.Point{X: 1000, Y: 1000})
@@ -147,7 +148,7 @@ func TestOscillatingWind_UpdateWithElapsedTime(t *testing.T) {
 }
 
 func TestOscillatingWind_YPositionDoesNotAffect(t *testing.T) {
-	wind := NewOscillatingWind(10.0, 20.0, 2000.0)
+	wind := NewOscillatingWind(10.0, 20.0, 2000.0, 0)
 
 	// Same X, different Y positions should give same result
 	_, speed1 := wind.GetWind(geometry.Point{X: 1000, Y: 0})
@@ -162,7 +163,7 @@ func TestOscillatingWind_YPositionDoesNotAffect(t *testing.T) {
 
 func TestOscillatingWind_EqualLeftRight(t *testing.T) {
 	// When left and right speeds are equal, should be constant across field
-	wind := NewOscillatingWind(12.0, 12.0, 2000.0)
+	wind := NewOscillatingWind(12.0, 12.0, 2000.0, 0)
 
 	positions := []float64{0, 500, 1000, 1500, 2000}
 
@@ -173,3 +174,137 @@ func TestOscillatingWind_EqualLeftRight(t *testing.T) {
 		}
 	}
 }
+
+func TestOscillatingWind_MedianDirection(t *testing.T) {
+	wind := NewOscillatingWind(12.0, 12.0, 2000.0, 20)
+
+	if dir, _ := wind.GetWind(geometry.Point{X: 1000, Y: 1000}); dir != 20 {
+		t.Errorf("Expected wind from the 20° median before any shift, got %.1f°", dir)
+	}
+	if wind.MedianDirection() != 20 {
+		t.Errorf("Expected median direction 20°, got %.1f°", wind.MedianDirection())
+	}
+}
+
+func TestOscillatingWind_InStartBias(t *testing.T) {
+	wind := NewOscillatingWind(12.0, 12.0, 2000.0, 0)
+	if !wind.InStartBias() {
+		t.Fatal("Expected the wind to open with the start-line bias shift")
+	}
+
+	shadowed := NewShadowedWind(wind, nil, nil, ShadowConfig{})
+	if !shadowed.InStartBias() {
+		t.Error("Expected the shadowed wind to report the undisturbed wind's bias shift")
+	}
+
+	// The bias shift lasts 45 seconds, then normal oscillations take over
+	for i := 0; i < 44; i++ {
+		wind.Advance(time.Second)
+	}
+	if !wind.InStartBias() {
+		t.Error("Expected the bias shift to still be running at 44s")
+	}
+	wind.Advance(time.Second)
+	wind.Advance(time.Second)
+	if wind.InStartBias() || shadowed.InStartBias() {
+		t.Error("Expected the bias shift to be over at 46s")
+	}
+}
+
+func TestVariableWind_SidesRotateWithAxis(t *testing.T) {
+	center := geometry.Point{X: 1000, Y: 1500}
+	wind := &VariableWind{Direction: 90, LeftSpeed: 8, RightSpeed: 14, WorldWidth: 2000, Axis: 90, Center: center}
+
+	// Looking upwind into an easterly, the left side is to the north and the right to the south
+	_, north := wind.GetWind(geometry.Point{X: 1000, Y: 500})
+	_, south := wind.GetWind(geometry.Point{X: 1000, Y: 2500})
+	if math.Abs(north-8) > 0.01 || math.Abs(south-14) > 0.01 {
+		t.Errorf("Expected 8 kts north and 14 kts south of the course, got %.2f and %.2f", north, south)
+	}
+
+	// Moving along the axis (east-west) doesn't change the speed
+	_, east := wind.GetWind(geometry.Point{X: 1900, Y: 1500})
+	_, west := wind.GetWind(geometry.Point{X: 100, Y: 1500})
+	if math.Abs(east-11) > 0.01 || math.Abs(west-11) > 0.01 {
+		t.Errorf("Expected 11 kts along the axis, got %.2f east and %.2f west", east, west)
+	}
+}
+
+func TestVariableWind_BuildsUpTheCourse(t *testing.T) {
+	wind := &VariableWind{LeftSpeed: 8, RightSpeed: 12, WorldWidth: 2000, BottomSpeed: 6, TopSpeed: 14, WorldHeight: 3000}
+
+	// Corners blend both ways: the side-to-side profile scaled to the speed at each height
+	corners := []struct {
+		pos  geometry.Point
+		want float64
+	}{
+		{geometry.Point{X: 0, Y: 3000}, 8 * 6.0 / 10},
+		{geometry.Point{X: 2000, Y: 3000}, 12 * 6.0 / 10},
+		{geometry.Point{X: 0, Y: 0}, 8 * 14.0 / 10},
+		{geometry.Point{X: 2000, Y: 0}, 12 * 14.0 / 10},
+		{geometry.Point{X: 1000, Y: 1500}, 10},
+	}
+	for _, c := range corners {
+		if _, speed := wind.GetWind(c.pos); math.Abs(speed-c.want) > 1e-9 {
+			t.Errorf("Expected %.2f kts at %+v, got %.2f", c.want, c.pos, speed)
+		}
+	}
+
+	// Beyond the top of the world the wind holds at the top speed
+	if _, speed := wind.GetWind(geometry.Point{X: 1000, Y: -500}); math.Abs(speed-14) > 1e-9 {
+		t.Errorf("Expected 14 kts above the course, got %.2f", speed)
+	}
+}
+
+func TestVariableWind_RotatedAxisPivotsAboutTheMiddle(t *testing.T) {
+	// Wind from the East with no center set: the axis runs through the middle of the world
+	wind := &VariableWind{Direction: 90, LeftSpeed: 8, RightSpeed: 12, WorldWidth: 2000, Axis: 90, BottomSpeed: 6, TopSpeed: 14, WorldHeight: 3000}
+
+	if _, speed := wind.GetWind(geometry.Point{X: 1000, Y: 1500}); math.Abs(speed-10) > 1e-9 {
+		t.Errorf("Expected the average 10 kts in the middle of the world, got %.2f", speed)
+	}
+}
+
+func TestVariableWind_NoGradientByDefault(t *testing.T) {
+	wind := &VariableWind{LeftSpeed: 8, RightSpeed: 12, WorldWidth: 2000}
+
+	_, top := wind.GetWind(geometry.Point{X: 500, Y: 0})
+	_, bottom := wind.GetWind(geometry.Point{X: 500, Y: 3000})
+	if top != 9 || bottom != 9 {
+		t.Errorf("Expected 9 kts top and bottom without a gradient, got %.2f and %.2f", top, bottom)
+	}
+
+	// A gradient with no height to spread over is ignored rather than dividing by zero
+	wind.TopSpeed, wind.BottomSpeed = 14, 6
+	if _, speed := wind.GetWind(geometry.Point{X: 500, Y: 0}); speed != 9 {
+		t.Errorf("Expected the gradient to be ignored without a world height, got %.2f", speed)
+	}
+}
+
+func TestOscillatingWind_PauseHoldsShiftPhase(t *testing.T) {
+	wind := NewOscillatingWind(12.0, 12.0, 2000.0, 0)
+	pos := geometry.Point{X: 1000, Y: 1000}
+
+	// Halfway through the 10s swing out to the initial bias
+	wind.UpdateWithElapsedTime(5)
+	dirBefore, _ := wind.GetWind(pos)
+	if want := geometry.NormalizeAngle(wind.initialBiasAngle * 0.5); math.Abs(geometry.NormalizeAngle(dirBefore)-want) > 1e-9 {
+		t.Fatalf("Expected the wind halfway to the initial bias (%.2f°) at 5s, got %.2f°", want, dirBefore)
+	}
+
+	// Paused for 10 seconds of wall clock: the game clock stands still
+	for frame := 0; frame < 10*60; frame++ {
+		wind.UpdateWithElapsedTime(5)
+	}
+	dirAfter, _ := wind.GetWind(pos)
+	if dirAfter != dirBefore || wind.shiftPhase != 0 {
+		t.Errorf("Expected the pause to hold the shift: %.2f° -> %.2f°, phase %d", dirBefore, dirAfter, wind.shiftPhase)
+	}
+
+	// One more second of game time after unpausing moves it on by one second, not eleven
+	wind.UpdateWithElapsedTime(6)
+	dir, _ := wind.GetWind(pos)
+	if want := geometry.NormalizeAngle(wind.initialBiasAngle * 0.6); math.Abs(geometry.NormalizeAngle(dir)-want) > 1e-9 {
+		t.Errorf("Expected the shift 60%% of the way out at 6s (%.2f°), got %.2f°", want, dir)
+	}
+}
@@ -0,0 +1,95 @@
+package world
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mpihlak/gosailing2/pkg/geometry"
+)
+
+const (
+	barbRedrawAngle = 2.0  // Redraw the barbs once the wind at any of them turns this many degrees
+	barbPadding     = 30.0 // Meters around the grid for the barb shafts and speed labels
+)
+
+// barbSample is the wind a barb was drawn for
+type barbSample struct {
+	Dir   float64
+	Speed float64
+}
+
+// barbLayer caches the wind barbs at the points of the visible grid. Drawing them is a few
+// lines per grid point every frame, while they only change when the wind shifts noticeably or the
+// camera moves onto new grid points.
+type barbLayer struct {
+	image   *ebiten.Image
+	grid    layerGrid
+	samples []barbSample // Wind each barb was drawn for, row by row
+	labels  bool         // Whether the speed labels were drawn
+}
+
+// sampleWind returns the wind at each grid point, row by row
+func (g layerGrid) sampleWind(wind Wind) []barbSample {
+	samples := make([]barbSample, 0, g.Cols*g.Rows)
+	for row := 0; row < g.Rows; row++ {
+		for col := 0; col < g.Cols; col++ {
+			dir, speed := wind.GetWind(g.point(col, row))
+			samples = append(samples, barbSample{Dir: dir, Speed: speed})
+		}
+	}
+	return samples
+}
+
+// stale reports whether the cached barbs no longer match: the camera moved onto other grid
+// points, the zoom changed, labels were toggled, or the wind at a barb turned past the
+// redraw threshold or changed enough to draw different feathers or labels
+func (l *barbLayer) stale(grid layerGrid, samples []barbSample, labels bool) bool {
+	if l.image == nil || grid != l.grid || labels != l.labels || len(samples) != len(l.samples) {
+		return true
+	}
+	for i, s := range samples {
+		drawn := l.samples[i]
+		if math.Abs(geometry.NormalizeAngle(s.Dir-drawn.Dir)) > barbRedrawAngle ||
+			int(s.Speed)/5 != int(drawn.Speed)/5 ||
+			(labels && windSpeedLabel(s.Speed) != windSpeedLabel(drawn.Speed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// origin returns the world position of the cached image's top-left corner
+func (l *barbLayer) origin() (float64, float64) {
+	return l.grid.StartX - barbPadding, l.grid.StartY - barbPadding
+}
+
+// redraw renders the barbs for the grid onto the cached image
+func (l *barbLayer) redraw(a *Arena, grid layerGrid, samples []barbSample, labels bool) {
+	l.grid, l.samples, l.labels = grid, samples, labels
+
+	// Room for every grid point plus the padding each side
+	width := int(math.Ceil((float64(grid.Cols-1)*layerGridSpacing + 2*barbPadding) * grid.Scale))
+	height := int(math.Ceil((float64(grid.Rows-1)*layerGridSpacing + 2*barbPadding) * grid.Scale))
+	if l.image == nil || l.image.Bounds().Dx() != width || l.image.Bounds().Dy() != height {
+		l.image = ebiten.NewImage(width, height)
+	} else {
+		l.image.Clear()
+	}
+
+	originX, originY := l.origin()
+	view := View{OffsetX: originX, OffsetY: originY, Scale: grid.Scale}
+	for row := 0; row < grid.Rows; row++ {
+		for col := 0; col < grid.Cols; col++ {
+			p := grid.point(col, row)
+			s := samples[row*grid.Cols+col]
+			a.drawWindBarb(l.image, view, p.X, p.Y, s.Dir, s.Speed)
+
+			// Numeric speed label just right of the barb base
+			if labels {
+				px, py := view.ToScreen(p.X, p.Y)
+				ebitenutil.DebugPrintAt(l.image, windSpeedLabel(s.Speed), int(px+view.Length(4)), int(py))
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package world
+
+import "testing"
+
+func TestBarbLayer_RedrawsOnlyWhenWindChanges(t *testing.T) {
+	arena := &Arena{}
+	grid := visibleGrid(View{Scale: 1}, 320, 240)
+	wind := &ConstantWind{Direction: 0, Speed: 12}
+
+	var layer barbLayer
+	samples := grid.sampleWind(wind)
+	if !layer.stale(grid, samples, false) {
+		t.Fatal("Expected an empty layer to need drawing")
+	}
+	layer.redraw(arena, grid, samples, false)
+	if layer.stale(grid, grid.sampleWind(wind), false) {
+		t.Error("Expected no redraw in the same wind")
+	}
+
+	tests := []struct {
+		name   string
+		wind   ConstantWind
+		labels bool
+		stale  bool
+	}{
+		{"Small oscillation", ConstantWind{Direction: 1, Speed: 12}, false, false},
+		{"Past the redraw angle", ConstantWind{Direction: 3, Speed: 12}, false, true},
+		{"Backed past north", ConstantWind{Direction: 359, Speed: 12}, false, false},
+		{"Speed within the same feathers", ConstantWind{Direction: 0, Speed: 13.4}, false, false},
+		{"Speed onto a half barb", ConstantWind{Direction: 0, Speed: 15}, false, true},
+		{"Labels turned on", ConstantWind{Direction: 0, Speed: 12}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if stale := layer.stale(grid, grid.sampleWind(&tt.wind), tt.labels); stale != tt.stale {
+				t.Errorf("stale() = %v, expected %v", stale, tt.stale)
+			}
+		})
+	}
+
+	// The camera reaching new grid points redraws too
+	moved := visibleGrid(View{OffsetX: 150, Scale: 1}, 320, 240)
+	if !layer.stale(moved, moved.sampleWind(wind), false) {
+		t.Error("Expected a redraw once the camera reaches new grid points")
+	}
+}
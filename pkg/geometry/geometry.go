@@ -1,5 +1,33 @@
 package geometry
 
+import "math"
+
 type Point struct {
 	X, Y float64
 }
+
+// NormalizeAngle maps an angle in degrees to -180..180
+func NormalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < -180 {
+		angle += 360
+	} else if angle > 180 {
+		angle -= 360
+	}
+	return angle
+}
+
+// Bearing returns the compass bearing in degrees (0 = North, Y growing southward) from one
+// point to another
+func Bearing(from, to Point) float64 {
+	bearing := math.Atan2(to.X-from.X, from.Y-to.Y) * 180 / math.Pi
+	if bearing < 0 {
+		bearing += 360
+	}
+	return bearing
+}
+
+// AngleDiff returns a - b in degrees, normalized to -180..180
+func AngleDiff(a, b float64) float64 {
+	return NormalizeAngle(a - b)
+}
@@ -0,0 +1,42 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeAngle(t *testing.T) {
+	tests := []struct{ angle, want float64 }{
+		{0, 0},
+		{190, -170},
+		{-190, 170},
+		{540, 180},
+		{-725, -5},
+	}
+	for _, tt := range tests {
+		if got := NormalizeAngle(tt.angle); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("NormalizeAngle(%.0f) = %.1f, expected %.1f", tt.angle, got, tt.want)
+		}
+	}
+	if got := AngleDiff(10, 350); math.Abs(got-20) > 1e-9 {
+		t.Errorf("Expected 10° to be 20° right of 350°, got %.1f", got)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	from := Point{X: 1000, Y: 1000}
+	tests := []struct {
+		to   Point
+		want float64
+	}{
+		{Point{X: 1000, Y: 900}, 0},    // North is up the screen
+		{Point{X: 1100, Y: 1000}, 90},  // East
+		{Point{X: 1000, Y: 1100}, 180}, // South
+		{Point{X: 900, Y: 900}, 315},   // North-west
+	}
+	for _, tt := range tests {
+		if got := Bearing(from, tt.to); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Bearing to %+v = %.1f, expected %.1f", tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package polars
+
+// DinghyPolar is a single-handed planing dinghy: slower and wider-angled upwind than
+// RealisticPolar, but it planes off downwind once the breeze is up
+type DinghyPolar struct{}
+
+// dinghyTable is the performance data behind DinghyPolar
+var dinghyTable = &TablePolar{
+	WindSpeeds: []float64{4, 6, 8, 10, 12, 14, 16, 20, 24},
+	Angles:     []float64{52, 60, 75, 90, 110, 120, 135, 150, 170, 180},
+
+	// Speed table: [wind_speed_index][angle_index]
+	SpeedTable: [][]float64{
+		{2.90, 3.10, 3.30, 3.40, 3.40, 3.30, 3.00, 2.60, 2.10, 1.90},      // 4 kt wind
+		{3.90, 4.10, 4.40, 4.60, 4.60, 4.50, 4.10, 3.60, 3.00, 2.70},      // 6 kt wind
+		{4.50, 4.80, 5.30, 5.60, 5.70, 5.60, 5.20, 4.60, 3.90, 3.50},      // 8 kt wind
+		{4.90, 5.20, 5.90, 6.50, 6.80, 6.80, 6.40, 5.60, 4.70, 4.30},      // 10 kt wind
+		{5.10, 5.50, 6.40, 7.30, 7.90, 8.00, 7.70, 6.80, 5.60, 5.10},      // 12 kt wind
+		{5.20, 5.70, 6.80, 8.00, 9.00, 9.30, 9.10, 8.10, 6.60, 5.90},      // 14 kt wind
+		{5.30, 5.80, 7.10, 8.60, 9.90, 10.50, 10.40, 9.40, 7.60, 6.70},    // 16 kt wind
+		{5.30, 5.90, 7.40, 9.30, 11.20, 12.20, 12.50, 11.60, 9.40, 8.20},  // 20 kt wind
+		{5.20, 5.80, 7.50, 9.70, 12.00, 13.30, 14.00, 13.20, 10.80, 9.40}, // 24 kt wind
+	},
+
+	// Close-hauled: dinghies point lower than keelboats and depower early in a blow
+	BeatVMG:    []float64{2.00, 2.70, 3.20, 3.50, 3.60, 3.65, 3.65, 3.60, 3.50},
+	BeatAngles: []float64{45.0, 44.0, 43.0, 43.0, 42.5, 42.5, 43.0, 44.0, 45.0},
+}
+
+// GetBoatSpeed returns boat speed in knots based on TWA (degrees) and TWS (knots)
+func (dp *DinghyPolar) GetBoatSpeed(twa, tws float64) float64 {
+	return dinghyTable.GetBoatSpeed(twa, tws)
+}
@@ -0,0 +1,73 @@
+package polars
+
+import (
+	"testing"
+)
+
+func TestOptimalBeatAngle_TightensInGust(t *testing.T) {
+	polar := &RealisticPolar{}
+
+	lullAngle := OptimalBeatAngle(polar, 8)
+	gustAngle := OptimalBeatAngle(polar, 14)
+
+	if gustAngle >= lullAngle {
+		t.Errorf("Expected optimal beat angle to tighten in a gust: 8 kts = %.1f°, 14 kts = %.1f°", lullAngle, gustAngle)
+	}
+
+	// Angles should match the polar's close-hauled data
+	if lullAngle < 39 || lullAngle > 42 {
+		t.Errorf("Expected beat angle around 40.4° at 8 kts, got %.1f°", lullAngle)
+	}
+	if gustAngle < 36 || gustAngle > 38 {
+		t.Errorf("Expected beat angle around 36.9° at 14 kts, got %.1f°", gustAngle)
+	}
+}
+
+func TestDinghyPolar_PlanesOffDownwind(t *testing.T) {
+	if err := dinghyTable.Validate(); err != nil {
+		t.Fatalf("Expected the dinghy table to be valid: %v", err)
+	}
+
+	dinghy, keelboat := &DinghyPolar{}, &RealisticPolar{}
+	if OptimalBeatAngle(dinghy, 10) <= OptimalBeatAngle(keelboat, 10) {
+		t.Errorf("Expected the dinghy to point lower than the keelboat: dinghy %.1f°, keelboat %.1f°",
+			OptimalBeatAngle(dinghy, 10), OptimalBeatAngle(keelboat, 10))
+	}
+	if dinghy.GetBoatSpeed(120, 20) <= keelboat.GetBoatSpeed(120, 20) {
+		t.Errorf("Expected the dinghy to out-reach the keelboat in 20 kts: dinghy %.2f, keelboat %.2f kts",
+			dinghy.GetBoatSpeed(120, 20), keelboat.GetBoatSpeed(120, 20))
+	}
+}
+
+func TestSpinnakerFactor_PaysOffOnlyDeep(t *testing.T) {
+	tests := []struct {
+		name    string
+		twa     float64
+		faster  bool
+		penalty bool
+	}{
+		{"Close reach", 60, false, true},
+		{"Beam reach", 95, false, true},
+		{"Broad reach", 135, true, false},
+		{"Other gybe", -150, true, false},
+		{"Deep run", 170, true, false},
+		{"Dead downwind", 180, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor := SpinnakerFactor(tt.twa)
+			if tt.faster && factor <= 1 {
+				t.Errorf("SpinnakerFactor(%.0f) = %.2f, expected the kite to add speed", tt.twa, factor)
+			}
+			if tt.penalty && factor >= 1 {
+				t.Errorf("SpinnakerFactor(%.0f) = %.2f, expected the kite to cost speed", tt.twa, factor)
+			}
+		})
+	}
+
+	if SpinnakerFactor(60) >= SpinnakerFactor(100) {
+		t.Errorf("Expected flying the kite well above a reach to cost most: 60° = %.2f, 100° = %.2f",
+			SpinnakerFactor(60), SpinnakerFactor(100))
+	}
+}
@@ -0,0 +1,36 @@
+package polars
+
+import "math"
+
+// Spinnaker trim: the kite pays off between the best angles, is dragged sideways when flown
+// higher than a beam reach and is blanketed by the main dead downwind
+const (
+	SpinnakerMinTWA   = 90.0 // The kite can only be hoisted this deep or deeper
+	spinnakerBestLow  = 120.0
+	spinnakerBestHigh = 170.0
+	spinnakerBoost    = 1.15 // Speed multiplier between the best angles
+	spinnakerReaching = 0.9  // Multiplier at SpinnakerMinTWA, rising to the boost at spinnakerBestLow
+	spinnakerTooHigh  = 0.75 // Multiplier when luffed up above SpinnakerMinTWA with the kite still up
+	spinnakerDeadRun  = 0.95 // Multiplier dead downwind, blanketed behind the main
+)
+
+// SpinnakerFactor returns the boat speed multiplier for flying a spinnaker at a TWA (degrees)
+func SpinnakerFactor(twa float64) float64 {
+	absTWA := math.Abs(twa)
+	if absTWA > 180 {
+		absTWA = 360 - absTWA
+	}
+
+	switch {
+	case absTWA < SpinnakerMinTWA:
+		return spinnakerTooHigh
+	case absTWA < spinnakerBestLow:
+		factor := (absTWA - SpinnakerMinTWA) / (spinnakerBestLow - SpinnakerMinTWA)
+		return spinnakerReaching + (spinnakerBoost-spinnakerReaching)*factor
+	case absTWA <= spinnakerBestHigh:
+		return spinnakerBoost
+	default:
+		factor := (absTWA - spinnakerBestHigh) / (180 - spinnakerBestHigh)
+		return spinnakerBoost + (spinnakerDeadRun-spinnakerBoost)*factor
+	}
+}
@@ -0,0 +1,246 @@
+package polars
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// TablePolar is a polar defined by a boat speed table, so different boats can be
+// loaded from JSON instead of compiled in
+type TablePolar struct {
+	WindSpeeds []float64   `json:"windSpeeds"` // True wind speeds (knots), ascending
+	Angles     []float64   `json:"angles"`     // True wind angles (degrees), ascending, from the first tabulated reaching angle
+	SpeedTable [][]float64 `json:"speedTable"` // Boat speed (knots): [wind_speed_index][angle_index]
+	BeatVMG    []float64   `json:"beatVMG"`    // Best upwind VMG (knots) at each wind speed
+	BeatAngles []float64   `json:"beatAngles"` // TWA (degrees) of the best upwind VMG at each wind speed
+}
+
+// LoadPolarFromJSON reads a polar table from a JSON file
+func LoadPolarFromJSON(path string) (*TablePolar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadPolar(f)
+}
+
+// LoadPolar reads a polar table from JSON. Use this in the browser, where there is
+// no filesystem to open a path on.
+func LoadPolar(r io.Reader) (*TablePolar, error) {
+	var tp TablePolar
+	if err := json.NewDecoder(r).Decode(&tp); err != nil {
+		return nil, fmt.Errorf("invalid polar JSON: %w", err)
+	}
+	if err := tp.Validate(); err != nil {
+		return nil, err
+	}
+	return &tp, nil
+}
+
+// Validate checks the table dimensions line up so lookups can't index out of range, and
+// that wind speeds and angles ascend so lookups find the right pair to interpolate between
+func (tp *TablePolar) Validate() error {
+	if len(tp.WindSpeeds) < 2 {
+		return fmt.Errorf("polar needs at least 2 wind speeds, got %d", len(tp.WindSpeeds))
+	}
+	if len(tp.Angles) < 2 {
+		return fmt.Errorf("polar needs at least 2 angles, got %d", len(tp.Angles))
+	}
+	if i := firstNotAscending(tp.WindSpeeds); i > 0 {
+		return fmt.Errorf("polar wind speeds must be strictly ascending, got %g after %g", tp.WindSpeeds[i], tp.WindSpeeds[i-1])
+	}
+	if i := firstNotAscending(tp.Angles); i > 0 {
+		return fmt.Errorf("polar angles must be strictly ascending, got %g after %g", tp.Angles[i], tp.Angles[i-1])
+	}
+	if len(tp.SpeedTable) != len(tp.WindSpeeds) {
+		return fmt.Errorf("polar speed table has %d rows for %d wind speeds", len(tp.SpeedTable), len(tp.WindSpeeds))
+	}
+	for i, row := range tp.SpeedTable {
+		if len(row) != len(tp.Angles) {
+			return fmt.Errorf("polar speed table row %d has %d speeds for %d angles", i, len(row), len(tp.Angles))
+		}
+	}
+	if len(tp.BeatVMG) != len(tp.WindSpeeds) || len(tp.BeatAngles) != len(tp.WindSpeeds) {
+		return fmt.Errorf("polar beat VMG and angles need one value per wind speed (%d), got %d and %d",
+			len(tp.WindSpeeds), len(tp.BeatVMG), len(tp.BeatAngles))
+	}
+	return nil
+}
+
+// firstNotAscending returns the index of the first value that is not above the one before
+// it, or 0 when the values are strictly ascending
+func firstNotAscending(values []float64) int {
+	for i := 1; i < len(values); i++ {
+		if !(values[i] > values[i-1]) {
+			return i
+		}
+	}
+	return 0
+}
+
+// Above the table, speeds carry on the trend of the top two wind speeds only up to this
+// multiple of the top wind speed, so a storm can't drive the boat at runaway speeds
+const maxWindExtrapolation = 1.5
+
+// GetBoatSpeed returns boat speed in knots based on TWA (degrees) and TWS (knots)
+func (tp *TablePolar) GetBoatSpeed(twa, tws float64) float64 {
+	// Normalize TWA to 0-180 degrees (absolute angle)
+	absTWA := math.Abs(twa)
+	if absTWA > 180 {
+		absTWA = 360 - absTWA
+	}
+
+	// No wind (or a nonsense reading) gives no speed, so a calm can't push the boat along
+	if !(tws > 0) || math.IsNaN(absTWA) {
+		return 0
+	}
+
+	var speed float64
+	lightest, strongest := tp.WindSpeeds[0], tp.WindSpeeds[len(tp.WindSpeeds)-1]
+	switch {
+	case tws < lightest:
+		// Light air: scale the lightest row down toward no speed in no wind
+		speed = tp.tableSpeed(absTWA, lightest) * tws / lightest
+	case tws > strongest:
+		// Heavy air: extrapolate linearly up to the cap, never dropping below the top row
+		capped := math.Min(tws, strongest*maxWindExtrapolation)
+		speed = math.Max(tp.tableSpeed(absTWA, strongest), tp.tableSpeed(absTWA, capped))
+	default:
+		speed = tp.tableSpeed(absTWA, tws)
+	}
+
+	if math.IsNaN(speed) || speed < 0 {
+		return 0
+	}
+	return speed
+}
+
+// tableSpeed looks up the boat speed for an absolute TWA (0-180 degrees), interpolating
+// within the table
+func (tp *TablePolar) tableSpeed(absTWA, tws float64) float64 {
+	// Below the first tabulated angle the boat is close-hauled: use beat VMG
+	minAngle := tp.Angles[0]
+	if absTWA < minAngle {
+		// Find wind speed index
+		windIndex := tp.findWindIndex(tws)
+		beatAngle := tp.interpolateFloat(tws, tp.BeatAngles, windIndex)
+
+		if absTWA < beatAngle {
+			// Instead of hard cutoff, interpolate from 0 speed at TWA=0 to beat speed at beat angle
+			// Using quadratic curve for more aggressive tapering near zero
+			vmg := tp.interpolateFloat(tws, tp.BeatVMG, windIndex)
+			// Convert VMG to actual boat speed: Speed = VMG / cos(beat angle)
+			beatAngleRad := beatAngle * math.Pi / 180
+			beatSpeed := vmg / math.Cos(beatAngleRad)
+			// Quadratic interpolation: factor^2 gives more aggressive tapering
+			factor := absTWA / beatAngle
+			return beatSpeed * factor * factor
+		}
+
+		// Interpolate between beat angle and the first tabulated angle
+		vmg := tp.interpolateFloat(tws, tp.BeatVMG, windIndex)
+		// Convert VMG to actual boat speed at beat angle
+		beatAngleRad := beatAngle * math.Pi / 180
+		beatSpeed := vmg / math.Cos(beatAngleRad)
+		speedMin := tp.getSpeedAtAngle(minAngle, tws)
+
+		// Linear interpolation between beat angle and the first tabulated angle
+		factor := (absTWA - beatAngle) / (minAngle - beatAngle)
+		return beatSpeed + (speedMin-beatSpeed)*factor
+	}
+
+	// For wider angles, use the speed table
+	return tp.getSpeedAtAngle(absTWA, tws)
+}
+
+// Helper function to find the appropriate wind speed index
+func (tp *TablePolar) findWindIndex(tws float64) int {
+	for i := 0; i < len(tp.WindSpeeds)-1; i++ {
+		if tws <= tp.WindSpeeds[i+1] {
+			return i
+		}
+	}
+	return len(tp.WindSpeeds) - 2 // Return second to last index for extrapolation
+}
+
+// Helper function to interpolate a float value from an array
+func (tp *TablePolar) interpolateFloat(tws float64, values []float64, windIndex int) float64 {
+	if windIndex >= len(tp.WindSpeeds)-1 {
+		return values[len(values)-1]
+	}
+
+	w1, w2 := tp.WindSpeeds[windIndex], tp.WindSpeeds[windIndex+1]
+	v1, v2 := values[windIndex], values[windIndex+1]
+
+	// Prevent division by zero
+	if w2 == w1 {
+		return v1
+	}
+
+	factor := (tws - w1) / (w2 - w1)
+	result := v1 + (v2-v1)*factor
+
+	// Validate result to prevent NaN propagation
+	if math.IsNaN(result) || math.IsInf(result, 0) || result < 0 {
+		return v1 // Return safe fallback value
+	}
+
+	return result
+}
+
+// Helper function to get speed at a specific angle
+func (tp *TablePolar) getSpeedAtAngle(twa, tws float64) float64 {
+	windIndex := tp.findWindIndex(tws)
+
+	// Find angle index
+	angleIndex := 0
+	for i := 0; i < len(tp.Angles)-1; i++ {
+		if twa <= tp.Angles[i+1] {
+			angleIndex = i
+			break
+		}
+	}
+
+	if angleIndex >= len(tp.Angles)-1 {
+		angleIndex = len(tp.Angles) - 2
+	}
+
+	// Interpolate between wind speeds
+	speed1 := tp.interpolateAngle(twa, tp.SpeedTable[windIndex], angleIndex)
+	speed2 := tp.interpolateAngle(twa, tp.SpeedTable[windIndex+1], angleIndex)
+
+	// Interpolate between the two wind speed results
+	w1, w2 := tp.WindSpeeds[windIndex], tp.WindSpeeds[windIndex+1]
+	factor := (tws - w1) / (w2 - w1)
+
+	return speed1 + (speed2-speed1)*factor
+}
+
+// Helper function to interpolate between angles
+func (tp *TablePolar) interpolateAngle(twa float64, speeds []float64, angleIndex int) float64 {
+	if angleIndex >= len(tp.Angles)-1 {
+		return speeds[len(speeds)-1]
+	}
+
+	a1, a2 := tp.Angles[angleIndex], tp.Angles[angleIndex+1]
+	s1, s2 := speeds[angleIndex], speeds[angleIndex+1]
+
+	// Prevent division by zero
+	if a2 == a1 {
+		return s1
+	}
+
+	factor := (twa - a1) / (a2 - a1)
+	result := s1 + (s2-s1)*factor
+
+	// Validate result to prevent NaN propagation
+	if math.IsNaN(result) || math.IsInf(result, 0) || result < 0 {
+		return s1 // Return safe fallback value
+	}
+
+	return result
+}
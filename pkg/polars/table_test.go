@@ -0,0 +1,136 @@
+package polars
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTablePolar_RoundTripsRealisticPolar(t *testing.T) {
+	data, err := json.Marshal(realisticTable)
+	if err != nil {
+		t.Fatalf("Failed to encode polar: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "realistic.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write polar file: %v", err)
+	}
+	loaded, err := LoadPolarFromJSON(path)
+	if err != nil {
+		t.Fatalf("Failed to load polar: %v", err)
+	}
+
+	realistic := &RealisticPolar{}
+	for tws := 3.0; tws <= 26; tws += 0.5 {
+		for twa := -180.0; twa <= 180; twa += 2.5 {
+			want := realistic.GetBoatSpeed(twa, tws)
+			if got := loaded.GetBoatSpeed(twa, tws); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("GetBoatSpeed(%.1f, %.1f) = %.4f from JSON, want %.4f", twa, tws, got, want)
+			}
+		}
+	}
+}
+
+func TestTablePolar_MatchesTabulatedSpeeds(t *testing.T) {
+	tests := []struct {
+		twa, tws, want float64
+	}{
+		{90, 10, 7.13},
+		{52, 4, 3.73},
+		{-135, 24, 10.85},
+		{180, 16, 4.95},
+		{82.5, 10, (6.93 + 7.13) / 2}, // Halfway between 75° and 90°
+	}
+	for _, tt := range tests {
+		if got := (&RealisticPolar{}).GetBoatSpeed(tt.twa, tt.tws); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("GetBoatSpeed(%.1f, %.1f) = %.4f, want %.4f", tt.twa, tt.tws, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPolar_RejectsMismatchedTable(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"Not JSON", "{windSpeeds"},
+		{"Single wind speed", `{"windSpeeds":[10],"angles":[60,90],"speedTable":[[6,7]],"beatVMG":[4],"beatAngles":[40]}`},
+		{"Missing row", `{"windSpeeds":[6,10],"angles":[60,90],"speedTable":[[5,6]],"beatVMG":[3,4],"beatAngles":[42,40]}`},
+		{"Short row", `{"windSpeeds":[6,10],"angles":[60,90],"speedTable":[[5,6],[7]],"beatVMG":[3,4],"beatAngles":[42,40]}`},
+		{"Short beat data", `{"windSpeeds":[6,10],"angles":[60,90],"speedTable":[[5,6],[6,7]],"beatVMG":[3],"beatAngles":[42,40]}`},
+		{"Descending wind speeds", `{"windSpeeds":[10,6],"angles":[60,90],"speedTable":[[6,7],[5,6]],"beatVMG":[4,3],"beatAngles":[40,42]}`},
+		{"Repeated wind speed", `{"windSpeeds":[6,6],"angles":[60,90],"speedTable":[[5,6],[6,7]],"beatVMG":[3,4],"beatAngles":[42,40]}`},
+		{"Descending angles", `{"windSpeeds":[6,10],"angles":[90,60],"speedTable":[[6,5],[7,6]],"beatVMG":[3,4],"beatAngles":[42,40]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadPolar(strings.NewReader(tt.json)); err == nil {
+				t.Error("Expected an error for an invalid polar table")
+			}
+		})
+	}
+
+	valid := `{"windSpeeds":[6,10],"angles":[60,90],"speedTable":[[5,6],[6,7]],"beatVMG":[3,4],"beatAngles":[42,40]}`
+	polar, err := LoadPolar(bytes.NewBufferString(valid))
+	if err != nil {
+		t.Fatalf("Expected a minimal table to load, got %v", err)
+	}
+	if got := polar.GetBoatSpeed(90, 8); math.Abs(got-6.5) > 1e-9 {
+		t.Errorf("Expected 6.5 kts at 90° in 8 kts, got %.2f", got)
+	}
+}
+
+func TestTablePolar_ExtrapolatesBeyondTabulatedWinds(t *testing.T) {
+	realistic := &RealisticPolar{}
+	winds := []float64{2, 4, 24, 28, 40}
+
+	for _, twa := range []float64{30, 45, 90, 135, 180} {
+		prev := 0.0
+		for _, tws := range winds {
+			speed := realistic.GetBoatSpeed(twa, tws)
+			if math.IsNaN(speed) || math.IsInf(speed, 0) || speed <= 0 {
+				t.Fatalf("GetBoatSpeed(%.0f, %.0f) = %v, want a finite positive speed", twa, tws, speed)
+			}
+			if speed < prev {
+				t.Errorf("GetBoatSpeed(%.0f, %.0f) = %.2f, slower than %.2f in less wind", twa, tws, speed, prev)
+			}
+			prev = speed
+		}
+
+		// Light air scales toward zero, and a gale doesn't run away
+		if light, top := realistic.GetBoatSpeed(twa, 2), realistic.GetBoatSpeed(twa, 4); math.Abs(light-top/2) > 1e-9 {
+			t.Errorf("GetBoatSpeed(%.0f, 2) = %.2f, want half of %.2f at 4 kt", twa, light, top)
+		}
+		if gale, top := realistic.GetBoatSpeed(twa, 40), realistic.GetBoatSpeed(twa, 24); gale > top*1.5 {
+			t.Errorf("GetBoatSpeed(%.0f, 40) = %.2f, want no more than 1.5x the %.2f at 24 kt", twa, gale, top)
+		}
+	}
+
+	if speed := realistic.GetBoatSpeed(90, 0); speed != 0 {
+		t.Errorf("GetBoatSpeed(90, 0) = %.2f, want 0 in no wind", speed)
+	}
+}
+
+func TestTablePolar_NoSpeedWithoutWind(t *testing.T) {
+	for _, p := range []Polars{&RealisticPolar{}, &DinghyPolar{}} {
+		for tws := -5.0; tws <= 4; tws += 0.25 {
+			for twa := -180.0; twa <= 180; twa += 5 {
+				speed := p.GetBoatSpeed(twa, tws)
+				if math.IsNaN(speed) || math.IsInf(speed, 0) || speed < 0 {
+					t.Fatalf("%T.GetBoatSpeed(%.0f, %.2f) = %v, want a finite speed >= 0", p, twa, tws, speed)
+				}
+				if tws <= 0 && speed != 0 {
+					t.Fatalf("%T.GetBoatSpeed(%.0f, %.2f) = %.2f, want 0 in no wind", p, twa, tws, speed)
+				}
+			}
+		}
+	}
+	if speed := (&RealisticPolar{}).GetBoatSpeed(90, math.NaN()); speed != 0 {
+		t.Errorf("GetBoatSpeed(90, NaN) = %v, want 0", speed)
+	}
+}